@@ -0,0 +1,163 @@
+// Package amdsmicli implements an AMD GPU telemetry backend running the amd-smi
+// command line tool and parsing its json output.
+package amdsmicli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "amdsmi"
+
+const amdSMICommand string = "amd-smi"
+
+const (
+	// defaultTimeout bounds amd-smi commands when no timeout is configured.
+	defaultTimeout = 10 * time.Second
+	// waitDelay is the time given to amd-smi output to be closed once the command is killed.
+	waitDelay = time.Second
+)
+
+// Backend reads AMD GPU metrics from amd-smi command line tool.
+type Backend struct {
+	logger *slog.Logger
+	// static readings taken when devices are discovered.
+	static *Static
+	// timeout bounds the amd-smi commands run for a reading.
+	timeout time.Duration
+}
+
+// NewBackend creates an amd-smi backend, amd-smi must be available in PATH.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	_, err := exec.LookPath(amdSMICommand)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find %s command: %w", amdSMICommand, err)
+	}
+
+	timeout := settings.AMDSMITimeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+
+	newBackend := Backend{
+		logger:  settings.Logger,
+		timeout: timeout,
+	}
+
+	return &newBackend, nil
+}
+
+// Devices gets GPU cards information from amd-smi static command.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	static, err := b.readStatic(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return static.Cards, nil
+}
+
+// ReadGPUs reads GPU metrics from amd-smi metric and xgmi commands, device ids, power
+// caps, maximum pcie link state and temperature limits are taken from amd-smi static command.
+// Commands share the configured timeout, so a hung amd-smi does not block the scrape.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+
+	if b.static == nil {
+		_, err := b.readStatic(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	stat.ResizeGPUs(b.static.NumGPUs)
+	copy(stat.GPUDevID, b.static.DevID)
+	copy(stat.GPUPowerCap, b.static.PowerCap)
+	copy(stat.GPUPCIeMaxSpeed, b.static.MaxPCIeSpeed)
+	copy(stat.GPUPCIeMaxWidth, b.static.MaxPCIeWidth)
+
+	for sensor := range gpus.NumTemperatureSensors {
+		copy(stat.GPUTemperatureCritical[sensor], b.static.TemperatureCritical[sensor])
+		copy(stat.GPUTemperatureEmergency[sensor], b.static.TemperatureEmergency[sensor])
+	}
+
+	output, err := b.run(ctx, "metric")
+	if err != nil {
+		return err
+	}
+
+	err = ParseMetrics(output, stat)
+	if err != nil {
+		return fmt.Errorf("reading amd-smi metrics: %w", err)
+	}
+
+	b.readXGMI(ctx, stat)
+
+	return nil
+}
+
+// readXGMI reads XGMI links from amd-smi xgmi command, links are left unsupported
+// when the command fails, e.g. releases without xgmi metrics.
+func (b *Backend) readXGMI(ctx context.Context, stat *gpus.AMDParams) {
+	output, err := b.run(ctx, "xgmi")
+	if err == nil {
+		err = ParseXGMI(output, stat)
+	}
+
+	if err != nil {
+		b.logger.Debug("unable to read xgmi links", slog.String("error", err.Error()))
+	}
+}
+
+// readStatic runs amd-smi static command and keeps its readings.
+func (b *Backend) readStatic(ctx context.Context) (*Static, error) {
+	output, err := b.run(ctx, "static")
+	if err != nil {
+		return nil, err
+	}
+
+	static, err := ParseStatic(output)
+	if err != nil {
+		return nil, fmt.Errorf("reading amd-smi static information: %w", err)
+	}
+
+	b.static = static
+
+	return static, nil
+}
+
+// run executes amd-smi subcommand with json output, the command is killed when the given context is done.
+func (b *Backend) run(ctx context.Context, subcommand string) ([]byte, error) {
+	b.logger.Debug("running amd-smi", slog.String("subcommand", subcommand))
+
+	cmd := exec.CommandContext(ctx, amdSMICommand, subcommand, "--json")
+	cmd.WaitDelay = waitDelay
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to run %s %s: %w", amdSMICommand, subcommand, err)
+	}
+
+	return output, nil
+}
+
+// ReadCPUs is not supported by this backend.
+func (b *Backend) ReadCPUs(_ *gpus.AMDParams) error {
+	return fmt.Errorf("amd-smi backend reading cpu metrics: %w", amd.ErrNotSupported)
+}
+
+// Close does nothing, amd-smi is executed on every reading.
+func (b *Backend) Close() error {
+	return nil
+}
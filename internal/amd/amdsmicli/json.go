@@ -0,0 +1,593 @@
+package amdsmicli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+/* amd-smi metric --json output sample (ROCm 6.1+), older releases print values
+as plain numbers or strings with units, e.g. "98 W", and newer releases wrap the
+list of GPUs within a "gpu_data" object.
+[
+    {
+        "gpu": 0,
+        "usage": {
+            "gfx_activity": {"value": 85, "unit": "%"},
+            "umc_activity": {"value": 43, "unit": "%"},
+            "mm_activity": "N/A"
+        },
+        "power": {
+            "socket_power": {"value": 612, "unit": "W"},
+            "power_management": "ENABLED"
+        },
+        "clock": {
+            "gfx_0": {"clk": {"value": 2100, "unit": "MHz"}},
+            "mem_0": {"clk": {"value": 1300, "unit": "MHz"}}
+        },
+        "temperature": {
+            "edge": {"value": 44, "unit": "C"},
+            "hotspot": {"value": 61, "unit": "C"},
+            "mem": {"value": 39, "unit": "C"}
+        }
+    }
+]
+*/
+
+var errUnexpectedJSON = errors.New("unexpected amd-smi json output")
+
+// unit conversion factors to the units used by gpus.AMDParams.
+var (
+	powerUnits = map[string]float64{
+		"":   1e6,
+		"W":  1e6,
+		"mW": 1e3,
+		"uW": 1,
+	}
+	temperatureUnits = map[string]float64{
+		"":   1e3,
+		"C":  1e3,
+		"°C": 1e3,
+	}
+	clockUnits = map[string]float64{
+		"":    1e6,
+		"MHz": 1e6,
+		"GHz": 1e9,
+		"Hz":  1,
+	}
+	percentUnits = map[string]float64{
+		"":  1,
+		"%": 1,
+	}
+	countUnits = map[string]float64{
+		"": 1,
+	}
+	fanSpeedUnits = map[string]float64{
+		"":    1,
+		"RPM": 1,
+	}
+	voltageUnits = map[string]float64{
+		"":   1,
+		"mV": 1,
+		"V":  1e3,
+	}
+	energyUnits = map[string]float64{
+		"":   1e6,
+		"J":  1e6,
+		"mJ": 1e3,
+		"uJ": 1,
+	}
+	linkSpeedUnits = map[string]float64{
+		"":     1,
+		"GT/s": 1,
+	}
+	bandwidthUnits = map[string]float64{
+		"":     1e6,
+		"Mb/s": 1e6 / 8,
+		"MB/s": 1e6,
+		"GB/s": 1e9,
+	}
+	// amd-smi reports memory in mebibytes labelled as MB.
+	memoryUnits = map[string]float64{
+		"":   1 << 20,
+		"B":  1,
+		"KB": 1 << 10,
+		"MB": 1 << 20,
+		"GB": 1 << 30,
+	}
+)
+
+// value is an amd-smi json value, it could be a number, a string such as "N/A" or "98 W",
+// or an object containing value and unit.
+type value struct {
+	Number float64
+	Unit   string
+	Valid  bool
+}
+
+// UnmarshalJSON decodes any of the value formats printed by amd-smi releases.
+func (v *value) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if data[0] != '{' {
+		return v.unmarshalScalar(data)
+	}
+
+	var object struct {
+		Value json.RawMessage `json:"value"`
+		Unit  string          `json:"unit"`
+	}
+
+	err := json.Unmarshal(data, &object)
+	if err != nil {
+		return fmt.Errorf("decoding amd-smi value object: %w", err)
+	}
+
+	err = v.unmarshalScalar(object.Value)
+	if err != nil {
+		return err
+	}
+
+	if object.Unit != "" {
+		v.Unit = object.Unit
+	}
+
+	return nil
+}
+
+// unmarshalScalar decodes numbers and strings, strings that are not numbers are considered unsupported values.
+func (v *value) unmarshalScalar(data []byte) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+
+	if data[0] != '"' {
+		err := json.Unmarshal(data, &v.Number)
+		if err != nil {
+			return fmt.Errorf("decoding amd-smi number: %w", err)
+		}
+
+		v.Valid = true
+
+		return nil
+	}
+
+	var text string
+
+	err := json.Unmarshal(data, &text)
+	if err != nil {
+		return fmt.Errorf("decoding amd-smi string: %w", err)
+	}
+
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	// values such as "N/A" are not supported by the device.
+	number, ok := parseNumber(fields[0])
+	if !ok {
+		return nil
+	}
+
+	v.Number = number
+	v.Valid = true
+
+	if len(fields) > 1 {
+		v.Unit = fields[1]
+	}
+
+	return nil
+}
+
+// parseNumber parses given text as a float number.
+func parseNumber(text string) (float64, bool) {
+	number, err := strconv.ParseFloat(text, 64)
+
+	return number, err == nil
+}
+
+// convert returns the value in the unit expected by gpus.AMDParams based on given conversion factors.
+func (v value) convert(factors map[string]float64) (float64, bool) {
+	if !v.Valid {
+		return 0, false
+	}
+
+	factor, exist := factors[v.Unit]
+	if !exist {
+		return 0, false
+	}
+
+	return v.Number * factor, true
+}
+
+// gpuList decodes amd-smi json output which could be a list of GPUs or an object containing the list.
+type gpuList[T any] []T
+
+// UnmarshalJSON decodes a list of GPUs or a "gpu_data" or "xgmi_metric" object.
+func (l *gpuList[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return errUnexpectedJSON
+	}
+
+	if data[0] == '[' {
+		var list []T
+
+		err := json.Unmarshal(data, &list)
+		if err != nil {
+			return fmt.Errorf("decoding amd-smi gpu list: %w", err)
+		}
+
+		*l = list
+
+		return nil
+	}
+
+	var wrapper struct {
+		GPUData []T `json:"gpu_data"`
+		// XGMIMetric contains the list of GPUs printed by amd-smi xgmi command.
+		XGMIMetric []T `json:"xgmi_metric"`
+	}
+
+	err := json.Unmarshal(data, &wrapper)
+	if err != nil {
+		return fmt.Errorf("decoding amd-smi gpu list object: %w", err)
+	}
+
+	*l = wrapper.GPUData
+	if wrapper.XGMIMetric != nil {
+		*l = wrapper.XGMIMetric
+	}
+
+	return nil
+}
+
+// staticGPU contains the fields used from amd-smi static --json output.
+type staticGPU struct {
+	GPU  int `json:"gpu"`
+	ASIC struct {
+		MarketName            string `json:"market_name"`
+		VendorName            string `json:"vendor_name"`
+		DeviceID              string `json:"device_id"`
+		TargetGraphicsVersion string `json:"target_graphics_version"`
+	} `json:"asic"`
+	Bus struct {
+		BDF          string `json:"bdf"`
+		MaxPCIeSpeed value  `json:"max_pcie_speed"`
+		MaxPCIeWidth value  `json:"max_pcie_width"`
+		// MaxPCIeLanes replaced by max_pcie_width since ROCm 6.1.
+		MaxPCIeLanes value `json:"max_pcie_lanes"`
+	} `json:"bus"`
+	VBIOS firmwareImage `json:"vbios"`
+	// IFWI replaces vbios section in newer releases.
+	IFWI   firmwareImage `json:"ifwi"`
+	Driver struct {
+		Version string `json:"version"`
+		// DriverVersion replaced by version since ROCm 6.1.
+		DriverVersion string `json:"driver_version"`
+	} `json:"driver"`
+	Limit struct {
+		SocketPower                value `json:"socket_power"`
+		SlowdownEdgeTemperature    value `json:"slowdown_edge_temperature"`
+		SlowdownHotspotTemperature value `json:"slowdown_hotspot_temperature"`
+		SlowdownVRAMTemperature    value `json:"slowdown_vram_temperature"`
+		ShutdownEdgeTemperature    value `json:"shutdown_edge_temperature"`
+		ShutdownHotspotTemperature value `json:"shutdown_hotspot_temperature"`
+		ShutdownVRAMTemperature    value `json:"shutdown_vram_temperature"`
+	} `json:"limit"`
+	// Partition is printed since ROCm 6.1, modes are "N/A" for GPUs that could not be partitioned.
+	Partition struct {
+		ComputePartition string `json:"compute_partition"`
+		MemoryPartition  string `json:"memory_partition"`
+		PartitionID      value  `json:"partition_id"`
+	} `json:"partition"`
+}
+
+// firmwareImage contains vbios or ifwi information.
+type firmwareImage struct {
+	PartNumber string `json:"part_number"`
+	Version    string `json:"version"`
+}
+
+// metricGPU contains the fields used from amd-smi metric --json output.
+type metricGPU struct {
+	GPU   int `json:"gpu"`
+	Usage struct {
+		GFXActivity value `json:"gfx_activity"`
+		UMCActivity value `json:"umc_activity"`
+	} `json:"usage"`
+	Power struct {
+		SocketPower value `json:"socket_power"`
+		// AverageSocketPower replaced by socket_power since ROCm 6.1.
+		AverageSocketPower value `json:"average_socket_power"`
+		GFXVoltage         value `json:"gfx_voltage"`
+		SoCVoltage         value `json:"soc_voltage"`
+		MemVoltage         value `json:"mem_voltage"`
+	} `json:"power"`
+	Clock struct {
+		GFX clock `json:"gfx_0"`
+		Mem clock `json:"mem_0"`
+	} `json:"clock"`
+	Temperature struct {
+		Edge    value `json:"edge"`
+		Hotspot value `json:"hotspot"`
+		Mem     value `json:"mem"`
+	} `json:"temperature"`
+	Fan struct {
+		RPM   value `json:"rpm"`
+		Usage value `json:"usage"`
+	} `json:"fan"`
+	Energy struct {
+		TotalEnergyConsumption value `json:"total_energy_consumption"`
+	} `json:"energy"`
+	MemUsage struct {
+		TotalVRAM       value `json:"total_vram"`
+		UsedVRAM        value `json:"used_vram"`
+		UsedVisibleVRAM value `json:"used_visible_vram"`
+		UsedGTT         value `json:"used_gtt"`
+	} `json:"mem_usage"`
+	ECCBlocks eccBlocks `json:"ecc_blocks"`
+	PCIe      struct {
+		Width            value `json:"width"`
+		Speed            value `json:"speed"`
+		Bandwidth        value `json:"bandwidth"`
+		ReplayCount      value `json:"replay_count"`
+		NAKSentCount     value `json:"nak_sent_count"`
+		NAKReceivedCount value `json:"nak_received_count"`
+	} `json:"pcie"`
+}
+
+// eccBlocks contains ecc error counts indexed by amd-smi block name, e.g. UMC.
+type eccBlocks map[string]eccCounts
+
+// eccCounts contains ecc error counts of a block.
+type eccCounts struct {
+	CorrectableCount   value `json:"correctable_count"`
+	UncorrectableCount value `json:"uncorrectable_count"`
+}
+
+// UnmarshalJSON decodes ecc blocks object, any other value such as "N/A" means
+// that ecc is not supported by the device.
+func (b *eccBlocks) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil
+	}
+
+	var blocks map[string]eccCounts
+
+	err := json.Unmarshal(data, &blocks)
+	if err != nil {
+		return fmt.Errorf("decoding amd-smi ecc blocks: %w", err)
+	}
+
+	*b = blocks
+
+	return nil
+}
+
+// eccBlockNames contains amd-smi block names indexed by block.
+var eccBlockNames = [gpus.NumRASBlocks]string{
+	gpus.RASBlockUMC:   "UMC",
+	gpus.RASBlockGFX:   "GFX",
+	gpus.RASBlockSDMA:  "SDMA",
+	gpus.RASBlockMMHUB: "MMHUB",
+	gpus.RASBlockXGMI:  "XGMI_WAFL",
+}
+
+// clock contains a clock domain reading.
+type clock struct {
+	Clk value `json:"clk"`
+}
+
+// Static contains GPU inventory and static readings parsed from amd-smi static --json output.
+type Static struct {
+	Cards        []gpus.Card
+	DevID        []gpus.Reading
+	PowerCap     []gpus.Reading
+	MaxPCIeSpeed []gpus.Reading
+	MaxPCIeWidth []gpus.Reading
+	// TemperatureCritical and TemperatureEmergency are the slowdown and shutdown
+	// temperatures indexed by sensor and then by GPU index.
+	TemperatureCritical  [gpus.NumTemperatureSensors][]gpus.Reading
+	TemperatureEmergency [gpus.NumTemperatureSensors][]gpus.Reading
+	NumGPUs              uint
+}
+
+// ParseStatic parses amd-smi static --json output.
+func ParseStatic(data []byte) (*Static, error) {
+	var list gpuList[staticGPU]
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse amd-smi static output: %w", err)
+	}
+
+	var result Static
+
+	for _, gpu := range list {
+		result.NumGPUs = max(result.NumGPUs, uint(max(gpu.GPU+1, 0)))
+	}
+
+	result.Cards = make([]gpus.Card, result.NumGPUs)
+	result.DevID = make([]gpus.Reading, result.NumGPUs)
+	result.PowerCap = make([]gpus.Reading, result.NumGPUs)
+	result.MaxPCIeSpeed = make([]gpus.Reading, result.NumGPUs)
+	result.MaxPCIeWidth = make([]gpus.Reading, result.NumGPUs)
+
+	for sensor := range gpus.NumTemperatureSensors {
+		result.TemperatureCritical[sensor] = make([]gpus.Reading, result.NumGPUs)
+		result.TemperatureEmergency[sensor] = make([]gpus.Reading, result.NumGPUs)
+	}
+
+	for _, gpu := range list {
+		if gpu.GPU < 0 {
+			continue
+		}
+
+		firmware := gpu.IFWI
+		if firmware.PartNumber == "" {
+			firmware = gpu.VBIOS
+		}
+
+		driverVersion := gpu.Driver.Version
+		if driverVersion == "" {
+			driverVersion = gpu.Driver.DriverVersion
+		}
+
+		result.Cards[gpu.GPU] = gpus.Card{
+			Cardseries:    gpu.ASIC.MarketName,
+			Cardmodel:     gpu.ASIC.DeviceID,
+			Cardvendor:    gpu.ASIC.VendorName,
+			CardSKU:       firmware.PartNumber,
+			PCIBus:        gpu.Bus.BDF,
+			VBIOSVersion:  firmware.PartNumber,
+			DriverVersion: driverVersion,
+			GFXVersion:    gpu.ASIC.TargetGraphicsVersion,
+
+			ComputePartition: partitionMode(gpu.Partition.ComputePartition),
+			MemoryPartition:  partitionMode(gpu.Partition.MemoryPartition),
+			PartitionID:      int(gpu.Partition.PartitionID.Number),
+		}
+
+		deviceID, err := strconv.ParseUint(strings.TrimPrefix(gpu.ASIC.DeviceID, "0x"), 16, 16)
+		if err == nil {
+			result.DevID[gpu.GPU] = gpus.NewReading(float64(deviceID))
+		}
+
+		setValue(&result.PowerCap[gpu.GPU], gpu.Limit.SocketPower, powerUnits)
+		setValue(&result.MaxPCIeSpeed[gpu.GPU], gpu.Bus.MaxPCIeSpeed, linkSpeedUnits)
+
+		maxWidth := gpu.Bus.MaxPCIeWidth
+		if !maxWidth.Valid {
+			maxWidth = gpu.Bus.MaxPCIeLanes
+		}
+
+		setValue(&result.MaxPCIeWidth[gpu.GPU], maxWidth, countUnits)
+
+		critical := [gpus.NumTemperatureSensors]value{
+			gpus.TemperatureSensorEdge:     gpu.Limit.SlowdownEdgeTemperature,
+			gpus.TemperatureSensorJunction: gpu.Limit.SlowdownHotspotTemperature,
+			gpus.TemperatureSensorMemory:   gpu.Limit.SlowdownVRAMTemperature,
+		}
+		emergency := [gpus.NumTemperatureSensors]value{
+			gpus.TemperatureSensorEdge:     gpu.Limit.ShutdownEdgeTemperature,
+			gpus.TemperatureSensorJunction: gpu.Limit.ShutdownHotspotTemperature,
+			gpus.TemperatureSensorMemory:   gpu.Limit.ShutdownVRAMTemperature,
+		}
+
+		for sensor := range gpus.NumTemperatureSensors {
+			setValue(&result.TemperatureCritical[sensor][gpu.GPU], critical[sensor], temperatureUnits)
+			setValue(&result.TemperatureEmergency[sensor][gpu.GPU], emergency[sensor], temperatureUnits)
+		}
+	}
+
+	return &result, nil
+}
+
+// partitionMode returns the given amd-smi partition mode, empty when it is not available.
+func partitionMode(mode string) string {
+	if mode == "N/A" {
+		return ""
+	}
+
+	return mode
+}
+
+// ParseMetrics parses amd-smi metric --json output filling given params,
+// unsupported readings are left untouched.
+func ParseMetrics(data []byte, stat *gpus.AMDParams) error {
+	var list gpuList[metricGPU]
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return fmt.Errorf("unable to parse amd-smi metric output: %w", err)
+	}
+
+	for _, gpu := range list {
+		i := gpu.GPU
+		if i < 0 {
+			continue
+		}
+
+		if uint(i) >= stat.NumGPUs {
+			stat.ResizeGPUs(uint(i) + 1)
+		}
+
+		setValue(&stat.GPUUsage[i], gpu.Usage.GFXActivity, percentUnits)
+		setValue(&stat.GPUMemoryUsage[i], gpu.Usage.UMCActivity, percentUnits)
+		setValue(&stat.GPUSCLK[i], gpu.Clock.GFX.Clk, clockUnits)
+		setValue(&stat.GPUMCLK[i], gpu.Clock.Mem.Clk, clockUnits)
+
+		power := gpu.Power.SocketPower
+		if !power.Valid {
+			power = gpu.Power.AverageSocketPower
+		}
+
+		setValue(&stat.GPUPower[i], power, powerUnits)
+		setValue(&stat.GPUEnergy[i], gpu.Energy.TotalEnergyConsumption, energyUnits)
+
+		// edge sensor is preferred and junction (hotspot) is used when it is not available.
+		temperature := gpu.Temperature.Edge
+		if !temperature.Valid {
+			temperature = gpu.Temperature.Hotspot
+		}
+
+		setValue(&stat.GPUTemperature[i], temperature, temperatureUnits)
+		setValue(&stat.GPUTemperatures[gpus.TemperatureSensorEdge][i], gpu.Temperature.Edge, temperatureUnits)
+		setValue(&stat.GPUTemperatures[gpus.TemperatureSensorJunction][i], gpu.Temperature.Hotspot, temperatureUnits)
+		setValue(&stat.GPUTemperatures[gpus.TemperatureSensorMemory][i], gpu.Temperature.Mem, temperatureUnits)
+
+		setValue(&stat.GPUFanSpeed[i], gpu.Fan.RPM, fanSpeedUnits)
+		setValue(&stat.GPUFanSpeedPercent[i], gpu.Fan.Usage, percentUnits)
+		setValue(&stat.GPUVoltage[gpus.VoltageRailGFX][i], gpu.Power.GFXVoltage, voltageUnits)
+		setValue(&stat.GPUVoltage[gpus.VoltageRailSoC][i], gpu.Power.SoCVoltage, voltageUnits)
+		setValue(&stat.GPUVoltage[gpus.VoltageRailMemory][i], gpu.Power.MemVoltage, voltageUnits)
+
+		setValue(&stat.GPUVRAMTotal[i], gpu.MemUsage.TotalVRAM, memoryUnits)
+		setValue(&stat.GPUVRAMUsed[i], gpu.MemUsage.UsedVRAM, memoryUnits)
+		setValue(&stat.GPUVisVRAMUsed[i], gpu.MemUsage.UsedVisibleVRAM, memoryUnits)
+		setValue(&stat.GPUGTTUsed[i], gpu.MemUsage.UsedGTT, memoryUnits)
+
+		setValue(&stat.GPUPCIeSpeed[i], gpu.PCIe.Speed, linkSpeedUnits)
+		setValue(&stat.GPUPCIeWidth[i], gpu.PCIe.Width, countUnits)
+		setValue(&stat.GPUPCIeReplays[i], gpu.PCIe.ReplayCount, countUnits)
+		setValue(&stat.GPUPCIeNAKSent[i], gpu.PCIe.NAKSentCount, countUnits)
+		setValue(&stat.GPUPCIeNAKReceived[i], gpu.PCIe.NAKReceivedCount, countUnits)
+		setValue(&stat.GPUPCIeBandwidth[i], gpu.PCIe.Bandwidth, bandwidthUnits)
+
+		for block := range gpus.NumRASBlocks {
+			counts := gpu.ECCBlocks[eccBlockNames[block]]
+			setValue(&stat.GPUECCCorrectable[block][i], counts.CorrectableCount, countUnits)
+			setValue(&stat.GPUECCUncorrectable[block][i], counts.UncorrectableCount, countUnits)
+		}
+	}
+
+	return nil
+}
+
+// setValue sets converted value into target if it is valid, values given in
+// unknown units are set as failed readings.
+func setValue(target *gpus.Reading, v value, factors map[string]float64) {
+	if !v.Valid {
+		return
+	}
+
+	converted, ok := v.convert(factors)
+	if !ok {
+		*target = gpus.FailedReading()
+
+		return
+	}
+
+	*target = gpus.NewReading(converted)
+}
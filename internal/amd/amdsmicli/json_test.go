@@ -0,0 +1,473 @@
+package amdsmicli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/amdsmicli"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatic(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		release      string
+		wantCards    []gpus.Card
+		wantDevID    []gpus.Reading
+		wantPowerCap []gpus.Reading
+		wantMaxSpeed []gpus.Reading
+		wantMaxWidth []gpus.Reading
+	}{
+		{
+			release: "rocm-6.0.2",
+			wantCards: []gpus.Card{
+				{
+					Cardseries:    "AMD Instinct MI210",
+					Cardmodel:     "0x740f",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-D67301-063",
+					PCIBus:        "0000:03:00.0",
+					VBIOSVersion:  "113-D67301-063",
+					DriverVersion: "6.3.6",
+				},
+				{
+					Cardseries:    "AMD Instinct MI210",
+					Cardmodel:     "0x740f",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-D67301-063",
+					PCIBus:        "0000:83:00.0",
+					VBIOSVersion:  "113-D67301-063",
+					DriverVersion: "6.3.6",
+				},
+			},
+			wantDevID:    []gpus.Reading{gpus.NewReading(0x740f), gpus.NewReading(0x740f)},
+			wantPowerCap: []gpus.Reading{gpus.NewReading(300e6), gpus.Reading{}},
+			wantMaxSpeed: []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)},
+			wantMaxWidth: []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)},
+		},
+		{
+			release: "rocm-6.2.0",
+			wantCards: []gpus.Card{
+				{
+					Cardseries:    "AMD Instinct MI300X",
+					Cardmodel:     "0x74a1",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-M3000100-102",
+					PCIBus:        "0000:0c:00.0",
+					VBIOSVersion:  "113-M3000100-102",
+					DriverVersion: "6.7.0",
+					GFXVersion:    "gfx942",
+				},
+				{
+					Cardseries:    "AMD Instinct MI300X",
+					Cardmodel:     "0x74a1",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-M3000100-102",
+					PCIBus:        "0000:22:00.0",
+					VBIOSVersion:  "113-M3000100-102",
+					DriverVersion: "6.7.0",
+					GFXVersion:    "gfx942",
+				},
+			},
+			wantDevID:    []gpus.Reading{gpus.NewReading(0x74a1), gpus.NewReading(0x74a1)},
+			wantPowerCap: []gpus.Reading{gpus.NewReading(750e6), gpus.NewReading(750e6)},
+			wantMaxSpeed: []gpus.Reading{gpus.NewReading(32), gpus.NewReading(32)},
+			wantMaxWidth: []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)},
+		},
+		{
+			release: "rocm-6.4.0",
+			wantCards: []gpus.Card{
+				{
+					Cardseries:    "AMD Instinct MI300X",
+					Cardmodel:     "0x74a1",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-M3000100-102",
+					PCIBus:        "0000:0c:00.0",
+					VBIOSVersion:  "113-M3000100-102",
+					DriverVersion: "6.8.5",
+					GFXVersion:    "gfx942",
+
+					ComputePartition: "SPX",
+					MemoryPartition:  "NPS1",
+				},
+				{
+					Cardseries:    "AMD Instinct MI300X",
+					Cardmodel:     "0x74a1",
+					Cardvendor:    "Advanced Micro Devices Inc. [AMD/ATI]",
+					CardSKU:       "113-M3000100-102",
+					PCIBus:        "0000:22:00.0",
+					VBIOSVersion:  "113-M3000100-102",
+					DriverVersion: "6.8.5",
+					GFXVersion:    "gfx942",
+
+					ComputePartition: "SPX",
+					MemoryPartition:  "NPS1",
+				},
+			},
+			wantDevID:    []gpus.Reading{gpus.NewReading(0x74a1), gpus.NewReading(0x74a1)},
+			wantPowerCap: []gpus.Reading{gpus.NewReading(750e6), gpus.NewReading(750e6)},
+			wantMaxSpeed: []gpus.Reading{gpus.NewReading(32), gpus.NewReading(32)},
+			wantMaxWidth: []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			data := readFixture(t, tt.release, "static.json")
+
+			// When
+			got, err := amdsmicli.ParseStatic(data)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, uint(len(tt.wantCards)), got.NumGPUs)
+			assert.Equal(t, tt.wantCards, got.Cards)
+			assert.Equal(t, tt.wantDevID, got.DevID)
+			assert.Equal(t, tt.wantPowerCap, got.PowerCap)
+			assert.Equal(t, tt.wantMaxSpeed, got.MaxPCIeSpeed)
+			assert.Equal(t, tt.wantMaxWidth, got.MaxPCIeWidth)
+		})
+	}
+}
+
+func TestParseStaticTemperatureLimits(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := readFixture(t, "rocm-6.2.0", "static.json")
+
+	// When
+	got, err := amdsmicli.ParseStatic(data)
+
+	// Then
+	require.NoError(t, err)
+
+	for i := range got.NumGPUs {
+		assert.Equal(t, gpus.Reading{}, got.TemperatureCritical[gpus.TemperatureSensorEdge][i])
+		assert.Equal(t, gpus.NewReading(100e3), got.TemperatureCritical[gpus.TemperatureSensorJunction][i])
+		assert.Equal(t, gpus.NewReading(105e3), got.TemperatureCritical[gpus.TemperatureSensorMemory][i])
+		assert.Equal(t, gpus.Reading{}, got.TemperatureEmergency[gpus.TemperatureSensorEdge][i])
+		assert.Equal(t, gpus.NewReading(110e3), got.TemperatureEmergency[gpus.TemperatureSensorJunction][i])
+		assert.Equal(t, gpus.NewReading(115e3), got.TemperatureEmergency[gpus.TemperatureSensorMemory][i])
+	}
+}
+
+func TestParseMetrics(t *testing.T) {
+	t.Parallel()
+
+	type gpuReadings struct {
+		usage, memoryUsage, power, temperature, sclk, mclk gpus.Reading
+	}
+
+	valid := gpus.NewReading
+
+	tests := []struct {
+		release string
+		want    []gpuReadings
+	}{
+		{
+			release: "rocm-6.0.2",
+			want: []gpuReadings{
+				{usage: valid(37), memoryUsage: valid(12), power: valid(98e6), temperature: valid(41e3), sclk: valid(1700e6), mclk: valid(1600e6)},
+				// edge temperature is not available, hotspot is used instead.
+				{usage: valid(0), memoryUsage: valid(0), power: valid(41e6), temperature: valid(52e3), sclk: valid(500e6), mclk: gpus.Reading{}},
+			},
+		},
+		{
+			release: "rocm-6.2.0",
+			want: []gpuReadings{
+				{usage: valid(85), memoryUsage: valid(43), power: valid(612e6), temperature: valid(44e3), sclk: valid(2100e6), mclk: valid(1300e6)},
+				{usage: valid(0), memoryUsage: gpus.Reading{}, power: valid(138e6), temperature: valid(40e3), sclk: valid(132e6), mclk: valid(900e6)},
+			},
+		},
+		{
+			release: "rocm-6.4.0",
+			want: []gpuReadings{
+				{usage: valid(85), memoryUsage: valid(43), power: valid(612e6), temperature: valid(44e3), sclk: valid(2100e6), mclk: valid(1300e6)},
+				{usage: valid(0), memoryUsage: gpus.Reading{}, power: valid(138e6), temperature: valid(40e3), sclk: valid(132e6), mclk: valid(900e6)},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.release, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			data := readFixture(t, tt.release, "metric.json")
+
+			var stat gpus.AMDParams
+			stat.Init()
+
+			// When
+			err := amdsmicli.ParseMetrics(data, &stat)
+
+			// Then
+			require.NoError(t, err)
+			require.Equal(t, uint(len(tt.want)), stat.NumGPUs)
+
+			for i, want := range tt.want {
+				got := gpuReadings{
+					usage:       stat.GPUUsage[i],
+					memoryUsage: stat.GPUMemoryUsage[i],
+					power:       stat.GPUPower[i],
+					temperature: stat.GPUTemperature[i],
+					sclk:        stat.GPUSCLK[i],
+					mclk:        stat.GPUMCLK[i],
+				}
+				assert.Equal(t, want, got, "gpu %d", i)
+			}
+		})
+	}
+}
+
+func TestParseMetricsMemoryUsage(t *testing.T) {
+	t.Parallel()
+
+	type memoryReadings struct {
+		vramTotal, vramUsed, visVRAMUsed, gttUsed gpus.Reading
+	}
+
+	mebibytes := func(value float64) gpus.Reading { return gpus.NewReading(value * (1 << 20)) }
+
+	idle := memoryReadings{
+		vramTotal:   mebibytes(196592),
+		vramUsed:    mebibytes(283),
+		visVRAMUsed: mebibytes(283),
+		gttUsed:     mebibytes(21),
+	}
+
+	tests := map[string]struct {
+		release string
+		want    memoryReadings
+	}{
+		"memory usage not reported": {release: "rocm-6.0.2", want: memoryReadings{}},
+		"memory usage":              {release: "rocm-6.2.0", want: idle},
+		"memory usage in gpu_data":  {release: "rocm-6.4.0", want: idle},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			data := readFixture(t, tt.release, "metric.json")
+
+			var stat gpus.AMDParams
+			stat.Init()
+
+			// When
+			err := amdsmicli.ParseMetrics(data, &stat)
+
+			// Then
+			require.NoError(t, err)
+
+			for i := range stat.NumGPUs {
+				got := memoryReadings{
+					vramTotal:   stat.GPUVRAMTotal[i],
+					vramUsed:    stat.GPUVRAMUsed[i],
+					visVRAMUsed: stat.GPUVisVRAMUsed[i],
+					gttUsed:     stat.GPUGTTUsed[i],
+				}
+				assert.Equal(t, tt.want, got, "gpu %d", i)
+			}
+		})
+	}
+}
+
+func TestParseMetricsEnergy(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		release string
+		want    []gpus.Reading
+	}{
+		"energy not reported": {release: "rocm-6.0.2", want: []gpus.Reading{{}, {}}},
+		"energy":              {release: "rocm-6.2.0", want: []gpus.Reading{gpus.NewReading(4821.367e6), gpus.NewReading(3107.934e6)}},
+		"energy in gpu_data":  {release: "rocm-6.4.0", want: []gpus.Reading{gpus.NewReading(15392.512e6), gpus.NewReading(9918.27e6)}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			data := readFixture(t, tt.release, "metric.json")
+
+			var stat gpus.AMDParams
+			stat.Init()
+
+			// When
+			err := amdsmicli.ParseMetrics(data, &stat)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, stat.GPUEnergy)
+		})
+	}
+}
+
+func TestParseMetricsECCBlocks(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := readFixture(t, "rocm-6.4.0", "metric.json")
+
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	err := amdsmicli.ParseMetrics(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, gpus.NewReading(3), stat.GPUECCCorrectable[gpus.RASBlockUMC][0])
+	assert.Equal(t, gpus.NewReading(0), stat.GPUECCUncorrectable[gpus.RASBlockUMC][0])
+	assert.Equal(t, gpus.NewReading(1), stat.GPUECCCorrectable[gpus.RASBlockGFX][0])
+	assert.Equal(t, gpus.NewReading(1), stat.GPUECCUncorrectable[gpus.RASBlockGFX][0])
+	assert.Equal(t, gpus.NewReading(0), stat.GPUECCCorrectable[gpus.RASBlockXGMI][0])
+	// second gpu does not support ecc.
+	assert.Equal(t, gpus.Reading{}, stat.GPUECCCorrectable[gpus.RASBlockUMC][1])
+}
+
+func TestParseMetricsPCIe(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := readFixture(t, "rocm-6.2.0", "metric.json")
+
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	err := amdsmicli.ParseMetrics(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+
+	for i := range stat.NumGPUs {
+		assert.Equal(t, gpus.NewReading(32), stat.GPUPCIeSpeed[i])
+		assert.Equal(t, gpus.NewReading(16), stat.GPUPCIeWidth[i])
+		assert.Equal(t, gpus.NewReading(0), stat.GPUPCIeReplays[i])
+		assert.Equal(t, gpus.NewReading(0), stat.GPUPCIeNAKSent[i])
+		assert.Equal(t, gpus.NewReading(0), stat.GPUPCIeNAKReceived[i])
+		// bandwidth is not available.
+		assert.Equal(t, gpus.Reading{}, stat.GPUPCIeBandwidth[i])
+	}
+}
+
+func TestParseXGMI(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := readFixture(t, "rocm-6.4.0", "xgmi.json")
+
+	var stat gpus.AMDParams
+	stat.Init()
+	stat.ResizeGPUs(2)
+
+	want := [][]gpus.XGMILink{
+		{{Peer: 1, ReadBytes: gpus.NewReading(1 << 20), WriteBytes: gpus.NewReading(2 << 20)}},
+		{{Peer: 0, ReadBytes: gpus.NewReading(2 << 20), WriteBytes: gpus.NewReading(1 << 20)}},
+	}
+
+	// When
+	err := amdsmicli.ParseXGMI(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, want, stat.GPUXGMILinks)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)}, stat.GPUXGMIWidth)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(32), gpus.NewReading(32)}, stat.GPUXGMISpeed)
+}
+
+func TestParseXGMIWithPCIeLinks(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := []byte(`[{"gpu": 0, "link_metrics": {"link_type": "PCIE", "links": [{"gpu": 1, "read": "N/A", "write": "N/A"}]}}]`)
+
+	var stat gpus.AMDParams
+	stat.Init()
+	stat.ResizeGPUs(2)
+
+	// When
+	err := amdsmicli.ParseXGMI(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, [][]gpus.XGMILink{nil, nil}, stat.GPUXGMILinks)
+	assert.Equal(t, []gpus.Reading{{}, {}}, stat.GPUXGMIWidth)
+}
+
+func TestParseMetricsSensors(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := readFixture(t, "rocm-6.0.2", "metric.json")
+
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	err := amdsmicli.ParseMetrics(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, gpus.NewReading(41e3), stat.GPUTemperatures[gpus.TemperatureSensorEdge][0])
+	assert.Equal(t, gpus.NewReading(48e3), stat.GPUTemperatures[gpus.TemperatureSensorJunction][0])
+	assert.Equal(t, gpus.NewReading(45e3), stat.GPUTemperatures[gpus.TemperatureSensorMemory][0])
+	// edge sensor is not available on the second GPU.
+	assert.Equal(t, gpus.Reading{}, stat.GPUTemperatures[gpus.TemperatureSensorEdge][1])
+	assert.Equal(t, gpus.NewReading(52e3), stat.GPUTemperatures[gpus.TemperatureSensorJunction][1])
+	// passively cooled GPUs have no fan and voltages are not reported.
+	assert.Equal(t, gpus.Reading{}, stat.GPUFanSpeed[0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUVoltage[gpus.VoltageRailGFX][0])
+}
+
+func TestParseMetricsFanAndVoltages(t *testing.T) {
+	t.Parallel()
+	// Given
+	data := []byte(`[{
+		"gpu": 0,
+		"power": {
+			"gfx_voltage": {"value": 806, "unit": "mV"},
+			"soc_voltage": {"value": 0.9, "unit": "V"},
+			"mem_voltage": "N/A"
+		},
+		"fan": {"speed": 51, "max": 255, "rpm": {"value": 1200, "unit": "RPM"}, "usage": {"value": 20, "unit": "%"}}
+	}]`)
+
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	err := amdsmicli.ParseMetrics(data, &stat)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, gpus.NewReading(806), stat.GPUVoltage[gpus.VoltageRailGFX][0])
+	assert.Equal(t, gpus.NewReading(900), stat.GPUVoltage[gpus.VoltageRailSoC][0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUVoltage[gpus.VoltageRailMemory][0])
+	assert.Equal(t, gpus.NewReading(1200), stat.GPUFanSpeed[0])
+	assert.Equal(t, gpus.NewReading(20), stat.GPUFanSpeedPercent[0])
+}
+
+func TestParseMetricsInvalidOutput(t *testing.T) {
+	t.Parallel()
+	// Given
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	err := amdsmicli.ParseMetrics([]byte("ERROR:root:Unable to detect any GPU devices"), &stat)
+
+	// Then
+	require.Error(t, err)
+}
+
+func readFixture(t *testing.T, release, name string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", release, name))
+	require.NoError(t, err)
+
+	return data
+}
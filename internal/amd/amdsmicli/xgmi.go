@@ -0,0 +1,112 @@
+package amdsmicli
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+/* amd-smi xgmi --json output sample, links contain every GPU of the node including
+the source GPU, read and write are the data accumulated by the link and max_bandwidth
+is the bit rate of the links multiplied by their width.
+{
+    "xgmi_metric": [
+        {
+            "gpu": 0,
+            "bdf": "0000:0c:00.0",
+            "link_metrics": {
+                "bit_rate": {"value": 32, "unit": "Gb/s"},
+                "max_bandwidth": {"value": 512, "unit": "Gb/s"},
+                "link_type": "XGMI",
+                "links": [
+                    {"gpu": 0, "bdf": "0000:0c:00.0", "read": "N/A", "write": "N/A"},
+                    {"gpu": 1, "bdf": "0000:22:00.0", "read": {"value": 1024, "unit": "KB"}, "write": {"value": 2048, "unit": "KB"}}
+                ]
+            }
+        }
+    ]
+}
+*/
+
+const xgmiLinkType string = "XGMI"
+
+// xgmi link bit rate conversion factors to Gb/s.
+var bitRateUnits = map[string]float64{
+	"":     1,
+	"Gb/s": 1,
+}
+
+// xgmiGPU contains the fields used from amd-smi xgmi --json output.
+type xgmiGPU struct {
+	GPU         int `json:"gpu"`
+	LinkMetrics struct {
+		BitRate      value      `json:"bit_rate"`
+		MaxBandwidth value      `json:"max_bandwidth"`
+		LinkType     string     `json:"link_type"`
+		Links        []xgmiPeer `json:"links"`
+	} `json:"link_metrics"`
+}
+
+// xgmiPeer contains the data accumulated by the link to a peer GPU.
+type xgmiPeer struct {
+	GPU   int   `json:"gpu"`
+	Read  value `json:"read"`
+	Write value `json:"write"`
+}
+
+// ParseXGMI parses amd-smi xgmi --json output filling XGMI links of given params, GPUs connected
+// by PCIe are left without links. amd-smi reports the width and speed of the GPU as a whole and
+// not the state of each link, so link status is left unsupported.
+func ParseXGMI(data []byte, stat *gpus.AMDParams) error {
+	var list gpuList[xgmiGPU]
+
+	err := json.Unmarshal(data, &list)
+	if err != nil {
+		return fmt.Errorf("unable to parse amd-smi xgmi output: %w", err)
+	}
+
+	for _, gpu := range list {
+		i := gpu.GPU
+		if i < 0 || uint(i) >= stat.NumGPUs || gpu.LinkMetrics.LinkType != xgmiLinkType {
+			continue
+		}
+
+		stat.GPUXGMIWidth[i] = linkWidth(gpu.LinkMetrics.BitRate, gpu.LinkMetrics.MaxBandwidth)
+		setValue(&stat.GPUXGMISpeed[i], gpu.LinkMetrics.BitRate, bitRateUnits)
+
+		var links []gpus.XGMILink
+
+		for _, peer := range gpu.LinkMetrics.Links {
+			if peer.GPU == i || peer.GPU < 0 || uint(peer.GPU) >= stat.NumGPUs {
+				continue
+			}
+
+			link := gpus.NewXGMILink(peer.GPU)
+			setValue(&link.ReadBytes, peer.Read, memoryUnits)
+			setValue(&link.WriteBytes, peer.Write, memoryUnits)
+
+			links = append(links, link)
+		}
+
+		stat.GPUXGMILinks[i] = links
+	}
+
+	return nil
+}
+
+// linkWidth returns the lanes of the XGMI links of a GPU, amd-smi reports the bandwidth of
+// every link as its bit rate per lane multiplied by its width.
+func linkWidth(bitRate, maxBandwidth value) gpus.Reading {
+	rate, rateExist := bitRate.convert(bitRateUnits)
+	bandwidth, bandwidthExist := maxBandwidth.convert(bitRateUnits)
+
+	switch {
+	case !rateExist || !bandwidthExist:
+		return gpus.Reading{}
+	case rate == 0:
+		return gpus.NewReading(0)
+	default:
+		return gpus.NewReading(bandwidth / rate)
+	}
+}
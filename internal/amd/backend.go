@@ -0,0 +1,111 @@
+package amd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// backend errors.
+var (
+	// ErrNotSupported is returned by backends when they are not able to provide
+	// the requested readings, e.g. a GPU only backend asked for CPU readings.
+	ErrNotSupported = errors.New("operation not supported by backend")
+	// ErrUnknownBackend is returned when there is no backend registered with the given name.
+	ErrUnknownBackend = errors.New("unknown backend")
+)
+
+// Backend defines a source of AMD GPU and CPU telemetry.
+type Backend interface {
+	// Devices discovers GPU cards available within the system.
+	Devices() ([]gpus.Card, error)
+	// ReadGPUs fills given params with GPU readings.
+	ReadGPUs(stat *gpus.AMDParams) error
+	// ReadCPUs fills given params with CPU readings.
+	ReadCPUs(stat *gpus.AMDParams) error
+	// Close releases resources held by the backend.
+	Close() error
+}
+
+// BackendSetup contains parameters required by backends to be created.
+type BackendSetup struct {
+	Logger *slog.Logger
+	// SysfsRoot is the directory where sysfs is mounted, e.g. /sys.
+	SysfsRoot string
+	// ReplayFile is the recording played by the replay backend.
+	ReplayFile string
+	// ReplaySpeed is the pace used to play recordings, e.g. 2 plays twice as fast as recorded.
+	ReplaySpeed float64
+	// Simulator contains the fleet simulated by the simulator backend.
+	Simulator SimulatorSetup
+	// DIMMAddresses are the DIMMs whose power and temperature are read through HSMP on every socket.
+	DIMMAddresses []uint8
+	// AMDSMITimeout bounds the amd-smi commands run by the amdsmi backend for a reading.
+	AMDSMITimeout time.Duration
+}
+
+// SimulatorSetup contains the fleet simulated by the simulator backend.
+type SimulatorSetup struct {
+	GPUs int
+	// GPUModel is the simulated GPU, e.g. MI300X.
+	GPUModel         string
+	Sockets          int
+	ThreadsPerSocket int
+	// Seed makes readings reproducible, a random seed is used when it is zero.
+	Seed uint64
+}
+
+// BackendFactory defines function signature to create a backend.
+type BackendFactory func(settings *BackendSetup) (Backend, error)
+
+// Registry contains backend factories indexed by backend name.
+type Registry struct {
+	factories map[string]BackendFactory
+}
+
+// NewRegistry creates an empty backend registry.
+func NewRegistry() *Registry {
+	newRegistry := Registry{
+		factories: make(map[string]BackendFactory),
+	}
+
+	return &newRegistry
+}
+
+// Register adds given backend factory under the given name.
+// A factory already registered with the same name is replaced.
+func (r *Registry) Register(name string, factory BackendFactory) {
+	r.factories[name] = factory
+}
+
+// Names returns sorted list of registered backend names.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.factories))
+
+	for name := range r.factories {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	return names
+}
+
+// New creates the backend registered with the given name.
+func (r *Registry) New(name string, settings *BackendSetup) (Backend, error) {
+	factory, exist := r.factories[name]
+	if !exist {
+		return nil, fmt.Errorf("%w: %q, available backends: %v", ErrUnknownBackend, name, r.Names())
+	}
+
+	backend, err := factory(settings)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %q backend: %w", name, err)
+	}
+
+	return backend, nil
+}
@@ -0,0 +1,193 @@
+package amd_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/fake"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistryNew(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry := amd.NewRegistry()
+	registry.Register(fake.BackendName, fake.NewBackend)
+
+	settings := amd.BackendSetup{
+		Logger: testlogs.NewLogger(),
+	}
+
+	// When
+	got, err := registry.New("fake", &settings)
+
+	// Then
+	require.NoError(t, err)
+	assert.IsType(t, &fake.Backend{}, got)
+}
+
+func TestRegistryNewUnknownBackend(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry := amd.NewRegistry()
+	registry.Register(fake.BackendName, fake.NewBackend)
+
+	settings := amd.BackendSetup{
+		Logger: testlogs.NewLogger(),
+	}
+
+	// When
+	got, err := registry.New("unknown", &settings)
+
+	// Then
+	require.ErrorIs(t, err, amd.ErrUnknownBackend)
+	assert.Nil(t, got)
+}
+
+func TestRegistryNewFactoryError(t *testing.T) {
+	t.Parallel()
+	// Given
+	errFactory := errors.New("factory error")
+	registry := amd.NewRegistry()
+	registry.Register("broken", func(*amd.BackendSetup) (amd.Backend, error) {
+		return nil, errFactory
+	})
+
+	// When
+	got, err := registry.New("broken", &amd.BackendSetup{})
+
+	// Then
+	require.ErrorIs(t, err, errFactory)
+	assert.Nil(t, got)
+}
+
+func TestRegistryNames(t *testing.T) {
+	t.Parallel()
+	// Given
+	registry := amd.NewRegistry()
+	registry.Register("sysfs", fake.NewBackend)
+	registry.Register("fake", fake.NewBackend)
+	registry.Register("goamdsmi", fake.NewBackend)
+
+	want := []string{"fake", "goamdsmi", "sysfs"}
+
+	// When
+	got := registry.Names()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestScanWithUnsupportedCPUBackend(t *testing.T) {
+	t.Parallel()
+	// Given
+	logger := testlogs.NewLogger()
+	gpuBackend, err := fake.NewBackend(&amd.BackendSetup{Logger: logger})
+	require.NoError(t, err)
+
+	settings := amd.ScannerSetup{
+		Logger:     logger,
+		GPUBackend: gpuBackend,
+		CPUBackend: unsupportedBackend{},
+	}
+	scanner := amd.NewScanner(&settings)
+
+	// When
+	got := scanner.Scan()
+
+	// Then
+	assert.Equal(t, uint(2), got.NumGPUs)
+	assert.Equal(t, uint(0), got.Sockets)
+	assert.Equal(t, uint(0), got.Threads)
+	assert.Equal(t, gpus.NewReading(150e6), got.GPUPower[0])
+	assert.Len(t, got.GPUPower, 2)
+	// fake backend does not provide energy, it is integrated from power from the first scan.
+	assert.Equal(t, gpus.NewReading(0), got.GPUEnergy[0])
+	assert.Empty(t, got.SocketPower)
+}
+
+func TestScanWithProcessReader(t *testing.T) {
+	t.Parallel()
+	// Given
+	logger := testlogs.NewLogger()
+	backend, err := fake.NewBackend(&amd.BackendSetup{Logger: logger})
+	require.NoError(t, err)
+
+	settings := amd.ScannerSetup{
+		Logger:        logger,
+		GPUBackend:    backend,
+		CPUBackend:    backend,
+		ProcessReader: singleProcessReader{},
+	}
+	scanner := amd.NewScanner(&settings)
+
+	want := []gpus.Process{{PID: 42, VRAMUsed: gpus.NewReading(1024)}}
+
+	// When
+	got := scanner.Scan()
+
+	// Then
+	require.Len(t, got.GPUProcesses, 2)
+	assert.Equal(t, want, got.GPUProcesses[1])
+	assert.Empty(t, got.GPUProcesses[0])
+}
+
+func TestScanWithCPUStatReader(t *testing.T) {
+	t.Parallel()
+	// Given
+	logger := testlogs.NewLogger()
+	backend, err := fake.NewBackend(&amd.BackendSetup{Logger: logger})
+	require.NoError(t, err)
+
+	settings := amd.ScannerSetup{
+		Logger:        logger,
+		GPUBackend:    backend,
+		CPUBackend:    backend,
+		CPUStatReader: singleCPUStatReader{},
+	}
+	scanner := amd.NewScanner(&settings)
+
+	// When
+	got := scanner.Scan()
+
+	// Then
+	assert.Equal(t, uint(4), got.Threads)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(12.5)}, got.CPUSeconds[gpus.CPUModeUser])
+	assert.Equal(t, []gpus.Reading{{}}, got.CPUFrequency)
+}
+
+// unsupportedBackend is a backend that does not support any reading.
+type unsupportedBackend struct{}
+
+func (unsupportedBackend) Devices() ([]gpus.Card, error) {
+	return nil, amd.ErrNotSupported
+}
+
+func (unsupportedBackend) ReadGPUs(*gpus.AMDParams) error { return amd.ErrNotSupported }
+
+func (unsupportedBackend) ReadCPUs(*gpus.AMDParams) error { return amd.ErrNotSupported }
+
+func (unsupportedBackend) Close() error { return nil }
+
+// singleProcessReader reports a single process using the last GPU.
+type singleProcessReader struct{}
+
+func (singleProcessReader) ReadProcesses(stat *gpus.AMDParams) error {
+	stat.GPUProcesses[stat.NumGPUs-1] = []gpus.Process{{PID: 42, VRAMUsed: gpus.NewReading(1024)}}
+
+	return nil
+}
+
+// singleCPUStatReader reports the user time of a single logical CPU.
+type singleCPUStatReader struct{}
+
+func (singleCPUStatReader) ReadCPUStats(stat *gpus.AMDParams) error {
+	stat.ResizeCPUStats(1)
+	stat.CPUSeconds[gpus.CPUModeUser][0] = gpus.NewReading(12.5)
+
+	return nil
+}
@@ -13,154 +13,106 @@
  * All rights reserved.
  */
 
-// Package cpustat provides an example parser for Linux CPU utilization statistics.
+// Package amd reads AMD CPU and GPU telemetry through pluggable backends.
 package amd
 
 import (
+	"errors"
 	"log/slog"
+	"time"
 
-	goamdsmi "github.com/amd/go_amd_smi"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
 )
 
-var UINT16_MAX = uint16(0xFFFF)
-var UINT32_MAX = uint32(0xFFFFFFFF)
-var UINT64_MAX = uint64(0xFFFFFFFFFFFFFFFF)
+// ScannerSetup contains parameters required to create a scanner.
+type ScannerSetup struct {
+	Logger *slog.Logger
+	// GPUBackend backend used to read GPU metrics.
+	GPUBackend Backend
+	// CPUBackend backend used to read CPU metrics.
+	CPUBackend Backend
+	// ProcessReader reads GPU usage by process, it is optional.
+	ProcessReader ProcessReader
+	// CPUStatReader reads the utilization and frequency of logical CPUs, it is optional.
+	CPUStatReader CPUStatReader
+}
+
+// ProcessReader defines a source of GPU usage by process.
+type ProcessReader interface {
+	// ReadProcesses fills given params with the processes using each GPU.
+	ReadProcesses(stat *gpus.AMDParams) error
+}
+
+// CPUStatReader defines a source of logical CPU utilization and frequency.
+type CPUStatReader interface {
+	// ReadCPUStats fills given params with the time spent by each logical CPU in each mode and its frequency.
+	ReadCPUStats(stat *gpus.AMDParams) error
+}
 
+// Scanner reads AMD metrics using the configured backends.
 type Scanner struct {
-	logger *slog.Logger
+	logger        *slog.Logger
+	gpuBackend    Backend
+	cpuBackend    Backend
+	processReader ProcessReader
+	cpuStatReader CPUStatReader
+	energy        *EnergyIntegrator
 }
 
-func NewScanner(logger *slog.Logger) *Scanner {
+func NewScanner(settings *ScannerSetup) *Scanner {
 	newScanner := Scanner{
-		logger: logger,
+		logger:        settings.Logger,
+		gpuBackend:    settings.GPUBackend,
+		cpuBackend:    settings.CPUBackend,
+		processReader: settings.ProcessReader,
+		cpuStatReader: settings.CPUStatReader,
+		energy:        NewEnergyIntegrator(),
 	}
 
 	return &newScanner
 }
 
-func (s *Scanner) Scan() gpus.AMDParams {
+func (s *Scanner) Scan() *gpus.AMDParams {
 	s.logger.Debug("scanning metrics")
 
-	var stat gpus.AMDParams
-	stat.Init()
-
-	value64 := uint64(0)
-	value32 := uint32(0)
-	value16 := uint16(0)
-
-	s.logger.Debug("GO_cpu_init", slog.Bool("value", goamdsmi.GO_cpu_init()))
-	if true == goamdsmi.GO_cpu_init() {
-		num_sockets := int(goamdsmi.GO_cpu_number_of_sockets_get())
-		num_threads := int(goamdsmi.GO_cpu_number_of_threads_get())
-		num_threads_per_core := int(goamdsmi.GO_cpu_threads_per_core_get())
-
-		stat.Sockets = uint(num_sockets)
-		stat.Threads = uint(num_threads)
-		stat.ThreadsPerCore = uint(num_threads_per_core)
-
-		for i := 0; i < num_threads; i++ {
-			value64 = uint64(goamdsmi.GO_cpu_core_energy_get(i))
-			if UINT64_MAX != value64 {
-				stat.CoreEnergy[i] = float64(value64)
-			}
-			value64 = 0
-
-			value32 = uint32(goamdsmi.GO_cpu_core_boostlimit_get(i))
-			if UINT32_MAX != value32 {
-				stat.CoreBoost[i] = float64(value32)
-			}
-			value32 = 0
-		}
+	stat := new(gpus.AMDParams)
+
+	err := s.cpuBackend.ReadCPUs(stat)
+	if err != nil {
+		s.logReadError("reading cpu metrics", err)
+	}
 
-		for i := 0; i < num_sockets; i++ {
-			value64 = uint64(goamdsmi.GO_cpu_socket_energy_get(i))
-			if UINT64_MAX != value64 {
-				stat.SocketEnergy[i] = float64(value64)
-			}
-			value64 = 0
-
-			value32 = uint32(goamdsmi.GO_cpu_socket_power_get(i))
-			if UINT32_MAX != value32 {
-				stat.SocketPower[i] = float64(value32)
-			}
-			value32 = 0
-
-			value32 = uint32(goamdsmi.GO_cpu_socket_power_cap_get(i))
-			if UINT32_MAX != value32 {
-				stat.PowerLimit[i] = float64(value32)
-			}
-			value32 = 0
-
-			value32 = uint32(goamdsmi.GO_cpu_prochot_status_get(i))
-			if UINT32_MAX != value32 {
-				stat.ProchotStatus[i] = float64(value32)
-			}
-			value32 = 0
+	if s.cpuStatReader != nil {
+		err = s.cpuStatReader.ReadCPUStats(stat)
+		if err != nil {
+			s.logReadError("reading cpu stats", err)
 		}
 	}
 
-	s.logger.Debug("GO_gpu_init", slog.Bool("value", goamdsmi.GO_gpu_init()))
-	if true == goamdsmi.GO_gpu_init() {
-
-		num_gpus := int(goamdsmi.GO_gpu_num_monitor_devices())
-		stat.NumGPUs = uint(num_gpus)
-
-		for i := 0; i < num_gpus; i++ {
-			value16 = uint16(goamdsmi.GO_gpu_dev_id_get(i))
-			if UINT16_MAX != value16 {
-				stat.GPUDevID[i] = float64(value16)
-			}
-			value16 = 0
-
-			value64 = uint64(goamdsmi.GO_gpu_dev_power_cap_get(i))
-			if UINT64_MAX != value64 {
-				stat.GPUPowerCap[i] = float64(value64)
-			}
-			value64 = 0
-
-			value64 = uint64(goamdsmi.GO_gpu_dev_power_get(i))
-			if UINT64_MAX != value64 {
-				stat.GPUPower[i] = float64(value64)
-			}
-			value64 = 0
-
-			//Get the value for GPU current temperature. Sensor = 0(GPU), Metric = 0(current)
-			value64 = uint64(goamdsmi.GO_gpu_dev_temp_metric_get(i, 0, 0))
-			if UINT64_MAX == value64 {
-				//Sensor = 1 (GPU Junction Temp)
-				value64 = uint64(goamdsmi.GO_gpu_dev_temp_metric_get(i, 1, 0))
-			}
-			if UINT64_MAX != value64 {
-				stat.GPUTemperature[i] = float64(value64)
-			}
-			value64 = 0
-
-			value64 = uint64(goamdsmi.GO_gpu_dev_gpu_clk_freq_get_sclk(i))
-			if UINT64_MAX != value64 {
-				stat.GPUSCLK[i] = float64(value64)
-			}
-			value64 = 0
-
-			value64 = uint64(goamdsmi.GO_gpu_dev_gpu_clk_freq_get_mclk(i))
-			if UINT64_MAX != value64 {
-				stat.GPUMCLK[i] = float64(value64)
-			}
-			value64 = 0
-
-			value32 = uint32(goamdsmi.GO_gpu_dev_gpu_busy_percent_get(i))
-			if UINT32_MAX != value32 {
-				stat.GPUUsage[i] = float64(value32)
-			}
-			value32 = 0
-
-			value64 = uint64(goamdsmi.GO_gpu_dev_gpu_memory_busy_percent_get(i))
-			if UINT64_MAX != value64 {
-				stat.GPUMemoryUsage[i] = float64(value64)
-			}
-			value64 = 0
+	err = s.gpuBackend.ReadGPUs(stat)
+	if err != nil {
+		s.logReadError("reading gpu metrics", err)
+	}
+
+	s.energy.Update(stat, time.Now())
+
+	if s.processReader != nil {
+		err = s.processReader.ReadProcesses(stat)
+		if err != nil {
+			s.logReadError("reading gpu processes", err)
 		}
 	}
 
 	return stat
 }
+
+// logReadError logs given backend error, unsupported readings are not considered errors.
+func (s *Scanner) logReadError(msg string, err error) {
+	if errors.Is(err, ErrNotSupported) {
+		s.logger.Debug(msg, slog.String("reason", err.Error()))
+
+		return
+	}
+
+	s.logger.Error(msg, slog.String("error", err.Error()))
+}
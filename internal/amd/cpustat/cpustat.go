@@ -0,0 +1,184 @@
+// Package cpustat reads the utilization of each logical CPU from /proc/stat and its current
+// frequency from cpufreq sysfs files, logical CPUs are indexed as the threads of E-SMI.
+package cpustat
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/topology"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// default mount points of sysfs and procfs.
+const (
+	SysfsRootDefault  string = "/sys"
+	ProcfsRootDefault string = "/proc"
+)
+
+// procfs and sysfs files.
+const (
+	statFile         string  = "stat"
+	cpuPrefix        string  = "cpu"
+	cpufreqPath      string  = "devices/system/cpu"
+	curFreqFile      string  = "cpufreq/scaling_cur_freq"
+	kilohertzToHertz float64 = 1e3
+	// userHZ is the unit of /proc/stat times, the kernel reports them in USER_HZ which is 100 on every architecture.
+	userHZ float64 = 100
+)
+
+// statColumns contains the column of each mode within the cpu lines of /proc/stat, columns
+// follow the cpu name: user, nice, system, idle, iowait, irq, softirq, steal, guest and guest_nice.
+var statColumns = [gpus.NumCPUModes]int{
+	gpus.CPUModeUser:   1,
+	gpus.CPUModeSystem: 3,
+	gpus.CPUModeIdle:   4,
+	gpus.CPUModeIOWait: 5,
+	gpus.CPUModeSteal:  8,
+}
+
+// Setup contains parameters required to create a reader.
+type Setup struct {
+	Logger     *slog.Logger
+	SysfsRoot  string
+	ProcfsRoot string
+}
+
+// Reader reads the time spent by logical CPUs in each mode and their frequency.
+type Reader struct {
+	logger     *slog.Logger
+	sysfsRoot  string
+	procfsRoot string
+	topology   *topology.Reader
+}
+
+// NewReader creates a reader of the logical CPUs of the host whose filesystems are mounted at the given roots.
+func NewReader(settings *Setup) *Reader {
+	newReader := Reader{
+		logger:     settings.Logger,
+		sysfsRoot:  settings.SysfsRoot,
+		procfsRoot: settings.ProcfsRoot,
+	}
+
+	if newReader.sysfsRoot == "" {
+		newReader.sysfsRoot = SysfsRootDefault
+	}
+
+	if newReader.procfsRoot == "" {
+		newReader.procfsRoot = ProcfsRootDefault
+	}
+
+	newReader.topology = topology.NewReader(newReader.sysfsRoot)
+
+	return &newReader
+}
+
+// ReadCPUStats fills given params with the time spent by each logical CPU in each mode, which
+// is given in seconds since boot, and the frequency of each logical CPU. Offline CPUs are not
+// listed by /proc/stat so their readings are unsupported, as are the frequencies of hosts
+// without cpufreq, e.g. most VMs. The placement of host CPUs is set when the CPU backend did not
+// set it, e.g. GPU only backends, so CPU stats can be aggregated.
+func (r *Reader) ReadCPUStats(stat *gpus.AMDParams) error {
+	content, err := os.ReadFile(filepath.Join(r.procfsRoot, statFile))
+	if err != nil {
+		return fmt.Errorf("unable to read cpu stats: %w", err)
+	}
+
+	cpus := parseStat(content)
+
+	stat.ResizeCPUStats(uint(len(cpus)))
+
+	if stat.CPUTopology == nil {
+		r.topology.Read(stat)
+	}
+
+	for i, times := range cpus {
+		if times == nil {
+			continue
+		}
+
+		for mode, column := range statColumns {
+			if column >= len(times) {
+				continue
+			}
+
+			stat.CPUSeconds[mode][i] = times[column]
+		}
+
+		stat.CPUFrequency[i] = r.readFrequency(i)
+	}
+
+	return nil
+}
+
+// parseStat returns the columns of the cpu lines of /proc/stat indexed by logical CPU, columns
+// are given in seconds and the first column is empty. CPUs not listed are nil.
+func parseStat(content []byte) [][]gpus.Reading {
+	var result [][]gpus.Reading
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// the aggregated line of every CPU is named cpu without index.
+		if len(fields) == 0 || !strings.HasPrefix(fields[0], cpuPrefix) || fields[0] == cpuPrefix {
+			continue
+		}
+
+		cpu, err := strconv.Atoi(strings.TrimPrefix(fields[0], cpuPrefix))
+		if err != nil || cpu < 0 {
+			continue
+		}
+
+		if cpu >= len(result) {
+			result = append(result, make([][]gpus.Reading, cpu+1-len(result))...)
+		}
+
+		times := make([]gpus.Reading, len(fields))
+
+		for column := 1; column < len(fields); column++ {
+			ticks, err := strconv.ParseFloat(fields[column], 64)
+			if err != nil {
+				times[column] = gpus.FailedReading()
+
+				continue
+			}
+
+			times[column] = gpus.NewReading(ticks / userHZ)
+		}
+
+		result[cpu] = times
+	}
+
+	return result
+}
+
+// readFrequency reads the current frequency of the given logical CPU in hertz.
+func (r *Reader) readFrequency(cpu int) gpus.Reading {
+	path := filepath.Join(r.sysfsRoot, cpufreqPath, cpuPrefix+strconv.Itoa(cpu), curFreqFile)
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return gpus.Reading{}
+	}
+
+	if err != nil {
+		r.logger.Debug("unable to read cpu frequency", slog.String("error", err.Error()))
+
+		return gpus.FailedReading()
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return gpus.FailedReading()
+	}
+
+	return gpus.NewReading(value * kilohertzToHertz)
+}
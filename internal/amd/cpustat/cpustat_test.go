@@ -0,0 +1,77 @@
+package cpustat_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/cpustat"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCPUStats(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	// the second CPU is offline and the third one runs on a kernel without steal time.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"proc/stat": "cpu  4705 356 584 3699176 23060 0 277 12 0 0\n" +
+			"cpu0 1393280 32966 572056 13343292 6130 0 17875 150 0 0\n" +
+			"cpu2 1220 11 341 99980 25\n" +
+			"intr 1462898 0 0\n" +
+			"ctxt 115315\n",
+		"sys/devices/system/cpu/cpu0/cpufreq/scaling_cur_freq":     "3100000\n",
+		"sys/devices/system/cpu/cpu2/cpufreq/scaling_cur_freq":     "invalid\n",
+		"sys/devices/system/cpu/cpu0/topology/physical_package_id": "0\n",
+		"sys/devices/system/cpu/cpu0/topology/core_id":             "0\n",
+		"sys/devices/system/cpu/cpu0/cache/index3/level":           "3\n",
+		"sys/devices/system/cpu/cpu0/cache/index3/id":              "0\n",
+	})
+
+	reader := cpustat.NewReader(&cpustat.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  root + "/sys",
+		ProcfsRoot: root + "/proc",
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeCPUs(1, 2)
+
+	// When
+	err := reader.ReadCPUStats(&got)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), got.Threads)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(13932.8), {}, gpus.NewReading(12.2)}, got.CPUSeconds[gpus.CPUModeUser])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(5720.56), {}, gpus.NewReading(3.41)}, got.CPUSeconds[gpus.CPUModeSystem])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(133432.92), {}, gpus.NewReading(999.8)}, got.CPUSeconds[gpus.CPUModeIdle])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(61.3), {}, gpus.NewReading(0.25)}, got.CPUSeconds[gpus.CPUModeIOWait])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(1.5), {}, {}}, got.CPUSeconds[gpus.CPUModeSteal])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(3.1e9), {}, gpus.FailedReading()}, got.CPUFrequency)
+	assert.Equal(t, []gpus.CPUPlacement{gpus.NewCPUPlacement(0, 0, 0), {}, {}}, got.CPUTopology)
+}
+
+func TestReadCPUStatsWithoutProcfs(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := cpustat.NewReader(&cpustat.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  t.TempDir(),
+		ProcfsRoot: t.TempDir(),
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	err := reader.ReadCPUStats(&got)
+
+	// Then
+	require.Error(t, err)
+	assert.Empty(t, got.CPUFrequency)
+}
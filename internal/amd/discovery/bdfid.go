@@ -0,0 +1,53 @@
+package discovery
+
+import "fmt"
+
+// BDFIDAddress decodes a BDF id given by rocm-smi library into the pci bus address of the device,
+// e.g. 0000:03:00.0, and the index of its compute partition, which is 0 for libraries that do not
+// report it. Domain is encoded in bits 32-63, partition in bits 28-31, bus in bits 8-15, device in
+// bits 3-7 and function in bits 0-2.
+func BDFIDAddress(bdfid uint64) (string, int) {
+	address := fmt.Sprintf(
+		"%04x:%02x:%02x.%x",
+		bdfid>>32, (bdfid>>8)&0xff, (bdfid>>3)&0x1f, bdfid&0x7,
+	)
+
+	return address, int((bdfid >> 28) & 0xf)
+}
+
+// MatchBDFIDs returns the index within the given BDF ids of every device, -1 for devices whose
+// id is not found. Devices are matched by pci bus address and partition, then devices left are
+// matched by address only, so partitions of libraries that do not report them are matched in order.
+func MatchBDFIDs(devices []Device, bdfids []uint64) []int {
+	result := make([]int, len(devices))
+	used := make([]bool, len(bdfids))
+
+	for i := range devices {
+		result[i] = matchBDFID(&devices[i], bdfids, used, true)
+	}
+
+	for i := range devices {
+		if result[i] < 0 {
+			result[i] = matchBDFID(&devices[i], bdfids, used, false)
+		}
+	}
+
+	return result
+}
+
+// matchBDFID returns the index of the first unused BDF id of the given device marking it as used,
+// -1 if there is none. The partition is only compared when required.
+func matchBDFID(device *Device, bdfids []uint64, used []bool, samePartition bool) int {
+	for i, bdfid := range bdfids {
+		address, partition := BDFIDAddress(bdfid)
+		if used[i] || address != device.Address || (samePartition && partition != device.PartitionID) {
+			continue
+		}
+
+		used[i] = true
+
+		return i
+	}
+
+	return -1
+}
@@ -0,0 +1,417 @@
+// Package discovery finds AMD GPU cards reading drm and pci sysfs files, product
+// names are resolved from a bundled table of pci ids.
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// sysfs paths and values.
+const (
+	RootDefault   string = "/sys"
+	drmClassPath  string = "class/drm"
+	amdVendorID   uint64 = 0x1002
+	amdVendorName string = "Advanced Micro Devices, Inc. [AMD/ATI]"
+	noNUMANode    int    = -1
+	noSocket      int    = -1
+)
+
+// pci device files.
+const (
+	vendorFile            string = "vendor"
+	deviceFile            string = "device"
+	subsystemVendorFile   string = "subsystem_vendor"
+	subsystemDeviceFile   string = "subsystem_device"
+	revisionFile          string = "revision"
+	uniqueIDFile          string = "unique_id"
+	numaNodeFile          string = "numa_node"
+	localCPUListFile      string = "local_cpulist"
+	vbiosVersionFile      string = "vbios_version"
+	productNameFile       string = "product_name"
+	computePartitionFile  string = "current_compute_partition"
+	memoryPartitionFile   string = "current_memory_partition"
+	vbiosVersionSeparator string = "-"
+)
+
+// firmware version files of the fw_version device directory, the psp firmware is its secure os.
+const (
+	firmwareVersionPath string = "fw_version"
+	smcFirmwareFile     string = "smc_fw_version"
+	mecFirmwareFile     string = "mec_fw_version"
+	sdmaFirmwareFile    string = "sdma_fw_version"
+	pspFirmwareFile     string = "sos_fw_version"
+)
+
+// driverVersionPath is only provided by drivers built as an out of tree module, e.g. by DKMS.
+const driverVersionPath string = "module/amdgpu/version"
+
+// drmFolderName is the directory of a device containing its drm card and render nodes.
+const drmFolderName string = "drm"
+
+var (
+	cardDirRegex    = regexp.MustCompile(`^card([0-9]+)$`)
+	renderNodeRegex = regexp.MustCompile(`^renderD([0-9]+)$`)
+	errNotAMD       = errors.New("not an amd device")
+)
+
+// Device contains pci information of an AMD GPU card, compute partitions of a GPU
+// are cards sharing the pci information of the GPU.
+type Device struct {
+	// CardIndex is the drm card index, e.g. 0 for card0.
+	CardIndex int
+	// Path is the sysfs pci device directory, it is the directory of the GPU for every partition.
+	Path string
+	// Address is the pci bus address, e.g. 0000:03:00.0.
+	Address           string
+	VendorID          uint64
+	DeviceID          uint64
+	SubsystemVendorID uint64
+	SubsystemDeviceID uint64
+	RevisionID        uint64
+	UniqueID          string
+	NUMANode          int
+	VBIOSVersion      string
+	// LocalCPUs is the list of CPUs local to the pci device, e.g. 0-23,48-71.
+	LocalCPUs string
+	// Socket is the physical package of the local CPUs, -1 if it is unknown or they span several sockets.
+	Socket int
+	// RootComplex is the pci domain and bus of the host bridge of the device, e.g. 0000:00.
+	RootComplex string
+	// DriverVersion is the version of the amdgpu module, empty for drivers built within the kernel.
+	DriverVersion string
+	// GFXVersion is the graphics target from kfd topology, e.g. gfx942, empty if kfd is not available.
+	GFXVersion string
+	// Firmware versions read from fw_version directory, e.g. 0x00556f00.
+	SMCFirmwareVersion  string
+	MECFirmwareVersion  string
+	SDMAFirmwareVersion string
+	PSPFirmwareVersion  string
+	// ComputePartition and MemoryPartition are the partition modes of the GPU, e.g. CPX and NPS4,
+	// they are empty for GPUs that could not be partitioned.
+	ComputePartition string
+	MemoryPartition  string
+	// PartitionID is the index of the compute partition within the GPU, 0 for the pci device.
+	PartitionID int
+	// PartitionDevice is the platform device of partitions other than the first one, e.g. amdgpu_xcp_3.
+	PartitionDevice string
+	// KFDGPUID is the gpu id given by the kernel fusion driver, empty if kfd is not available.
+	KFDGPUID string
+	// XGMIPeers contains pci bus addresses of the GPUs linked to this one by XGMI
+	// according to kfd topology, it is empty if kfd is not available.
+	XGMIPeers []string
+	// productName is the name reported by the driver, it is empty on most devices.
+	productName string
+	// renderMinor is the minor number of the drm render node of the card, -1 if it is unknown.
+	renderMinor int
+}
+
+// partitionCard is a drm card of a compute partition backed by a platform device.
+type partitionCard struct {
+	cardIndex   int
+	path        string
+	renderMinor int
+}
+
+// Discover finds AMD GPU cards within drm class directory below given sysfs root,
+// devices are sorted by drm card index. Compute partitions other than the first one
+// are cards of platform devices, they are matched to their GPU through kfd topology.
+func Discover(root string) ([]Device, error) {
+	if root == "" {
+		root = RootDefault
+	}
+
+	drmPath := filepath.Join(root, drmClassPath)
+
+	entries, err := os.ReadDir(drmPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", drmPath, err)
+	}
+
+	topology := readKFDTopology(root)
+	driverVersion, _ := readString(filepath.Join(root, driverVersionPath))
+
+	var (
+		result     []Device
+		partitions []partitionCard
+	)
+
+	for _, entry := range entries {
+		matches := cardDirRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		cardIndex, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+
+		devicePath := filepath.Join(drmPath, entry.Name(), "device")
+
+		device, err := readDevice(devicePath)
+		if errors.Is(err, os.ErrNotExist) {
+			// platform devices do not have pci files.
+			partitions = append(partitions, partitionCard{
+				cardIndex:   cardIndex,
+				path:        devicePath,
+				renderMinor: readRenderMinor(devicePath),
+			})
+
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		node := topology.node(device.Address, device.renderMinor)
+		device.CardIndex = cardIndex
+		device.KFDGPUID = node.gpuID
+		device.GFXVersion = node.gfxVersion
+		device.DriverVersion = driverVersion
+		device.XGMIPeers = topology.xgmiPeers(node)
+		device.Socket = readSocket(root, device.LocalCPUs)
+
+		result = append(result, device)
+	}
+
+	result = append(result, partitionDevices(result, partitions, topology)...)
+
+	slices.SortFunc(result, func(a, b Device) int {
+		return a.CardIndex - b.CardIndex
+	})
+
+	// partitions of each GPU are numbered by card index after its pci device.
+	partitionIDs := make(map[string]int)
+
+	for i := range result {
+		if result[i].PartitionDevice == "" {
+			continue
+		}
+
+		partitionIDs[result[i].Address]++
+		result[i].PartitionID = partitionIDs[result[i].Address]
+	}
+
+	return result, nil
+}
+
+// partitionDevices builds the devices of the given partition cards from the pci devices of their GPUs,
+// cards that are not found in kfd topology, e.g. cards of other platform devices, are ignored.
+func partitionDevices(devices []Device, partitions []partitionCard, topology kfdTopology) []Device {
+	var result []Device
+
+	for _, partition := range partitions {
+		if partition.renderMinor < 0 {
+			continue
+		}
+
+		node, exist := topology.renderNodes[uint64(partition.renderMinor)]
+		if !exist {
+			continue
+		}
+
+		index := slices.IndexFunc(devices, func(device Device) bool {
+			return device.Address == node.address
+		})
+		if index < 0 {
+			continue
+		}
+
+		device := devices[index]
+		device.CardIndex = partition.cardIndex
+		device.KFDGPUID = node.gpuID
+		device.GFXVersion = node.gfxVersion
+		device.XGMIPeers = topology.xgmiPeers(node)
+		device.renderMinor = partition.renderMinor
+		device.PartitionDevice = partition.path
+
+		if resolvedPath, err := filepath.EvalSymlinks(partition.path); err == nil {
+			device.PartitionDevice = filepath.Base(resolvedPath)
+		}
+
+		result = append(result, device)
+	}
+
+	return result
+}
+
+// readRenderMinor reads the minor number of the render node of the given device, -1 if it has none.
+func readRenderMinor(devicePath string) int {
+	entries, err := os.ReadDir(filepath.Join(devicePath, drmFolderName))
+	if err != nil {
+		return -1
+	}
+
+	for _, entry := range entries {
+		matches := renderNodeRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+
+		if minor, err := strconv.Atoi(matches[1]); err == nil {
+			return minor
+		}
+	}
+
+	return -1
+}
+
+// readDevice reads pci information of the given device directory.
+func readDevice(path string) (Device, error) {
+	vendorID, err := readHex(filepath.Join(path, vendorFile))
+	if err != nil {
+		return Device{}, err
+	}
+
+	if vendorID != amdVendorID {
+		return Device{}, errNotAMD
+	}
+
+	device := Device{
+		Path:     path,
+		VendorID: vendorID,
+		NUMANode: noNUMANode,
+		Socket:   noSocket,
+	}
+
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err == nil {
+		device.Path = resolvedPath
+		device.Address = filepath.Base(resolvedPath)
+		device.RootComplex = rootComplex(resolvedPath)
+	}
+
+	device.DeviceID, _ = readHex(filepath.Join(path, deviceFile))
+	device.SubsystemVendorID, _ = readHex(filepath.Join(path, subsystemVendorFile))
+	device.SubsystemDeviceID, _ = readHex(filepath.Join(path, subsystemDeviceFile))
+	device.RevisionID, _ = readHex(filepath.Join(path, revisionFile))
+	device.UniqueID, _ = readString(filepath.Join(path, uniqueIDFile))
+	device.VBIOSVersion, _ = readString(filepath.Join(path, vbiosVersionFile))
+	device.productName, _ = readString(filepath.Join(path, productNameFile))
+	device.ComputePartition, _ = readString(filepath.Join(path, computePartitionFile))
+	device.MemoryPartition, _ = readString(filepath.Join(path, memoryPartitionFile))
+	device.LocalCPUs, _ = readString(filepath.Join(path, localCPUListFile))
+	device.renderMinor = readRenderMinor(path)
+	device.SMCFirmwareVersion, _ = readString(filepath.Join(path, firmwareVersionPath, smcFirmwareFile))
+	device.MECFirmwareVersion, _ = readString(filepath.Join(path, firmwareVersionPath, mecFirmwareFile))
+	device.SDMAFirmwareVersion, _ = readString(filepath.Join(path, firmwareVersionPath, sdmaFirmwareFile))
+	device.PSPFirmwareVersion, _ = readString(filepath.Join(path, firmwareVersionPath, pspFirmwareFile))
+
+	if numaNode, err := readString(filepath.Join(path, numaNodeFile)); err == nil {
+		if value, err := strconv.Atoi(numaNode); err == nil {
+			device.NUMANode = value
+		}
+	}
+
+	return device, nil
+}
+
+// ProductName returns a readable product name, it is resolved from the bundled pci ids
+// table, falling back to the name reported by the driver.
+func (d *Device) ProductName() string {
+	if name, exist := ProductName(d.DeviceID, d.RevisionID); exist {
+		return name
+	}
+
+	if d.productName != "" {
+		return d.productName
+	}
+
+	return unknownProductName(d.DeviceID)
+}
+
+// SKU returns the board sku taken from vbios version, e.g. D67301 for 113-D67301-063.
+func (d *Device) SKU() string {
+	parts := strings.Split(d.VBIOSVersion, vbiosVersionSeparator)
+	if len(parts) < 3 {
+		return ""
+	}
+
+	return parts[1]
+}
+
+// Card builds gpu card information.
+func (d *Device) Card() gpus.Card {
+	return gpus.Card{
+		Cardseries: d.ProductName(),
+		Cardmodel:  fmt.Sprintf("0x%04x", d.SubsystemDeviceID),
+		Cardvendor: amdVendorName,
+		CardSKU:    d.SKU(),
+		PCIBus:     d.Address,
+		CardGUID:   d.KFDGPUID,
+		UniqueID:   d.UniqueID,
+
+		VBIOSVersion:        d.VBIOSVersion,
+		DriverVersion:       d.DriverVersion,
+		GFXVersion:          d.GFXVersion,
+		SMCFirmwareVersion:  d.SMCFirmwareVersion,
+		MECFirmwareVersion:  d.MECFirmwareVersion,
+		SDMAFirmwareVersion: d.SDMAFirmwareVersion,
+		PSPFirmwareVersion:  d.PSPFirmwareVersion,
+
+		ComputePartition: d.ComputePartition,
+		MemoryPartition:  d.MemoryPartition,
+		PartitionID:      d.PartitionID,
+		PartitionDevice:  d.PartitionDevice,
+
+		NUMANode:    optionalInt(d.NUMANode),
+		LocalCPUs:   d.LocalCPUs,
+		Socket:      optionalInt(d.Socket),
+		RootComplex: d.RootComplex,
+	}
+}
+
+// optionalInt formats the given value, it is empty for negative values which stand for unknown ones.
+func optionalInt(value int) string {
+	if value < 0 {
+		return ""
+	}
+
+	return strconv.Itoa(value)
+}
+
+// Cards builds gpu cards information of the given devices.
+func Cards(devices []Device) []gpus.Card {
+	result := make([]gpus.Card, len(devices))
+
+	for i := range devices {
+		result[i] = devices[i].Card()
+	}
+
+	return result
+}
+
+// readString reads given file returning its content without surrounding spaces.
+func readString(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// readHex reads given file containing a single hexadecimal number, e.g. 0x740f.
+func readHex(path string) (uint64, error) {
+	content, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseUint(strings.TrimPrefix(content, "0x"), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return value, nil
+}
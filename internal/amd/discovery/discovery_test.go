@@ -0,0 +1,375 @@
+package discovery_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscover(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makeSysfsFixture(t)
+
+	// When
+	got, err := discovery.Discover(root)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, 0, got[0].CardIndex)
+	assert.Equal(t, "0000:0c:00.0", got[0].Address)
+	assert.Equal(t, uint64(0x1002), got[0].VendorID)
+	assert.Equal(t, uint64(0x74a1), got[0].DeviceID)
+	assert.Equal(t, uint64(0x1002), got[0].SubsystemVendorID)
+	assert.Equal(t, uint64(0x74a1), got[0].SubsystemDeviceID)
+	assert.Equal(t, uint64(0), got[0].RevisionID)
+	assert.Equal(t, "0xd4a2a8a1d2f3c5e6", got[0].UniqueID)
+	assert.Equal(t, 0, got[0].NUMANode)
+	assert.Equal(t, "113-M3000100-102", got[0].VBIOSVersion)
+	assert.Equal(t, "53091", got[0].KFDGPUID)
+	assert.Equal(t, "gfx942", got[0].GFXVersion)
+	assert.Equal(t, "6.8.5", got[0].DriverVersion)
+	assert.Equal(t, "0x00556f00", got[0].SMCFirmwareVersion)
+	assert.Equal(t, "0x0000009c", got[0].MECFirmwareVersion)
+	assert.Equal(t, "0x00000014", got[0].SDMAFirmwareVersion)
+	assert.Equal(t, "0x00270082", got[0].PSPFirmwareVersion)
+	assert.Equal(t, []string{"0000:9f:00.0"}, got[0].XGMIPeers)
+	assert.Equal(t, "0-1,4", got[0].LocalCPUs)
+	assert.Equal(t, 0, got[0].Socket)
+	assert.Equal(t, "0000:00", got[0].RootComplex)
+
+	assert.Equal(t, 1, got[1].CardIndex)
+	assert.Equal(t, "0000:9f:00.0", got[1].Address)
+	assert.Equal(t, 1, got[1].NUMANode)
+	assert.Equal(t, "15664", got[1].KFDGPUID)
+	assert.Equal(t, "gfx90a", got[1].GFXVersion)
+	assert.Empty(t, got[1].SMCFirmwareVersion)
+	assert.Equal(t, []string{"0000:0c:00.0"}, got[1].XGMIPeers)
+	assert.Equal(t, "2-3", got[1].LocalCPUs)
+	assert.Equal(t, 1, got[1].Socket)
+
+	// card without numa node nor kfd topology node.
+	assert.Equal(t, 8, got[2].CardIndex)
+	assert.Equal(t, -1, got[2].NUMANode)
+	assert.Empty(t, got[2].KFDGPUID)
+	assert.Empty(t, got[2].GFXVersion)
+	assert.Empty(t, got[2].XGMIPeers)
+	assert.Equal(t, "0-4", got[2].LocalCPUs)
+	assert.Equal(t, -1, got[2].Socket)
+}
+
+func TestDiscoverPartitions(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	sysfsfixtures.AMDGPUDevice(t, root, "card1", "0000:0c:00.0", map[string]string{
+		"device":                    "0x74a1\n",
+		"vbios_version":             "113-M3000100-102\n",
+		"current_compute_partition": "CPX\n",
+		"current_memory_partition":  "NPS4\n",
+		"drm/card1/dev":             "226:1\n",
+		"drm/renderD128/dev":        "226:128\n",
+	})
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"devices/platform/amdgpu_xcp_1/drm/card3/dev":         "226:3\n",
+		"devices/platform/amdgpu_xcp_1/drm/renderD130/dev":    "226:130\n",
+		"devices/platform/amdgpu_xcp_0/drm/card2/dev":         "226:2\n",
+		"devices/platform/amdgpu_xcp_0/drm/renderD129/dev":    "226:129\n",
+		"devices/platform/simple-framebuffer.0/drm/card0/dev": "226:0\n",
+		"class/kfd/kfd/topology/nodes/1/gpu_id":               "1001\n",
+		"class/kfd/kfd/topology/nodes/1/properties":           "location_id 3072\ndomain 0\ndrm_render_minor 128\n",
+		"class/kfd/kfd/topology/nodes/2/gpu_id":               "1002\n",
+		"class/kfd/kfd/topology/nodes/2/properties":           "location_id 3072\ndomain 0\ndrm_render_minor 129\n",
+		"class/kfd/kfd/topology/nodes/3/gpu_id":               "1003\n",
+		"class/kfd/kfd/topology/nodes/3/properties":           "location_id 3072\ndomain 0\ndrm_render_minor 130\n",
+	})
+	sysfsfixtures.Symlink(t, root, "devices/platform/amdgpu_xcp_1", "class/drm/card3/device")
+	sysfsfixtures.Symlink(t, root, "devices/platform/amdgpu_xcp_0", "class/drm/card2/device")
+	// cards of other platform devices are ignored.
+	sysfsfixtures.Symlink(t, root, "devices/platform/simple-framebuffer.0", "class/drm/card0/device")
+
+	// When
+	got, err := discovery.Discover(root)
+
+	// Then
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	for i, want := range []struct {
+		cardIndex       int
+		gpuID           string
+		partitionID     int
+		partitionDevice string
+	}{
+		{cardIndex: 1, gpuID: "1001"},
+		{cardIndex: 2, gpuID: "1002", partitionID: 1, partitionDevice: "amdgpu_xcp_0"},
+		{cardIndex: 3, gpuID: "1003", partitionID: 2, partitionDevice: "amdgpu_xcp_1"},
+	} {
+		assert.Equal(t, want.cardIndex, got[i].CardIndex)
+		assert.Equal(t, want.gpuID, got[i].KFDGPUID)
+		assert.Equal(t, want.partitionID, got[i].PartitionID)
+		assert.Equal(t, want.partitionDevice, got[i].PartitionDevice)
+		assert.Equal(t, "0000:0c:00.0", got[i].Address)
+		assert.Equal(t, got[0].Path, got[i].Path)
+		assert.Equal(t, "113-M3000100-102", got[i].VBIOSVersion)
+		assert.Equal(t, "CPX", got[i].ComputePartition)
+		assert.Equal(t, "NPS4", got[i].MemoryPartition)
+	}
+}
+
+func TestDiscoverWithoutDRMClass(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	// When
+	got, err := discovery.Discover(root)
+
+	// Then
+	require.Error(t, err)
+	assert.Empty(t, got)
+}
+
+func TestCards(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makeSysfsFixture(t)
+	devices, err := discovery.Discover(root)
+	require.NoError(t, err)
+
+	want := make([]gpus.Card, 3)
+	want[0] = gpus.Card{
+		Cardseries: "AMD Instinct MI300X",
+		Cardmodel:  "0x74a1",
+		Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+		CardSKU:    "M3000100",
+		PCIBus:     "0000:0c:00.0",
+		CardGUID:   "53091",
+		UniqueID:   "0xd4a2a8a1d2f3c5e6",
+
+		VBIOSVersion:        "113-M3000100-102",
+		DriverVersion:       "6.8.5",
+		GFXVersion:          "gfx942",
+		SMCFirmwareVersion:  "0x00556f00",
+		MECFirmwareVersion:  "0x0000009c",
+		SDMAFirmwareVersion: "0x00000014",
+		PSPFirmwareVersion:  "0x00270082",
+
+		NUMANode:    "0",
+		LocalCPUs:   "0-1,4",
+		Socket:      "0",
+		RootComplex: "0000:00",
+	}
+	want[1] = gpus.Card{
+		Cardseries: "AMD Instinct MI300X",
+		Cardmodel:  "0x74a1",
+		Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+		CardSKU:    "M3000100",
+		PCIBus:     "0000:9f:00.0",
+		CardGUID:   "15664",
+
+		VBIOSVersion:  "113-M3000100-102",
+		DriverVersion: "6.8.5",
+		GFXVersion:    "gfx90a",
+
+		NUMANode:    "1",
+		LocalCPUs:   "2-3",
+		Socket:      "1",
+		RootComplex: "0000:00",
+	}
+	want[2] = gpus.Card{
+		Cardseries: "AMD Instinct Prototype",
+		Cardmodel:  "0x0000",
+		Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+		PCIBus:     "0000:c1:00.0",
+
+		DriverVersion: "6.8.5",
+
+		LocalCPUs:   "0-4",
+		RootComplex: "0000:00",
+	}
+
+	// When
+	got := discovery.Cards(devices)
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestDeviceProductName(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		device discovery.Device
+		want   string
+	}{
+		"exact revision": {
+			device: discovery.Device{DeviceID: 0x66a0, RevisionID: 0x01},
+			want:   "AMD Radeon Instinct MI60",
+		},
+		"any revision": {
+			device: discovery.Device{DeviceID: 0x740f, RevisionID: 0x07},
+			want:   "AMD Instinct MI210",
+		},
+		"unknown device": {
+			device: discovery.Device{DeviceID: 0xabcd},
+			want:   "AMD GPU 0xabcd",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			got := tt.device.ProductName()
+
+			// Then
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func makeSysfsFixture(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	sysfsfixtures.AMDGPUDevice(t, root, "card1", "0000:9f:00.0", map[string]string{
+		"device":           "0x74a1\n",
+		"subsystem_vendor": "0x1002\n",
+		"subsystem_device": "0x74a1\n",
+		"revision":         "0x00\n",
+		"numa_node":        "1\n",
+		"local_cpulist":    "2-3\n",
+		"vbios_version":    "113-M3000100-102\n",
+	})
+	sysfsfixtures.AMDGPUDevice(t, root, "card0", "0000:0c:00.0", map[string]string{
+		"device":           "0x74a1\n",
+		"subsystem_vendor": "0x1002\n",
+		"subsystem_device": "0x74a1\n",
+		"revision":         "0x00\n",
+		"numa_node":        "0\n",
+		"local_cpulist":    "0-1,4\n",
+		"unique_id":        "0xd4a2a8a1d2f3c5e6\n",
+		"vbios_version":    "113-M3000100-102\n",
+		// fw_version files of other engines are ignored.
+		"fw_version/smc_fw_version":  "0x00556f00\n",
+		"fw_version/mec_fw_version":  "0x0000009c\n",
+		"fw_version/sdma_fw_version": "0x00000014\n",
+		"fw_version/sos_fw_version":  "0x00270082\n",
+		"fw_version/rlc_fw_version":  "0x00000011\n",
+	})
+	// device not found in the bundled table uses the name reported by the driver.
+	sysfsfixtures.AMDGPUDevice(t, root, "card8", "0000:c1:00.0", map[string]string{
+		"device":       "0x7fff\n",
+		"product_name": "AMD Instinct Prototype\n",
+		"numa_node":    "-1\n",
+	})
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/drm/card2/device/vendor":             "0x10de\n",
+		"class/drm/card0-DP-1/status":               "disconnected\n",
+		"class/kfd/kfd/topology/nodes/0/gpu_id":     "0\n",
+		"class/kfd/kfd/topology/nodes/0/properties": "cpu_cores_count 96\nsimd_count 0\n",
+		"class/kfd/kfd/topology/nodes/1/gpu_id":     "53091\n",
+		"class/kfd/kfd/topology/nodes/1/properties": "simd_count 1216\nlocation_id 3072\ndomain 0\ngfx_target_version 90402\n",
+		"class/kfd/kfd/topology/nodes/2/gpu_id":     "15664\n",
+		"class/kfd/kfd/topology/nodes/2/properties": "simd_count 1216\nlocation_id 40704\ndomain 0\ngfx_target_version 90010\n",
+		"module/amdgpu/version":                     "6.8.5\n",
+		// pcie links to the cpu node are not xgmi links.
+		"class/kfd/kfd/topology/nodes/1/io_links/0/properties": "type 2\nnode_from 1\nnode_to 0\nweight 20\n",
+		"class/kfd/kfd/topology/nodes/1/io_links/1/properties": "type 11\nnode_from 1\nnode_to 2\nweight 15\n",
+		"class/kfd/kfd/topology/nodes/2/io_links/0/properties": "type 2\nnode_from 2\nnode_to 0\nweight 20\n",
+		"class/kfd/kfd/topology/nodes/2/io_links/1/properties": "type 11\nnode_from 2\nnode_to 1\nweight 15\n",
+	})
+	// cpu2 and cpu3 run on the second socket, card8 is local to the CPUs of both sockets.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"devices/pci0000:00/0000:c1:00.0/local_cpulist":        "0-4\n",
+		"devices/system/cpu/cpu0/topology/physical_package_id": "0\n",
+		"devices/system/cpu/cpu1/topology/physical_package_id": "0\n",
+		"devices/system/cpu/cpu2/topology/physical_package_id": "1\n",
+		"devices/system/cpu/cpu3/topology/physical_package_id": "1\n",
+		"devices/system/cpu/cpu4/topology/physical_package_id": "0\n",
+	})
+
+	return root
+}
+
+func TestBDFIDAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		bdfid         uint64
+		wantAddress   string
+		wantPartition int
+	}{
+		"whole gpu": {
+			bdfid:       0x9f << 8,
+			wantAddress: "0000:9f:00.0",
+		},
+		"device and function within domain": {
+			bdfid:       1<<32 | 0x03<<8 | 0x1f<<3 | 0x7,
+			wantAddress: "0001:03:1f.7",
+		},
+		"compute partition": {
+			bdfid:         3<<28 | 0x0c<<8,
+			wantAddress:   "0000:0c:00.0",
+			wantPartition: 3,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			gotAddress, gotPartition := discovery.BDFIDAddress(tt.bdfid)
+
+			// Then
+			assert.Equal(t, tt.wantAddress, gotAddress)
+			assert.Equal(t, tt.wantPartition, gotPartition)
+		})
+	}
+}
+
+func TestMatchBDFIDs(t *testing.T) {
+	t.Parallel()
+	// Given
+	// the library enumerates the GPUs in another order than drm cards and does not monitor the last one.
+	devices := []discovery.Device{
+		{Address: "0000:0c:00.0"},
+		{Address: "0000:0c:00.0", PartitionID: 1},
+		{Address: "0000:9f:00.0"},
+		{Address: "0000:c1:00.0"},
+	}
+	bdfids := []uint64{0x9f << 8, 1<<28 | 0x0c<<8, 0x0c << 8}
+
+	// When
+	got := discovery.MatchBDFIDs(devices, bdfids)
+
+	// Then
+	assert.Equal(t, []int{2, 1, 0, -1}, got)
+}
+
+func TestMatchBDFIDsWithoutPartitions(t *testing.T) {
+	t.Parallel()
+	// Given
+	// libraries that do not report partitions give the same id to every partition of a GPU.
+	devices := []discovery.Device{
+		{Address: "0000:0c:00.0"},
+		{Address: "0000:0c:00.0", PartitionID: 1},
+		{Address: "0000:0c:00.0", PartitionID: 2},
+	}
+	bdfids := []uint64{0x0c << 8, 0x0c << 8, 0x0c << 8}
+
+	// When
+	got := discovery.MatchBDFIDs(devices, bdfids)
+
+	// Then
+	assert.Equal(t, []int{0, 1, 2}, got)
+}
@@ -0,0 +1,209 @@
+package discovery
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// kfd topology files.
+const (
+	kfdTopologyNodesPath string = "class/kfd/kfd/topology/nodes"
+	kfdIOLinksFolderName string = "io_links"
+	kfdGPUIDFile         string = "gpu_id"
+	kfdPropertiesFile    string = "properties"
+	kfdLocationIDKey     string = "location_id"
+	kfdDomainKey         string = "domain"
+	kfdLinkTypeKey       string = "type"
+	kfdLinkNodeToKey     string = "node_to"
+	kfdGFXVersionKey     string = "gfx_target_version"
+	kfdRenderMinorKey    string = "drm_render_minor"
+	kfdCPUGPUID          string = "0"
+	// kfdXGMILinkType is the io link type of XGMI links, other links are PCIe links to CPUs.
+	kfdXGMILinkType uint64 = 11
+)
+
+// kfdNode contains the information of a GPU node of kfd topology, every compute
+// partition of a GPU is a node located at the pci bus address of the GPU.
+type kfdNode struct {
+	address    string
+	gpuID      string
+	gfxVersion string
+	// xgmiPeers contains the kfd node ids linked to this node by XGMI.
+	xgmiPeers []string
+}
+
+// kfdTopology contains kfd topology GPU nodes indexed by pci bus address and render minor.
+type kfdTopology struct {
+	nodes map[string]kfdNode
+	// renderNodes contains GPU nodes indexed by the minor number of their drm render node.
+	renderNodes map[uint64]kfdNode
+	// addresses contains pci bus addresses indexed by kfd node id.
+	addresses map[string]string
+}
+
+// readKFDTopology reads kfd topology GPU nodes, an empty topology is returned if kfd is not available.
+func readKFDTopology(root string) kfdTopology {
+	result := kfdTopology{
+		nodes:       make(map[string]kfdNode),
+		renderNodes: make(map[uint64]kfdNode),
+		addresses:   make(map[string]string),
+	}
+
+	nodePaths, err := filepath.Glob(filepath.Join(root, kfdTopologyNodesPath, "*"))
+	if err != nil {
+		return result
+	}
+
+	for _, nodePath := range nodePaths {
+		gpuID, err := readString(filepath.Join(nodePath, kfdGPUIDFile))
+		if err != nil || gpuID == kfdCPUGPUID || gpuID == "" {
+			continue
+		}
+
+		properties, err := readKFDProperties(filepath.Join(nodePath, kfdPropertiesFile))
+		if err != nil {
+			continue
+		}
+
+		address, err := kfdNodeAddress(properties)
+		if err != nil {
+			continue
+		}
+
+		node := kfdNode{
+			address:    address,
+			gpuID:      gpuID,
+			gfxVersion: gfxVersion(properties),
+			xgmiPeers:  readKFDXGMIPeers(nodePath),
+		}
+
+		// the first partition stands for the GPU at its address.
+		if _, exist := result.nodes[address]; !exist {
+			result.nodes[address] = node
+		}
+
+		if renderMinor, exist := properties[kfdRenderMinorKey]; exist {
+			result.renderNodes[renderMinor] = node
+		}
+
+		result.addresses[filepath.Base(nodePath)] = address
+	}
+
+	return result
+}
+
+// node returns the GPU node of the device with the given render minor, the node at the given
+// pci bus address is returned when the render minor is unknown, e.g. on older kernels.
+func (t kfdTopology) node(address string, renderMinor int) kfdNode {
+	if renderMinor >= 0 {
+		if node, exist := t.renderNodes[uint64(renderMinor)]; exist {
+			return node
+		}
+	}
+
+	return t.nodes[address]
+}
+
+// xgmiPeers returns pci bus addresses of the GPUs linked by XGMI to the given node,
+// GPUs linked to several partitions of the node are returned once.
+func (t kfdTopology) xgmiPeers(node kfdNode) []string {
+	var result []string
+
+	for _, peer := range node.xgmiPeers {
+		peerAddress, exist := t.addresses[peer]
+		if !exist || peerAddress == node.address || slices.Contains(result, peerAddress) {
+			continue
+		}
+
+		result = append(result, peerAddress)
+	}
+
+	return result
+}
+
+// readKFDXGMIPeers reads io links of the given kfd node returning the node ids linked by XGMI.
+func readKFDXGMIPeers(nodePath string) []string {
+	linkPaths, err := filepath.Glob(filepath.Join(nodePath, kfdIOLinksFolderName, "*", kfdPropertiesFile))
+	if err != nil {
+		return nil
+	}
+
+	var result []string
+
+	for _, linkPath := range linkPaths {
+		properties, err := readKFDProperties(linkPath)
+		if err != nil || properties[kfdLinkTypeKey] != kfdXGMILinkType {
+			continue
+		}
+
+		nodeTo, exist := properties[kfdLinkNodeToKey]
+		if !exist {
+			continue
+		}
+
+		result = append(result, strconv.FormatUint(nodeTo, 10))
+	}
+
+	return result
+}
+
+// readKFDProperties reads a kfd properties file made of "key value" lines with numeric values.
+func readKFDProperties(path string) (map[string]uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	properties := make(map[string]uint64)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		properties[fields[0]] = value
+	}
+
+	return properties, nil
+}
+
+// kfdNodeAddress builds pci bus address from kfd node properties, location_id
+// contains bus number and device function, e.g. 768 (0x300) is 03:00.0.
+func kfdNodeAddress(properties map[string]uint64) (string, error) {
+	locationID, exist := properties[kfdLocationIDKey]
+	if !exist {
+		return "", fmt.Errorf("%s not found in kfd node properties", kfdLocationIDKey)
+	}
+
+	bus := (locationID >> 8) & 0xff
+	deviceFunction := locationID & 0xff
+
+	return fmt.Sprintf(
+		"%04x:%02x:%02x.%x",
+		properties[kfdDomainKey], bus, deviceFunction>>3, deviceFunction&0x7,
+	), nil
+}
+
+// gfxVersion builds the graphics target name from kfd node properties, gfx_target_version
+// contains major, minor and stepping versions as decimal digits, e.g. 90010 is gfx90a.
+func gfxVersion(properties map[string]uint64) string {
+	version, exist := properties[kfdGFXVersionKey]
+	if !exist || version == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("gfx%d%x%x", version/10000, version/100%100, version%100)
+}
@@ -0,0 +1,87 @@
+package discovery
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// packageFilePattern is the sysfs file containing the physical package of a logical CPU.
+const packageFilePattern string = "devices/system/cpu/cpu%d/topology/physical_package_id"
+
+// rootBusRegex matches the directory of a pci host bridge, e.g. pci0000:00.
+var rootBusRegex = regexp.MustCompile(`^pci([0-9a-f]{4}:[0-9a-f]{2})$`)
+
+// readSocket reads the physical package of the given list of CPUs below the given sysfs root,
+// -1 if the list is empty, a package could not be read or CPUs belong to several packages.
+func readSocket(root, cpuList string) int {
+	cpus, err := parseCPUList(cpuList)
+	if err != nil || len(cpus) == 0 {
+		return noSocket
+	}
+
+	socket := noSocket
+
+	for _, cpu := range cpus {
+		content, err := readString(filepath.Join(root, fmt.Sprintf(packageFilePattern, cpu)))
+		if err != nil {
+			return noSocket
+		}
+
+		value, err := strconv.Atoi(content)
+		if err != nil || (socket != noSocket && value != socket) {
+			return noSocket
+		}
+
+		socket = value
+	}
+
+	return socket
+}
+
+// parseCPUList parses a list of CPUs in sysfs list format, e.g. 0-3,8,10-11.
+func parseCPUList(list string) ([]int, error) {
+	if list == "" {
+		return nil, nil
+	}
+
+	var result []int
+
+	for _, part := range strings.Split(list, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse cpu list %s: %w", list, err)
+		}
+
+		end := start
+
+		if isRange {
+			end, err = strconv.Atoi(last)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse cpu list %s: %w", list, err)
+			}
+		}
+
+		for cpu := start; cpu <= end; cpu++ {
+			result = append(result, cpu)
+		}
+	}
+
+	return result, nil
+}
+
+// rootComplex returns the pci domain and bus of the host bridge within the given resolved
+// device path, e.g. 0000:00 for /sys/devices/pci0000:00/0000:00:01.1/0000:03:00.0.
+func rootComplex(devicePath string) string {
+	for _, dir := range strings.Split(filepath.ToSlash(devicePath), "/") {
+		if matches := rootBusRegex.FindStringSubmatch(dir); matches != nil {
+			return matches[1]
+		}
+	}
+
+	return ""
+}
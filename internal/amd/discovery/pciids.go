@@ -0,0 +1,77 @@
+package discovery
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+//go:embed amdgpu.ids
+var amdgpuIDs string
+
+// productKey identifies a product by its pci device and revision ids.
+type productKey struct {
+	deviceID   uint64
+	revisionID uint64
+}
+
+// productNames contains bundled product names indexed by device and revision ids.
+var productNames, deviceProductNames = parseProductNames(amdgpuIDs)
+
+// parseProductNames parses amdgpu.ids content returning product names indexed by device
+// and revision ids, and indexed only by device id taking the first entry found.
+func parseProductNames(content string) (map[productKey]string, map[uint64]string) {
+	byRevision := make(map[productKey]string)
+	byDevice := make(map[uint64]string)
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		deviceID, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 16, 16)
+		if err != nil {
+			continue
+		}
+
+		revisionID, err := strconv.ParseUint(strings.TrimSpace(fields[1]), 16, 8)
+		if err != nil {
+			continue
+		}
+
+		name := strings.TrimSpace(fields[2])
+		byRevision[productKey{deviceID: deviceID, revisionID: revisionID}] = name
+
+		if _, exist := byDevice[deviceID]; !exist {
+			byDevice[deviceID] = name
+		}
+	}
+
+	return byRevision, byDevice
+}
+
+// ProductName resolves a readable product name from pci device and revision ids.
+// It returns false if the device is not found within the bundled table.
+func ProductName(deviceID, revisionID uint64) (string, bool) {
+	if name, exist := productNames[productKey{deviceID: deviceID, revisionID: revisionID}]; exist {
+		return name, true
+	}
+
+	name, exist := deviceProductNames[deviceID]
+
+	return name, exist
+}
+
+// unknownProductName builds a product name for devices not found within the bundled table.
+func unknownProductName(deviceID uint64) string {
+	return fmt.Sprintf("AMD GPU 0x%04x", deviceID)
+}
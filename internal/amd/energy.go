@@ -0,0 +1,64 @@
+package amd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// EnergyIntegrator accumulates the energy of GPUs whose backend does not provide an energy
+// accumulator by integrating their power across scans, the first scan only sets the starting point.
+type EnergyIntegrator struct {
+	mutex     sync.Mutex
+	timestamp time.Time
+	power     []gpus.Reading
+	energy    []float64
+}
+
+// NewEnergyIntegrator creates an integrator without consumed energy.
+func NewEnergyIntegrator() *EnergyIntegrator {
+	return &EnergyIntegrator{}
+}
+
+// Update sets the energy of GPUs without energy readings in the given params read at the given time.
+// The power read by both scans is averaged over the elapsed time, so intervals in which the power
+// of a GPU could not be read are not accumulated. GPUs whose energy reading failed are left untouched,
+// so the energy of a GPU never switches between the backend accumulator and the integrated one.
+func (e *EnergyIntegrator) Update(stat *gpus.AMDParams, now time.Time) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	var elapsed float64
+	if !e.timestamp.IsZero() && now.After(e.timestamp) {
+		elapsed = now.Sub(e.timestamp).Seconds()
+	}
+
+	if uint(len(e.energy)) < stat.NumGPUs {
+		e.power = append(e.power, make([]gpus.Reading, int(stat.NumGPUs)-len(e.power))...)
+		e.energy = append(e.energy, make([]float64, int(stat.NumGPUs)-len(e.energy))...)
+	}
+
+	for i := range stat.NumGPUs {
+		if stat.GPUEnergy[i].Valid() || stat.GPUEnergy[i].Failed() {
+			continue
+		}
+
+		power := stat.GPUPower[i]
+		previous := e.power[i]
+		e.power[i] = power
+
+		if !power.Valid() {
+			continue
+		}
+
+		if previous.Valid() {
+			// power is given in microwatts, so energy is given in microjoules.
+			e.energy[i] += (previous.Value + power.Value) / 2 * elapsed
+		}
+
+		stat.GPUEnergy[i] = gpus.NewReading(e.energy[i])
+	}
+
+	e.timestamp = now
+}
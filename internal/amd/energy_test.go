@@ -0,0 +1,44 @@
+package amd_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnergyIntegratorUpdate(t *testing.T) {
+	t.Parallel()
+	// Given
+	integrator := amd.NewEnergyIntegrator()
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	// first GPU reads power, second GPU provides its own energy, third GPU
+	// fails to read power once and fourth GPU fails to read energy.
+	scan := func(power, thirdPower gpus.Reading) *gpus.AMDParams {
+		var stat gpus.AMDParams
+		stat.Init()
+		stat.ResizeGPUs(4)
+		stat.GPUPower = []gpus.Reading{power, power, thirdPower, power}
+		stat.GPUEnergy[1] = gpus.NewReading(5e6)
+		stat.GPUEnergy[3] = gpus.FailedReading()
+
+		return &stat
+	}
+
+	first := scan(gpus.NewReading(100e6), gpus.NewReading(100e6))
+	second := scan(gpus.NewReading(300e6), gpus.FailedReading())
+	third := scan(gpus.NewReading(300e6), gpus.NewReading(300e6))
+
+	// When
+	integrator.Update(first, start)
+	integrator.Update(second, start.Add(10*time.Second))
+	integrator.Update(third, start.Add(20*time.Second))
+
+	// Then
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(0), gpus.NewReading(5e6), gpus.NewReading(0), gpus.FailedReading()}, first.GPUEnergy)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(2000e6), gpus.NewReading(5e6), {}, gpus.FailedReading()}, second.GPUEnergy)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(5000e6), gpus.NewReading(5e6), gpus.NewReading(0), gpus.FailedReading()}, third.GPUEnergy)
+}
@@ -0,0 +1,228 @@
+// Package fake implements an AMD telemetry backend returning static data,
+// it allows running the exporter in environments without AMD hardware or libraries.
+package fake
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "fake"
+
+// fake inventory values.
+const (
+	numSockets        uint    = 1
+	numThreads        uint    = 4
+	numThreadsPerCore uint    = 2
+	numGPUs           uint    = 2
+	gpuDeviceID       float64 = 0x740f
+	gpuPowerCap       float64 = 300e6 // microwatts
+	gpuPower          float64 = 150e6 // microwatts
+	gpuTemperature    float64 = 45e3  // millidegrees celsius
+	gpuJunctionTemp   float64 = 55e3  // millidegrees celsius
+	gpuMemoryTemp     float64 = 50e3  // millidegrees celsius
+	gpuCriticalTemp   float64 = 105e3 // millidegrees celsius
+	gpuEmergencyTemp  float64 = 110e3 // millidegrees celsius
+	gpuGFXVoltage     float64 = 806   // millivolts
+	gpuSoCVoltage     float64 = 900   // millivolts
+	gpuSCLK           float64 = 1700e6
+	gpuMCLK           float64 = 1600e6
+	gpuUsage          float64 = 50
+	gpuMemoryUsage    float64 = 25
+	gpuVRAMTotal      float64 = 64 << 30 // bytes
+	gpuVRAMUsed       float64 = 16 << 30 // bytes
+	gpuGTTUsed        float64 = 32 << 20 // bytes
+	gpuPCIeSpeed      float64 = 16       // GT/s
+	gpuPCIeWidth      float64 = 16
+	gpuPCIeBandwidth  float64 = 2e9 // bytes per second
+	gpuXGMIWidth      float64 = 16
+	gpuXGMISpeed      float64 = 25      // Gb/s
+	gpuXGMIBytes      float64 = 1 << 30 // bytes
+	coreEnergy        float64 = 1e6
+	coreBoost         float64 = 3500
+	socketEnergy      float64 = 1e9
+	socketPower       float64 = 180e3 // milliwatts
+	socketPowerLimit  float64 = 280e3 // milliwatts
+	prochotStatus     float64 = 0
+	socketTctl        float64 = 62e3 // millidegrees celsius
+	ccdTemperature    float64 = 55e3 // millidegrees celsius
+	numCCDs           int     = 2
+	ddrBandwidth      float64 = 115e9 // bytes per second
+	ddrMaxBandwidth   float64 = 460e9 // bytes per second
+	ddrUtilization    float64 = 25
+	socketFCLK        float64 = 2000e6
+	socketMCLK        float64 = 2400e6
+	c0Residency       float64 = 40
+	frequencyLimit    float64 = 3700e6
+	sviPower          float64 = 45e3 // milliwatts
+)
+
+// Backend returns static AMD metrics.
+type Backend struct {
+	logger *slog.Logger
+}
+
+// NewBackend creates a fake backend.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	newBackend := Backend{
+		logger: settings.Logger,
+	}
+
+	return &newBackend, nil
+}
+
+// Devices returns a static inventory of GPU cards.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	result := make([]gpus.Card, numGPUs)
+
+	for i := range numGPUs {
+		result[i] = gpus.Card{
+			Cardseries: "AMD Instinct MI210",
+			Cardmodel:  "0x0c34",
+			Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+			CardSKU:    "D67301",
+			PCIBus:     fmt.Sprintf("0000:%02x:00.0", i+1),
+			CardGUID:   fmt.Sprintf("%d", 1000+i),
+
+			VBIOSVersion: "113-D67301-063",
+			GFXVersion:   "gfx90a",
+
+			// both GPUs are local to the single socket.
+			NUMANode:    "0",
+			Socket:      "0",
+			LocalCPUs:   fmt.Sprintf("0-%d", numThreads-1),
+			RootComplex: "0000:00",
+		}
+	}
+
+	return result, nil
+}
+
+// ReadGPUs fills given params with static GPU readings.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	stat.ResizeGPUs(numGPUs)
+
+	for i := range numGPUs {
+		stat.GPUDevID[i] = gpus.NewReading(gpuDeviceID)
+		stat.GPUPowerCap[i] = gpus.NewReading(gpuPowerCap)
+		stat.GPUPower[i] = gpus.NewReading(gpuPower)
+		stat.GPUTemperature[i] = gpus.NewReading(gpuTemperature)
+		stat.GPUSCLK[i] = gpus.NewReading(gpuSCLK)
+		stat.GPUMCLK[i] = gpus.NewReading(gpuMCLK)
+		stat.GPUUsage[i] = gpus.NewReading(gpuUsage)
+		stat.GPUMemoryUsage[i] = gpus.NewReading(gpuMemoryUsage)
+		stat.GPUVRAMTotal[i] = gpus.NewReading(gpuVRAMTotal)
+		stat.GPUVRAMUsed[i] = gpus.NewReading(gpuVRAMUsed)
+		stat.GPUVisVRAMUsed[i] = gpus.NewReading(gpuVRAMUsed)
+		stat.GPUGTTUsed[i] = gpus.NewReading(gpuGTTUsed)
+		stat.GPURetiredPages[i] = gpus.NewReading(0)
+		stat.GPUPCIeSpeed[i] = gpus.NewReading(gpuPCIeSpeed)
+		stat.GPUPCIeMaxSpeed[i] = gpus.NewReading(gpuPCIeSpeed)
+		stat.GPUPCIeWidth[i] = gpus.NewReading(gpuPCIeWidth)
+		stat.GPUPCIeMaxWidth[i] = gpus.NewReading(gpuPCIeWidth)
+		stat.GPUPCIeReplays[i] = gpus.NewReading(0)
+		stat.GPUPCIeNAKSent[i] = gpus.NewReading(0)
+		stat.GPUPCIeNAKReceived[i] = gpus.NewReading(0)
+		stat.GPUPCIeBandwidth[i] = gpus.NewReading(gpuPCIeBandwidth)
+
+		for block := range gpus.NumRASBlocks {
+			stat.GPUECCCorrectable[block][i] = gpus.NewReading(0)
+			stat.GPUECCUncorrectable[block][i] = gpus.NewReading(0)
+		}
+
+		stat.GPUTemperatures[gpus.TemperatureSensorEdge][i] = gpus.NewReading(gpuTemperature)
+		stat.GPUTemperatures[gpus.TemperatureSensorJunction][i] = gpus.NewReading(gpuJunctionTemp)
+		stat.GPUTemperatures[gpus.TemperatureSensorMemory][i] = gpus.NewReading(gpuMemoryTemp)
+		stat.GPUTemperatureCritical[gpus.TemperatureSensorJunction][i] = gpus.NewReading(gpuCriticalTemp)
+		stat.GPUTemperatureEmergency[gpus.TemperatureSensorJunction][i] = gpus.NewReading(gpuEmergencyTemp)
+		stat.GPUVoltage[gpus.VoltageRailGFX][i] = gpus.NewReading(gpuGFXVoltage)
+		stat.GPUVoltage[gpus.VoltageRailSoC][i] = gpus.NewReading(gpuSoCVoltage)
+
+		for reason := range gpus.NumThrottleReasons {
+			stat.GPUThrottled[reason][i] = gpus.NewReading(0)
+			stat.GPUThrottledSeconds[reason][i] = gpus.NewReading(0)
+		}
+
+		stat.GPUXGMILinks[i] = xgmiLinks(int(i))
+		stat.GPUXGMIWidth[i] = gpus.NewReading(gpuXGMIWidth)
+		stat.GPUXGMISpeed[i] = gpus.NewReading(gpuXGMISpeed)
+	}
+
+	return nil
+}
+
+// xgmiLinks returns static links from the given GPU to every other GPU.
+func xgmiLinks(gpu int) []gpus.XGMILink {
+	links := make([]gpus.XGMILink, 0, numGPUs-1)
+
+	for peer := range int(numGPUs) {
+		if peer == gpu {
+			continue
+		}
+
+		links = append(links, gpus.XGMILink{
+			Peer:       peer,
+			Status:     gpus.NewReading(1),
+			ReadBytes:  gpus.NewReading(gpuXGMIBytes),
+			WriteBytes: gpus.NewReading(gpuXGMIBytes),
+		})
+	}
+
+	return links
+}
+
+// ReadCPUs fills given params with static CPU readings.
+func (b *Backend) ReadCPUs(stat *gpus.AMDParams) error {
+	stat.ResizeCPUs(numSockets, numThreads)
+	stat.ThreadsPerCore = numThreadsPerCore
+
+	stat.CPUTopology = make([]gpus.CPUPlacement, numThreads)
+
+	for i := range numThreads {
+		stat.CoreEnergy[i] = gpus.NewReading(coreEnergy)
+		stat.CoreBoost[i] = gpus.NewReading(coreBoost)
+		// SMT siblings of the single CCX are numbered after the first thread of every core.
+		stat.CPUTopology[i] = gpus.NewCPUPlacement(0, int(i%(numThreads/numThreadsPerCore)), 0)
+	}
+
+	for i := range numSockets {
+		stat.SocketEnergy[i] = gpus.NewReading(socketEnergy)
+		stat.SocketPower[i] = gpus.NewReading(socketPower)
+		stat.PowerLimit[i] = gpus.NewReading(socketPowerLimit)
+		stat.ProchotStatus[i] = gpus.NewReading(prochotStatus)
+		stat.SocketTemperatures[gpus.SocketTemperatureTctl][i] = gpus.NewReading(socketTctl)
+		stat.CCDTemperatures[i] = make([]gpus.Reading, numCCDs)
+
+		for ccd := range numCCDs {
+			stat.CCDTemperatures[i][ccd] = gpus.NewReading(ccdTemperature)
+		}
+
+		stat.SocketDDRBandwidth[i] = gpus.NewReading(ddrBandwidth)
+		stat.SocketDDRMaxBandwidth[i] = gpus.NewReading(ddrMaxBandwidth)
+		stat.SocketDDRUtilization[i] = gpus.NewReading(ddrUtilization)
+		stat.SocketFCLK[i] = gpus.NewReading(socketFCLK)
+		stat.SocketMCLK[i] = gpus.NewReading(socketMCLK)
+		stat.SocketC0Residency[i] = gpus.NewReading(c0Residency)
+		stat.SocketFrequencyLimit[i] = gpus.NewReading(frequencyLimit)
+		stat.SocketSVIPower[i] = gpus.NewReading(sviPower)
+
+		for source := range gpus.NumFrequencyLimitSources {
+			stat.SocketFrequencyLimited[source][i] = gpus.NewReading(0)
+		}
+
+		stat.SocketFrequencyLimited[gpus.FrequencyLimitOPNMax][i] = gpus.NewReading(1)
+	}
+
+	return nil
+}
+
+// Close does nothing.
+func (b *Backend) Close() error {
+	b.logger.Debug("closing fake backend")
+
+	return nil
+}
@@ -0,0 +1,366 @@
+// Package gpumetrics parses the gpu_metrics binary table exposed by the amdgpu driver
+// in sysfs, the table is a C structure whose layout depends on its format and content
+// revisions, see kgd_pp_interface.h in the Linux kernel sources.
+package gpumetrics
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// table header and value conversion constants.
+const (
+	headerSize       int     = 4
+	megahertzToHertz float64 = 1e6
+	gigabytesToBytes float64 = 1e9
+	kilobytesToBytes float64 = 1 << 10
+	// energyResolution is the energy of an energy accumulator unit in microjoules, the same
+	// resolution is assumed by rocm-smi for every ASIC.
+	energyResolution float64 = 15.3
+)
+
+// ASIC independent throttler bits of indep_throttle_status, bits are grouped by reason.
+const (
+	powerThrottlerBits   uint64 = 0xffff
+	currentThrottlerBits uint64 = 0xffff << 16
+	thermalThrottlerBits uint64 = 0x3fff << 32
+	prochotThrottlerBits uint64 = 0x3 << 46
+)
+
+// Throttler bits of the throttle_status of MI300 series firmware (smu_v13_0_6).
+const (
+	mi300ProchotThrottlerBits uint64 = 0x1
+	mi300PowerThrottlerBits   uint64 = 0x2
+	mi300ThermalThrottlerBits uint64 = 0x1c
+)
+
+// parsing errors.
+var (
+	// ErrUnsupportedVersion is returned when the table version is not known by the parser.
+	ErrUnsupportedVersion = errors.New("unsupported gpu_metrics version")
+	errTableSize          = errors.New("unexpected gpu_metrics size")
+)
+
+// averageClockMembers contains the table members of the average frequency of each clock domain.
+var averageClockMembers = [gpus.NumClockDomains]string{
+	gpus.ClockDomainGFX:    "average_gfxclk_frequency",
+	gpus.ClockDomainSoC:    "average_socclk_frequency",
+	gpus.ClockDomainMemory: "average_uclk_frequency",
+}
+
+// residencyMembers contains the accumulators of firmware samples throttled by each reason,
+// reasons with several accumulators are throttled by any of them.
+var residencyMembers = [gpus.NumThrottleReasons][]string{
+	gpus.ThrottleReasonThermal: {"socket_thm_residency_acc", "vr_thm_residency_acc", "hbm_thm_residency_acc"},
+	gpus.ThrottleReasonPower:   {"ppt_residency_acc"},
+	gpus.ThrottleReasonProchot: {"prochot_residency_acc"},
+}
+
+// throttlerBits contains indep_throttle_status bits of each reason.
+var throttlerBits = [gpus.NumThrottleReasons]uint64{
+	gpus.ThrottleReasonThermal: thermalThrottlerBits,
+	gpus.ThrottleReasonPower:   powerThrottlerBits,
+	gpus.ThrottleReasonProchot: prochotThrottlerBits,
+	gpus.ThrottleReasonCurrent: currentThrottlerBits,
+}
+
+// asicThrottlerBits contains the throttle_status bits of each reason for table versions
+// without indep_throttle_status whose ASIC is known, reasons without bits are not reported.
+var asicThrottlerBits = map[revision][gpus.NumThrottleReasons]uint64{
+	{format: 1, content: 4}: mi300ThrottlerBits,
+	{format: 1, content: 5}: mi300ThrottlerBits,
+}
+
+// mi300ThrottlerBits contains throttle_status bits of each reason reported by MI300 series firmware.
+var mi300ThrottlerBits = [gpus.NumThrottleReasons]uint64{
+	gpus.ThrottleReasonThermal: mi300ThermalThrottlerBits,
+	gpus.ThrottleReasonPower:   mi300PowerThrottlerBits,
+	gpus.ThrottleReasonProchot: mi300ProchotThrottlerBits,
+}
+
+// Table contains the values read from a gpu_metrics table, values not provided by
+// the table version or not filled by the ASIC are unsupported readings.
+type Table struct {
+	FormatRevision  uint8
+	ContentRevision uint8
+	// SystemClockCounter is the driver timestamp of the table in nanoseconds.
+	SystemClockCounter gpus.Reading
+	// EnergyAccumulator is the energy consumed by the socket, its unit depends on the ASIC.
+	EnergyAccumulator gpus.Reading
+	// Energy is the energy accumulator in microjoules, it is only provided by 64 bit
+	// accumulators since 32 bit ones wrap around within minutes at full power.
+	Energy gpus.Reading
+	// AverageClocks contains the average frequency of each clock domain in hertz.
+	AverageClocks [gpus.NumClockDomains]gpus.Reading
+	// Throttled is 1 for the reasons throttling clocks when the table was sampled,
+	// it is provided by tables with ASIC independent throttle status and by MI300
+	// series tables with ASIC dependent throttle status.
+	Throttled [gpus.NumThrottleReasons]gpus.Reading
+	// AccumulationCounter is the number of firmware samples and ThrottleResidency is the
+	// number of them throttled by each reason, they are provided by MI300 series tables
+	// without throttle status.
+	AccumulationCounter gpus.Reading
+	ThrottleResidency   [gpus.NumThrottleReasons]gpus.Reading
+	// XCDActivity contains the instantaneous activity in percent of the XCDs of each compute partition.
+	XCDActivity [][]gpus.Reading
+	// PCIeBandwidthAccumulator is the sum of the pcie bandwidth in gigabytes per second sampled by
+	// the firmware and PCIeBandwidth is the instantaneous bandwidth in bytes per second.
+	PCIeBandwidthAccumulator gpus.Reading
+	PCIeBandwidth            gpus.Reading
+	// PCIeReplays, PCIeNAKsSent and PCIeNAKsReceived are pcie counters accumulated by the firmware,
+	// NAK counters are provided from content revision 5.
+	PCIeReplays      gpus.Reading
+	PCIeNAKsSent     gpus.Reading
+	PCIeNAKsReceived gpus.Reading
+	// XGMILinkWidth is the width in lanes and XGMILinkSpeed the speed in Gb/s per lane of the XGMI links,
+	// the table reports them for the GPU as a whole.
+	XGMILinkWidth gpus.Reading
+	XGMILinkSpeed gpus.Reading
+	// XGMIReadBytes and XGMIWriteBytes contain the data transferred by each XGMI link in bytes,
+	// links are indexed by the GPU at the other end in the order of the hive.
+	XGMIReadBytes  []gpus.Reading
+	XGMIWriteBytes []gpus.Reading
+	// XGMILinkStatus is 1 for each XGMI link that is up and 0 for links that are down, it is
+	// indexed as XGMIReadBytes and provided from content revision 7.
+	XGMILinkStatus []gpus.Reading
+}
+
+// Parse parses a gpu_metrics table, ErrUnsupportedVersion is returned for unknown versions.
+func Parse(data []byte) (*Table, error) {
+	if len(data) < headerSize {
+		return nil, fmt.Errorf("%w: %d bytes is shorter than the header", errTableSize, len(data))
+	}
+
+	table := Table{
+		FormatRevision:  data[2],
+		ContentRevision: data[3],
+	}
+
+	l, exist := layouts[revision{format: table.FormatRevision, content: table.ContentRevision}]
+	if !exist {
+		return nil, fmt.Errorf("%w: v%d.%d", ErrUnsupportedVersion, table.FormatRevision, table.ContentRevision)
+	}
+
+	structureSize := int(binary.LittleEndian.Uint16(data))
+	if structureSize != l.size || len(data) < l.size {
+		return nil, fmt.Errorf(
+			"%w: v%d.%d table of %d bytes declares %d bytes, %d bytes expected",
+			errTableSize, table.FormatRevision, table.ContentRevision, len(data), structureSize, l.size,
+		)
+	}
+
+	d := decoder{data: data, layout: l}
+
+	table.SystemClockCounter = d.reading("system_clock_counter")
+	table.EnergyAccumulator = d.reading("energy_accumulator")
+	if table.EnergyAccumulator.Valid() && d.layout.members["energy_accumulator"].size == 8 {
+		table.Energy = gpus.NewReading(table.EnergyAccumulator.Value * energyResolution)
+	}
+
+	for clock, name := range averageClockMembers {
+		table.AverageClocks[clock] = d.reading(name)
+		if table.AverageClocks[clock].Valid() {
+			table.AverageClocks[clock].Value *= megahertzToHertz
+		}
+	}
+
+	d.decodeThrottleStatus(&table)
+	d.decodeThrottleResidency(&table)
+	table.XCDActivity = d.xcdActivity()
+	d.decodePCIe(&table)
+	d.decodeXGMI(&table)
+
+	return &table, nil
+}
+
+// PartitionActivity returns the average activity in percent of the XCDs of the given compute
+// partition, it is unsupported when the table does not provide the activity of the partition.
+func (t *Table) PartitionActivity(partition int) gpus.Reading {
+	if partition >= len(t.XCDActivity) || len(t.XCDActivity[partition]) == 0 {
+		return gpus.Reading{}
+	}
+
+	var total float64
+
+	for _, activity := range t.XCDActivity[partition] {
+		total += activity.Value
+	}
+
+	return gpus.NewReading(total / float64(len(t.XCDActivity[partition])))
+}
+
+// decoder reads little endian members of a table, amdgpu is only supported by little endian hosts.
+type decoder struct {
+	data   []byte
+	layout *layout
+}
+
+// value reads the element of an integer member at the given offset, false is returned when
+// the value has all bits set since the driver fills values not provided by the ASIC that way.
+func (d decoder) value(offset, size int) (uint64, bool) {
+	var value, unset uint64
+
+	switch size {
+	case 1:
+		value, unset = uint64(d.data[offset]), 0xff
+	case 2:
+		value, unset = uint64(binary.LittleEndian.Uint16(d.data[offset:])), 0xffff
+	case 4:
+		value, unset = uint64(binary.LittleEndian.Uint32(d.data[offset:])), 0xffffffff
+	default:
+		value, unset = binary.LittleEndian.Uint64(d.data[offset:]), 0xffffffffffffffff
+	}
+
+	return value, value != unset
+}
+
+// member reads the given integer member, false is returned when it is not part of the table or it is unset.
+func (d decoder) member(name string) (uint64, bool) {
+	m, exist := d.layout.members[name]
+	if !exist {
+		return 0, false
+	}
+
+	return d.value(m.offset, m.size)
+}
+
+// reading reads the given integer member as a reading, unsupported when the member is not available.
+func (d decoder) reading(name string) gpus.Reading {
+	value, exist := d.member(name)
+	if !exist {
+		return gpus.Reading{}
+	}
+
+	return gpus.NewReading(float64(value))
+}
+
+// readings reads the elements of the given integer array member multiplied by the given factor,
+// unset elements are unsupported readings and nil is returned when the member is not available.
+func (d decoder) readings(name string, factor float64) []gpus.Reading {
+	m, exist := d.layout.members[name]
+	if !exist {
+		return nil
+	}
+
+	result := make([]gpus.Reading, m.count)
+
+	for i := range result {
+		if value, exist := d.value(m.offset+i*m.size, m.size); exist {
+			result[i] = gpus.NewReading(float64(value) * factor)
+		}
+	}
+
+	return result
+}
+
+// decodeThrottleStatus sets throttle reasons from the ASIC independent throttle status,
+// the ASIC dependent throttle_status member is only decoded for versions whose ASIC is
+// known since its bits vary by ASIC.
+func (d decoder) decodeThrottleStatus(table *Table) {
+	member, reasonBits := "indep_throttle_status", throttlerBits
+	if _, exist := d.layout.members[member]; !exist {
+		var known bool
+
+		member = "throttle_status"
+		reasonBits, known = asicThrottlerBits[revision{format: table.FormatRevision, content: table.ContentRevision}]
+		if !known {
+			return
+		}
+	}
+
+	status, exist := d.member(member)
+	if !exist {
+		return
+	}
+
+	for reason, bits := range reasonBits {
+		if bits == 0 {
+			continue
+		}
+
+		table.Throttled[reason] = gpus.NewReading(0)
+		if status&bits != 0 {
+			table.Throttled[reason] = gpus.NewReading(1)
+		}
+	}
+}
+
+// decodeThrottleResidency sets the firmware samples throttled by each reason.
+func (d decoder) decodeThrottleResidency(table *Table) {
+	table.AccumulationCounter = d.reading("accumulation_counter")
+	if !table.AccumulationCounter.Valid() {
+		return
+	}
+
+	for reason, names := range residencyMembers {
+		for _, name := range names {
+			residency := d.reading(name)
+			if !residency.Valid() {
+				continue
+			}
+
+			table.ThrottleResidency[reason] = gpus.NewReading(table.ThrottleResidency[reason].Value + residency.Value)
+		}
+	}
+}
+
+// decodePCIe sets the pcie bandwidth and counters accumulated by the firmware of MI300 series.
+func (d decoder) decodePCIe(table *Table) {
+	table.PCIeBandwidthAccumulator = d.reading("pcie_bandwidth_acc")
+	table.PCIeBandwidth = d.reading("pcie_bandwidth_inst")
+	if table.PCIeBandwidth.Valid() {
+		table.PCIeBandwidth.Value *= gigabytesToBytes
+	}
+
+	table.PCIeReplays = d.reading("pcie_replay_count_acc")
+	table.PCIeNAKsSent = d.reading("pcie_nak_sent_count_acc")
+	table.PCIeNAKsReceived = d.reading("pcie_nak_rcvd_count_acc")
+}
+
+// decodeXGMI sets the state and the data accumulated by the XGMI links of MI300 series.
+func (d decoder) decodeXGMI(table *Table) {
+	table.XGMILinkWidth = d.reading("xgmi_link_width")
+	table.XGMILinkSpeed = d.reading("xgmi_link_speed")
+	table.XGMIReadBytes = d.readings("xgmi_read_data_acc", kilobytesToBytes)
+	table.XGMIWriteBytes = d.readings("xgmi_write_data_acc", kilobytesToBytes)
+	table.XGMILinkStatus = d.readings("xgmi_link_status", 1)
+
+	// the driver stores the error of links whose status could not be read.
+	for l, status := range table.XGMILinkStatus {
+		if status.Valid() && status.Value > 1 {
+			table.XGMILinkStatus[l] = gpus.Reading{}
+		}
+	}
+}
+
+// xcdActivity reads the instantaneous activity of the XCDs of each compute partition,
+// XCDs not present in a partition are unset.
+func (d decoder) xcdActivity() [][]gpus.Reading {
+	partitions, exist := d.member("num_partition")
+	if !exist {
+		return nil
+	}
+
+	stats := d.layout.members["xcp_stats"]
+	busy := stats.layout.members["gfx_busy_inst"]
+
+	result := make([][]gpus.Reading, min(int(partitions), stats.count))
+
+	for partition := range result {
+		for xcd := range busy.count {
+			offset := stats.offset + partition*stats.size + busy.offset + xcd*busy.size
+
+			value, exist := d.value(offset, busy.size)
+			if !exist {
+				continue
+			}
+
+			result[partition] = append(result[partition], gpus.NewReading(float64(value)))
+		}
+	}
+
+	return result
+}
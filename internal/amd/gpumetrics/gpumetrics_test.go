@@ -0,0 +1,336 @@
+package gpumetrics_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/gpumetrics"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParse decodes the tables of testdata, which are synthetic: they are written with the offsets of the
+// layouts of the parser, so they test decoding but do not verify the layouts against the driver.
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	zero, one := gpus.NewReading(0), gpus.NewReading(1)
+	energy := func(accumulator float64) gpus.Reading { return gpus.NewReading(accumulator * 15.3) }
+	kilobytes := func(values ...float64) []gpus.Reading {
+		result := make([]gpus.Reading, len(values))
+		for i, value := range values {
+			result[i] = gpus.NewReading(value * 1024)
+		}
+
+		return result
+	}
+
+	tests := []struct {
+		file      string
+		wantTable gpumetrics.Table
+	}{
+		{
+			file: "gpu_metrics_v1_0.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:     1,
+				ContentRevision:    0,
+				SystemClockCounter: gpus.NewReading(1.5e12),
+				EnergyAccumulator:  gpus.NewReading(4e9),
+				AverageClocks:      [gpus.NumClockDomains]gpus.Reading{{}, gpus.NewReading(1090e6), gpus.NewReading(900e6)},
+			},
+		},
+		{
+			file: "gpu_metrics_v1_3.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:     1,
+				ContentRevision:    3,
+				SystemClockCounter: gpus.NewReading(1e12),
+				EnergyAccumulator:  gpus.NewReading(123456789),
+				Energy:             energy(123456789),
+				AverageClocks: [gpus.NumClockDomains]gpus.Reading{
+					gpus.NewReading(1700e6), gpus.NewReading(1090e6), gpus.NewReading(1600e6),
+				},
+				Throttled: [gpus.NumThrottleReasons]gpus.Reading{one, one, zero, zero},
+			},
+		},
+		{
+			file: "gpu_metrics_v1_4.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:           1,
+				ContentRevision:          4,
+				SystemClockCounter:       gpus.NewReading(6e12),
+				EnergyAccumulator:        gpus.NewReading(9e9),
+				Energy:                   energy(9e9),
+				Throttled:                [gpus.NumThrottleReasons]gpus.Reading{zero, zero, zero, {}},
+				PCIeBandwidthAccumulator: gpus.NewReading(654321),
+				PCIeBandwidth:            gpus.NewReading(20e9),
+				PCIeReplays:              gpus.NewReading(5),
+				XGMILinkWidth:            gpus.NewReading(16),
+				XGMILinkSpeed:            gpus.NewReading(32),
+				XGMIReadBytes:            kilobytes(0, 100, 200, 300, 400, 500, 600, 700),
+				XGMIWriteBytes:           kilobytes(0, 150, 250, 350, 450, 550, 650, 750),
+			},
+		},
+		{
+			file: "gpu_metrics_v1_5.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:           1,
+				ContentRevision:          5,
+				SystemClockCounter:       gpus.NewReading(5e12),
+				EnergyAccumulator:        gpus.NewReading(7e9),
+				Energy:                   energy(7e9),
+				Throttled:                [gpus.NumThrottleReasons]gpus.Reading{zero, one, zero, {}},
+				PCIeBandwidthAccumulator: gpus.NewReading(123456),
+				PCIeBandwidth:            gpus.NewReading(12e9),
+				PCIeReplays:              gpus.NewReading(7),
+				PCIeNAKsSent:             gpus.NewReading(2),
+				PCIeNAKsReceived:         gpus.NewReading(1),
+				XGMILinkWidth:            gpus.NewReading(16),
+				XGMILinkSpeed:            gpus.NewReading(32),
+				XGMIReadBytes:            kilobytes(1000, 2000, 3000, 4000, 5000, 6000, 7000, 0),
+				XGMIWriteBytes:           kilobytes(1100, 2100, 3100, 4100, 5100, 6100, 7100, 0),
+			},
+		},
+		{
+			file: "gpu_metrics_v1_6.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:      1,
+				ContentRevision:     6,
+				SystemClockCounter:  gpus.NewReading(2e12),
+				EnergyAccumulator:   gpus.NewReading(5e9),
+				Energy:              energy(5e9),
+				AccumulationCounter: gpus.NewReading(1000),
+				ThrottleResidency: [gpus.NumThrottleReasons]gpus.Reading{
+					gpus.NewReading(15), gpus.NewReading(250), gpus.NewReading(0), {},
+				},
+				XCDActivity: [][]gpus.Reading{{
+					gpus.NewReading(90), gpus.NewReading(80), gpus.NewReading(70), gpus.NewReading(60),
+					gpus.NewReading(50), gpus.NewReading(40), gpus.NewReading(30), gpus.NewReading(20),
+				}},
+				PCIeBandwidthAccumulator: zero,
+				PCIeBandwidth:            zero,
+				PCIeReplays:              zero,
+				PCIeNAKsSent:             zero,
+				PCIeNAKsReceived:         zero,
+				XGMILinkWidth:            gpus.NewReading(16),
+				XGMILinkSpeed:            gpus.NewReading(32),
+				XGMIReadBytes:            kilobytes(0, 0, 0, 0, 0, 0, 0, 0),
+				XGMIWriteBytes:           kilobytes(0, 0, 0, 0, 0, 0, 0, 0),
+			},
+		},
+		{
+			file: "gpu_metrics_v1_7.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:      1,
+				ContentRevision:     7,
+				SystemClockCounter:  gpus.NewReading(7e12),
+				EnergyAccumulator:   gpus.NewReading(8e9),
+				Energy:              energy(8e9),
+				AccumulationCounter: gpus.NewReading(2000),
+				ThrottleResidency: [gpus.NumThrottleReasons]gpus.Reading{
+					gpus.NewReading(10), gpus.NewReading(400), gpus.NewReading(0), {},
+				},
+				PCIeBandwidthAccumulator: gpus.NewReading(222222),
+				PCIeBandwidth:            gpus.NewReading(30e9),
+				PCIeReplays:              gpus.NewReading(4),
+				PCIeNAKsSent:             gpus.NewReading(6),
+				PCIeNAKsReceived:         gpus.NewReading(5),
+				XGMILinkWidth:            gpus.NewReading(16),
+				XGMILinkSpeed:            gpus.NewReading(32),
+				XGMIReadBytes:            kilobytes(0, 1000, 2000, 3000, 4000, 5000, 6000, 7000),
+				XGMIWriteBytes:           kilobytes(0, 1500, 2500, 3500, 4500, 5500, 6500, 7500),
+				XGMILinkStatus:           []gpus.Reading{{}, one, one, zero, one, one, one, one},
+			},
+		},
+		{
+			file: "gpu_metrics_v2_2.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:     2,
+				ContentRevision:    2,
+				SystemClockCounter: gpus.NewReading(3e9),
+				AverageClocks:      [gpus.NumClockDomains]gpus.Reading{gpus.NewReading(1200e6), gpus.NewReading(800e6), {}},
+				Throttled:          [gpus.NumThrottleReasons]gpus.Reading{zero, zero, one, zero},
+			},
+		},
+		{
+			file: "gpu_metrics_v3_0.bin",
+			wantTable: gpumetrics.Table{
+				FormatRevision:     3,
+				ContentRevision:    0,
+				SystemClockCounter: gpus.NewReading(4e9),
+				AverageClocks: [gpus.NumClockDomains]gpus.Reading{
+					gpus.NewReading(2900e6), gpus.NewReading(1000e6), gpus.NewReading(2800e6),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			data, err := os.ReadFile(filepath.Join("testdata", test.file))
+			require.NoError(t, err)
+
+			// When
+			got, err := gpumetrics.Parse(data)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, &test.wantTable, got)
+		})
+	}
+}
+
+func TestParseInvalidTables(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		data        []byte
+		unsupported bool
+	}{
+		{name: "short header", data: []byte{0x78, 0x00, 0x01}},
+		{name: "unknown version", data: []byte{0x08, 0x00, 0x01, 0x63, 0, 0, 0, 0}, unsupported: true},
+		{name: "unexpected structure size", data: append([]byte{0x7c, 0x00, 0x01, 0x03}, make([]byte, 120)...)},
+		{name: "truncated table", data: append([]byte{0x78, 0x00, 0x01, 0x03}, make([]byte, 60)...)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			got, err := gpumetrics.Parse(test.data)
+
+			// Then
+			require.Error(t, err)
+			assert.Nil(t, got)
+			assert.Equal(t, test.unsupported, errors.Is(err, gpumetrics.ErrUnsupportedVersion))
+		})
+	}
+}
+
+func TestPartitionActivity(t *testing.T) {
+	t.Parallel()
+	// Given
+	table := gpumetrics.Table{
+		XCDActivity: [][]gpus.Reading{
+			{gpus.NewReading(90), gpus.NewReading(70)},
+			{gpus.NewReading(10), gpus.NewReading(20)},
+			nil,
+		},
+	}
+
+	// When
+	got := []gpus.Reading{
+		table.PartitionActivity(0),
+		table.PartitionActivity(1),
+		table.PartitionActivity(2),
+		table.PartitionActivity(3),
+	}
+
+	// Then
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(80), gpus.NewReading(15), {}, {}}, got)
+}
+
+func TestThrottleTrackerStatus(t *testing.T) {
+	t.Parallel()
+	// Given
+	tracker := gpumetrics.NewThrottleTracker()
+
+	var stat gpus.AMDParams
+	stat.Init()
+	stat.ResizeGPUs(2)
+
+	zero, one := gpus.NewReading(0), gpus.NewReading(1)
+	first := gpumetrics.Table{
+		SystemClockCounter: gpus.NewReading(10e9),
+		Throttled:          [gpus.NumThrottleReasons]gpus.Reading{zero, one, zero, zero},
+	}
+	second := gpumetrics.Table{
+		SystemClockCounter: gpus.NewReading(25e9),
+		Throttled:          [gpus.NumThrottleReasons]gpus.Reading{one, one, zero, zero},
+	}
+
+	// When
+	tracker.Update(&first, 1, &stat)
+	tracker.Update(&second, 1, &stat)
+
+	// Then
+	assert.Equal(t, one, stat.GPUThrottled[gpus.ThrottleReasonThermal][1])
+	assert.Equal(t, one, stat.GPUThrottled[gpus.ThrottleReasonPower][1])
+	assert.Equal(t, zero, stat.GPUThrottled[gpus.ThrottleReasonProchot][1])
+	assert.Equal(t, gpus.NewReading(15), stat.GPUThrottledSeconds[gpus.ThrottleReasonThermal][1])
+	assert.Equal(t, gpus.NewReading(15), stat.GPUThrottledSeconds[gpus.ThrottleReasonPower][1])
+	assert.Equal(t, zero, stat.GPUThrottledSeconds[gpus.ThrottleReasonCurrent][1])
+	assert.Equal(t, gpus.Reading{}, stat.GPUThrottledSeconds[gpus.ThrottleReasonPower][0])
+}
+
+func TestThrottleTrackerResidency(t *testing.T) {
+	t.Parallel()
+	// Given
+	tracker := gpumetrics.NewThrottleTracker()
+
+	var stat gpus.AMDParams
+	stat.Init()
+	stat.ResizeGPUs(1)
+
+	first := gpumetrics.Table{
+		SystemClockCounter:  gpus.NewReading(10e9),
+		AccumulationCounter: gpus.NewReading(1000),
+		ThrottleResidency:   [gpus.NumThrottleReasons]gpus.Reading{gpus.NewReading(5), gpus.NewReading(100), gpus.NewReading(0), {}},
+	}
+	second := gpumetrics.Table{
+		SystemClockCounter:  gpus.NewReading(20e9),
+		AccumulationCounter: gpus.NewReading(2000),
+		ThrottleResidency:   [gpus.NumThrottleReasons]gpus.Reading{gpus.NewReading(5), gpus.NewReading(350), gpus.NewReading(0), {}},
+	}
+
+	// When
+	tracker.Update(&first, 0, &stat)
+	firstThrottled := stat.GPUThrottled[gpus.ThrottleReasonPower][0]
+	tracker.Update(&second, 0, &stat)
+
+	// Then
+	assert.Equal(t, gpus.Reading{}, firstThrottled)
+	assert.Equal(t, gpus.NewReading(0), stat.GPUThrottled[gpus.ThrottleReasonThermal][0])
+	assert.Equal(t, gpus.NewReading(1), stat.GPUThrottled[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.NewReading(0), stat.GPUThrottledSeconds[gpus.ThrottleReasonThermal][0])
+	assert.Equal(t, gpus.NewReading(2.5), stat.GPUThrottledSeconds[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUThrottled[gpus.ThrottleReasonCurrent][0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUThrottledSeconds[gpus.ThrottleReasonCurrent][0])
+}
+
+func TestThrottleTrackerASICThrottleStatus(t *testing.T) {
+	t.Parallel()
+	// Given
+	data, err := os.ReadFile(filepath.Join("testdata", "gpu_metrics_v1_5.bin"))
+	require.NoError(t, err)
+
+	first, err := gpumetrics.Parse(data)
+	require.NoError(t, err)
+
+	second := *first
+	second.SystemClockCounter = gpus.NewReading(first.SystemClockCounter.Value + 10e9)
+
+	tracker := gpumetrics.NewThrottleTracker()
+
+	var stat gpus.AMDParams
+	stat.Init()
+	stat.ResizeGPUs(1)
+
+	// When
+	tracker.Update(first, 0, &stat)
+	tracker.Update(&second, 0, &stat)
+
+	// Then
+	assert.Equal(t, gpus.NewReading(1), stat.GPUThrottled[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.NewReading(10), stat.GPUThrottledSeconds[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.NewReading(0), stat.GPUThrottled[gpus.ThrottleReasonThermal][0])
+	assert.Equal(t, gpus.NewReading(0), stat.GPUThrottledSeconds[gpus.ThrottleReasonThermal][0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUThrottled[gpus.ThrottleReasonCurrent][0])
+	assert.Equal(t, gpus.Reading{}, stat.GPUThrottledSeconds[gpus.ThrottleReasonCurrent][0])
+}
@@ -0,0 +1,97 @@
+package gpumetrics
+
+// field is a member of a gpu_metrics C structure, count is the number of elements of arrays.
+type field struct {
+	name   string
+	size   int
+	align  int
+	count  int
+	layout *layout
+}
+
+// member is the position of a field within a structure.
+type member struct {
+	offset int
+	size   int
+	count  int
+	// layout is the layout of structure members, nil for integer members.
+	layout *layout
+}
+
+// layout contains the members of a C structure placed with natural alignment
+// as the compiler does for the structures declared by kgd_pp_interface.h.
+type layout struct {
+	members map[string]member
+	size    int
+	align   int
+}
+
+// newLayout places given fields in order, padding each one to its alignment and
+// the whole structure to the alignment of its largest member.
+func newLayout(fields ...field) *layout {
+	result := layout{
+		members: make(map[string]member, len(fields)),
+		align:   1,
+	}
+
+	offset := 0
+
+	for _, f := range fields {
+		offset = alignTo(offset, f.align)
+		result.members[f.name] = member{offset: offset, size: f.size, count: f.count, layout: f.layout}
+		offset += f.size * f.count
+		result.align = max(result.align, f.align)
+	}
+
+	result.size = alignTo(offset, result.align)
+
+	return &result
+}
+
+// newTableLayout places given fields after the common metrics table header.
+func newTableLayout(fields ...field) *layout {
+	header := []field{u16("structure_size"), u8("format_revision"), u8("content_revision")}
+
+	return newLayout(append(header, fields...)...)
+}
+
+func alignTo(offset, align int) int {
+	return (offset + align - 1) / align * align
+}
+
+func u8(name string) field  { return array(name, 1, 1) }
+func u16(name string) field { return array(name, 2, 1) }
+func u32(name string) field { return array(name, 4, 1) }
+func u64(name string) field { return array(name, 8, 1) }
+
+// u16s returns a field for each given name, it shortens runs of members of the same type.
+func u16s(names ...string) []field {
+	result := make([]field, len(names))
+
+	for i, name := range names {
+		result[i] = u16(name)
+	}
+
+	return result
+}
+
+// array returns an array field of count integers of the given size.
+func array(name string, size, count int) field {
+	return field{name: name, size: size, align: size, count: count}
+}
+
+// structArray returns an array field of count structures of the given layout.
+func structArray(name string, l *layout, count int) field {
+	return field{name: name, size: l.size, align: l.align, count: count, layout: l}
+}
+
+// fields concatenates groups of fields.
+func fields(groups ...[]field) []field {
+	var result []field
+
+	for _, group := range groups {
+		result = append(result, group...)
+	}
+
+	return result
+}
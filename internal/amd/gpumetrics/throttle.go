@@ -0,0 +1,82 @@
+package gpumetrics
+
+import (
+	"sync"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+const nanosecondsPerSecond float64 = 1e9
+
+// ThrottleTracker accumulates the time a GPU spends throttled by each reason across
+// the tables read from it, the first table only sets the starting point.
+type ThrottleTracker struct {
+	mutex        sync.Mutex
+	started      bool
+	timestamp    float64
+	accumulation float64
+	residency    [gpus.NumThrottleReasons]gpus.Reading
+	throttled    [gpus.NumThrottleReasons]gpus.Reading
+	seconds      [gpus.NumThrottleReasons]float64
+}
+
+// NewThrottleTracker creates a tracker without throttled time.
+func NewThrottleTracker() *ThrottleTracker {
+	return &ThrottleTracker{}
+}
+
+// Update sets throttle states and throttled seconds of the given card from the given table.
+// Reasons reported by throttle status accumulate the time elapsed since the previous table
+// while they are active, so throttling shorter than the reading interval may be missed.
+// Reasons reported by residency accumulate the fraction of the elapsed time in which the
+// firmware sampled them, and they are active when any sample was throttled.
+func (t *ThrottleTracker) Update(table *Table, i int, stat *gpus.AMDParams) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var elapsed, samples float64
+
+	timestamp := table.SystemClockCounter
+	if t.started && timestamp.Valid() && timestamp.Value > t.timestamp {
+		elapsed = (timestamp.Value - t.timestamp) / nanosecondsPerSecond
+	}
+
+	accumulation := table.AccumulationCounter
+	if t.started && accumulation.Valid() && accumulation.Value > t.accumulation {
+		samples = accumulation.Value - t.accumulation
+	}
+
+	for reason := range gpus.NumThrottleReasons {
+		switch {
+		case table.Throttled[reason].Valid():
+			t.throttled[reason] = table.Throttled[reason]
+			t.seconds[reason] += table.Throttled[reason].Value * elapsed
+		case table.ThrottleResidency[reason].Valid():
+			previous := t.residency[reason]
+			t.residency[reason] = table.ThrottleResidency[reason]
+
+			if !previous.Valid() || samples == 0 {
+				break
+			}
+
+			throttled := max(table.ThrottleResidency[reason].Value-previous.Value, 0)
+			t.seconds[reason] += min(throttled/samples, 1) * elapsed
+
+			t.throttled[reason] = gpus.NewReading(0)
+			if throttled > 0 {
+				t.throttled[reason] = gpus.NewReading(1)
+			}
+		default:
+			continue
+		}
+
+		stat.GPUThrottled[reason][i] = t.throttled[reason]
+		stat.GPUThrottledSeconds[reason][i] = gpus.NewReading(t.seconds[reason])
+	}
+
+	if timestamp.Valid() {
+		t.started = true
+		t.timestamp = timestamp.Value
+		t.accumulation = accumulation.Value
+	}
+}
@@ -0,0 +1,239 @@
+package gpumetrics
+
+// gpu_metrics array lengths declared by kgd_pp_interface.h.
+const (
+	numHBMInstances int = 4
+	numVCN          int = 4
+	numJPEGEngines  int = 32
+	numXGMILinks    int = 8
+	maxGFXClocks    int = 8
+	maxClocks       int = 4
+	maxXCC          int = 8
+	numXCP          int = 8
+	numAPUCores     int = 8
+	numAPUL3        int = 2
+	numV3Cores      int = 16
+	numV3IPUColumns int = 8
+)
+
+// revision identifies a gpu_metrics table version, format 1 tables are reported by
+// discrete GPUs and formats 2 and 3 by APUs. Layouts are transcribed from kgd_pp_interface.h
+// and have not been verified against tables captured from hardware, a wrong layout is only
+// detected when its size differs from the size declared by the table header.
+type revision struct {
+	format  uint8
+	content uint8
+}
+
+// v1 tables share their first members until content revision 3.
+var (
+	v1Temperatures = u16s(
+		"temperature_edge", "temperature_hotspot", "temperature_mem",
+		"temperature_vrgfx", "temperature_vrsoc", "temperature_vrmem",
+	)
+	v1Activities    = u16s("average_gfx_activity", "average_umc_activity", "average_mm_activity")
+	v1AverageClocks = u16s(
+		"average_gfxclk_frequency", "average_socclk_frequency", "average_uclk_frequency",
+		"average_vclk0_frequency", "average_dclk0_frequency", "average_vclk1_frequency", "average_dclk1_frequency",
+	)
+	v1CurrentClocks = u16s(
+		"current_gfxclk", "current_socclk", "current_uclk",
+		"current_vclk0", "current_dclk0", "current_vclk1", "current_dclk1",
+	)
+	v11Members = fields(
+		v1Temperatures,
+		v1Activities,
+		[]field{u16("average_socket_power"), u64("energy_accumulator"), u64("system_clock_counter")},
+		v1AverageClocks,
+		v1CurrentClocks,
+		[]field{u32("throttle_status"), u16("current_fan_speed")},
+		u16s("pcie_link_width", "pcie_link_speed", "padding"),
+		[]field{
+			u32("gfx_activity_acc"), u32("mem_activity_acc"),
+			array("temperature_hbm", 2, numHBMInstances),
+		},
+	)
+	v12Members = fields(v11Members, []field{u64("firmware_timestamp")})
+	v13Members = fields(
+		v12Members,
+		u16s("voltage_soc", "voltage_gfx", "voltage_mem", "padding1"),
+		[]field{u64("indep_throttle_status")},
+	)
+)
+
+// v1 tables of MI300 series share their link members from content revision 4.
+var (
+	v14Sensors    = u16s("temperature_hotspot", "temperature_mem", "temperature_vrsoc", "curr_socket_power")
+	v14Activities = u16s("average_gfx_activity", "average_umc_activity")
+	v14Links      = fields(
+		[]field{u32("gfxclk_lock_status")},
+		u16s("pcie_link_width", "pcie_link_speed", "xgmi_link_width", "xgmi_link_speed"),
+		[]field{
+			u32("gfx_activity_acc"), u32("mem_activity_acc"),
+			u64("pcie_bandwidth_acc"), u64("pcie_bandwidth_inst"), u64("pcie_l0_to_recov_count_acc"),
+			u64("pcie_replay_count_acc"), u64("pcie_replay_rover_count_acc"),
+		},
+	)
+	v15PCIeNAKs = []field{u32("pcie_nak_sent_count_acc"), u32("pcie_nak_rcvd_count_acc")}
+	v14XGMIData = []field{
+		array("xgmi_read_data_acc", 8, numXGMILinks),
+		array("xgmi_write_data_acc", 8, numXGMILinks),
+	}
+	v14Clocks = []field{
+		u64("firmware_timestamp"),
+		array("current_gfxclk", 2, maxGFXClocks),
+		array("current_socclk", 2, maxClocks),
+		array("current_vclk0", 2, maxClocks),
+		array("current_dclk0", 2, maxClocks),
+		u16("current_uclk"),
+	}
+	v14XGMIClocks = fields(v14XGMIData, v14Clocks)
+	v16Counters   = []field{
+		u64("energy_accumulator"), u64("system_clock_counter"),
+		u32("accumulation_counter"), u32("prochot_residency_acc"), u32("ppt_residency_acc"),
+		u32("socket_thm_residency_acc"), u32("vr_thm_residency_acc"), u32("hbm_thm_residency_acc"),
+	}
+	// xcpMembers are the members of amdgpu_xcp_metrics, the activity of a compute partition.
+	xcpMembers = []field{
+		array("gfx_busy_inst", 4, maxXCC),
+		array("jpeg_busy", 2, numJPEGEngines),
+		array("vcn_busy", 2, numVCN),
+		array("gfx_busy_acc", 8, maxXCC),
+	}
+	xcpLayout = newLayout(xcpMembers...)
+	// xcpLayoutV11 is the layout of amdgpu_xcp_metrics_v1_1, used from content revision 7.
+	xcpLayoutV11 = newLayout(fields(xcpMembers, []field{array("gfx_below_host_limit_acc", 8, maxXCC)})...)
+)
+
+// v2 tables share their members until content revision 1.
+var (
+	v2Members = fields(
+		[]field{u64("system_clock_counter")},
+		u16s("temperature_gfx", "temperature_soc"),
+		[]field{array("temperature_core", 2, numAPUCores), array("temperature_l3", 2, numAPUL3)},
+		u16s(
+			"average_gfx_activity", "average_mm_activity",
+			"average_socket_power", "average_cpu_power", "average_soc_power", "average_gfx_power",
+		),
+		[]field{array("average_core_power", 2, numAPUCores)},
+		u16s(
+			"average_gfxclk_frequency", "average_socclk_frequency", "average_uclk_frequency",
+			"average_fclk_frequency", "average_vclk_frequency", "average_dclk_frequency",
+			"current_gfxclk", "current_socclk", "current_uclk",
+			"current_fclk", "current_vclk", "current_dclk",
+		),
+		[]field{array("current_coreclk", 2, numAPUCores), array("current_l3clk", 2, numAPUL3)},
+		[]field{u32("throttle_status"), u16("fan_pwm")},
+	)
+	v22Members = fields(v2Members, []field{array("padding", 2, 3), u64("indep_throttle_status")})
+	v23Members = fields(
+		v22Members,
+		u16s("average_temperature_gfx", "average_temperature_soc"),
+		[]field{array("average_temperature_core", 2, numAPUCores), array("average_temperature_l3", 2, numAPUL3)},
+	)
+)
+
+// layouts contains the layouts of known gpu_metrics table versions.
+var layouts = map[revision]*layout{
+	{format: 1, content: 0}: newTableLayout(fields(
+		[]field{u64("system_clock_counter")},
+		v1Temperatures,
+		v1Activities,
+		[]field{u16("average_socket_power"), u32("energy_accumulator")},
+		v1AverageClocks,
+		v1CurrentClocks,
+		[]field{u32("throttle_status"), u16("current_fan_speed"), u8("pcie_link_width"), u8("pcie_link_speed")},
+	)...),
+	{format: 1, content: 1}: newTableLayout(v11Members...),
+	{format: 1, content: 2}: newTableLayout(v12Members...),
+	{format: 1, content: 3}: newTableLayout(v13Members...),
+	{format: 1, content: 4}: newTableLayout(fields(
+		v14Sensors,
+		v14Activities,
+		[]field{array("vcn_activity", 2, numVCN)},
+		[]field{u64("energy_accumulator"), u64("system_clock_counter"), u32("throttle_status")},
+		v14Links,
+		v14XGMIClocks,
+		[]field{u16("padding")},
+	)...),
+	{format: 1, content: 5}: newTableLayout(fields(
+		v14Sensors,
+		v14Activities,
+		[]field{array("vcn_activity", 2, numVCN), array("jpeg_activity", 2, numJPEGEngines)},
+		[]field{u64("energy_accumulator"), u64("system_clock_counter"), u32("throttle_status")},
+		v14Links,
+		v15PCIeNAKs,
+		v14XGMIClocks,
+		[]field{u16("padding")},
+	)...),
+	{format: 1, content: 6}: newTableLayout(fields(
+		v14Sensors,
+		v14Activities,
+		v16Counters,
+		v14Links,
+		v15PCIeNAKs,
+		v14XGMIClocks,
+		[]field{
+			u16("num_partition"),
+			structArray("xcp_stats", xcpLayout, numXCP),
+			u32("pcie_lc_perf_other_end_recovery"),
+		},
+	)...),
+	{format: 1, content: 7}: newTableLayout(fields(
+		v14Sensors,
+		v14Activities,
+		[]field{u64("mem_max_bandwidth")},
+		v16Counters,
+		v14Links,
+		v15PCIeNAKs,
+		v14XGMIData,
+		[]field{array("xgmi_link_status", 2, numXGMILinks), u16("padding")},
+		v14Clocks,
+		[]field{
+			u16("num_partition"),
+			structArray("xcp_stats", xcpLayoutV11, numXCP),
+			u32("pcie_lc_perf_other_end_recovery"),
+		},
+	)...),
+	{format: 2, content: 0}: newTableLayout(fields(v2Members, []field{u16("padding")})...),
+	{format: 2, content: 1}: newTableLayout(fields(v2Members, []field{array("padding", 2, 3)})...),
+	{format: 2, content: 2}: newTableLayout(v22Members...),
+	{format: 2, content: 3}: newTableLayout(v23Members...),
+	{format: 2, content: 4}: newTableLayout(fields(
+		v23Members,
+		u16s(
+			"average_cpu_voltage", "average_soc_voltage", "average_gfx_voltage",
+			"average_cpu_current", "average_soc_current", "average_gfx_current",
+		),
+	)...),
+	{format: 3, content: 0}: newTableLayout(fields(
+		u16s("temperature_gfx", "temperature_soc"),
+		[]field{array("temperature_core", 2, numV3Cores), u16("temperature_skin")},
+		u16s("average_gfx_activity", "average_vcn_activity"),
+		[]field{
+			array("average_ipu_activity", 2, numV3IPUColumns),
+			array("average_core_c0_activity", 2, numV3Cores),
+		},
+		u16s("average_dram_reads", "average_dram_writes", "average_ipu_reads", "average_ipu_writes"),
+		[]field{
+			u64("system_clock_counter"),
+			u32("average_socket_power"), u16("average_ipu_power"), u32("average_apu_power"),
+			u32("average_gfx_power"), u32("average_dgpu_power"), u32("average_all_core_power"),
+			array("average_core_power", 2, numV3Cores),
+		},
+		u16s(
+			"average_sys_power", "stapm_power_limit", "current_stapm_power_limit",
+			"average_gfxclk_frequency", "average_socclk_frequency", "average_vpeclk_frequency",
+			"average_ipuclk_frequency", "average_fclk_frequency", "average_vclk_frequency",
+			"average_uclk_frequency", "average_mpipu_frequency",
+		),
+		[]field{array("current_coreclk", 2, numV3Cores)},
+		u16s("current_core_maxfreq", "current_gfx_maxfreq"),
+		[]field{
+			u32("throttle_residency_prochot"), u32("throttle_residency_spl"),
+			u32("throttle_residency_fppt"), u32("throttle_residency_sppt"),
+			u32("throttle_residency_thm_core"), u32("throttle_residency_thm_gfx"),
+			u32("throttle_residency_thm_soc"), u32("time_filter_alphavalue"),
+		},
+	)...),
+}
@@ -0,0 +1,38 @@
+package hsmp
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// HSMP_IOCTL_CMD is _IOWR(0xF8, 0, struct hsmp_message).
+const (
+	iocReadWrite  uintptr = 3
+	hsmpIoctlBase uintptr = 0xf8
+	hsmpIoctlCmd  uintptr = iocReadWrite<<30 | unsafe.Sizeof(Message{})<<16 | hsmpIoctlBase<<8
+)
+
+// device sends messages through the HSMP character device.
+type device struct {
+	path string
+}
+
+// Send sends the given message through the ioctl of the HSMP device, which waits for the
+// response of the SMU. The device is opened read only since only get messages are sent.
+func (d *device) Send(msg *Message) error {
+	file, err := os.Open(d.path)
+	if err != nil {
+		return fmt.Errorf("unable to open hsmp device: %w", err)
+	}
+
+	defer file.Close()
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, file.Fd(), hsmpIoctlCmd, uintptr(unsafe.Pointer(msg)))
+	if errno != 0 {
+		return fmt.Errorf("unable to send hsmp message %#x: %w", msg.ID, errno)
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+// Package hsmp reads AMD EPYC socket telemetry by sending Host System Management Port messages
+// to the SMU of each socket through the HSMP driver, these are the messages E-SMI sends for the
+// readings the go_amd_smi binding does not expose, e.g. DDR bandwidth or DIMM temperatures.
+package hsmp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// DevicePathDefault is the character device created by the HSMP driver.
+const DevicePathDefault string = "/dev/hsmp"
+
+// HSMP message identifiers and maximum number of arguments.
+const (
+	msgGetFCLKMCLK      uint32 = 0x0f
+	msgGetC0Percent     uint32 = 0x11
+	msgGetDDRBandwidth  uint32 = 0x14
+	msgGetDIMMPower     uint32 = 0x17
+	msgGetDIMMThermal   uint32 = 0x18
+	msgGetSocketFreqLim uint32 = 0x19
+	msgGetRailsSVI      uint32 = 0x1b
+	maxArgs             int    = 8
+)
+
+// units of HSMP responses.
+const (
+	megahertzToHertz float64 = 1e6
+	gigabytesToBytes float64 = 1e9
+	// dimmTemperatureScale converts quarters of degree celsius to millidegrees celsius.
+	dimmTemperatureScale float64 = 250
+)
+
+// Message is an HSMP message, its layout matches struct hsmp_message of the HSMP driver.
+// Args contains the arguments of the message and then its response.
+type Message struct {
+	ID           uint32
+	NumArgs      uint16
+	ResponseSize uint16
+	Args         [maxArgs]uint32
+	Socket       uint16
+}
+
+// Transport sends HSMP messages to the SMU of a socket.
+type Transport interface {
+	// Send sends the given message and sets its response.
+	Send(msg *Message) error
+}
+
+// Setup contains parameters required to create a reader.
+type Setup struct {
+	// DevicePath is the HSMP character device, DevicePathDefault is used when it is empty.
+	DevicePath string
+	// DIMMAddresses are the DIMMs read on every socket, DIMMs are not read when it is empty.
+	DIMMAddresses []uint8
+	// Transport sends messages to the SMU, the HSMP device is used when it is nil.
+	Transport Transport
+}
+
+// ParseDIMMAddresses parses the given DIMM addresses, which may be given in hexadecimal, e.g. 0x80.
+func ParseDIMMAddresses(values []string) ([]uint8, error) {
+	result := make([]uint8, 0, len(values))
+
+	for _, value := range values {
+		address, err := strconv.ParseUint(value, 0, 8)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse dimm address %q: %w", value, err)
+		}
+
+		result = append(result, uint8(address))
+	}
+
+	return result, nil
+}
+
+// Reader reads socket telemetry through HSMP messages.
+type Reader struct {
+	transport     Transport
+	dimmAddresses []uint8
+}
+
+// NewReader creates an HSMP reader. Readings are unsupported when there is no HSMP device or
+// when the SMU does not implement a message, e.g. processors older than the fourth generation.
+func NewReader(settings *Setup) *Reader {
+	newReader := Reader{
+		transport:     settings.Transport,
+		dimmAddresses: settings.DIMMAddresses,
+	}
+
+	if newReader.transport == nil {
+		path := settings.DevicePath
+		if path == "" {
+			path = DevicePathDefault
+		}
+
+		newReader.transport = &device{path: path}
+	}
+
+	return &newReader
+}
+
+// Read sets the DDR bandwidth, clocks, C0 residency, frequency limit, SVI rail power and DIMM
+// readings of the sockets known by the given params.
+func (r *Reader) Read(stat *gpus.AMDParams) {
+	for i := range stat.Sockets {
+		r.readDDRBandwidth(stat, int(i))
+		r.readClocks(stat, int(i))
+		r.readC0Residency(stat, int(i))
+		r.readFrequencyLimit(stat, int(i))
+		r.readSVIPower(stat, int(i))
+		r.readDIMMs(stat, int(i))
+	}
+}
+
+// readDDRBandwidth reads the maximum and utilized DDR bandwidth given in GB/s by bits 31:20 and
+// 19:8 of the response, and the utilization given in percent by bits 7:0.
+func (r *Reader) readDDRBandwidth(stat *gpus.AMDParams, socket int) {
+	response, err := r.send(socket, msgGetDDRBandwidth, 1)
+	if err != nil {
+		failure := errorReading(err)
+		stat.SocketDDRMaxBandwidth[socket] = failure
+		stat.SocketDDRBandwidth[socket] = failure
+		stat.SocketDDRUtilization[socket] = failure
+
+		return
+	}
+
+	stat.SocketDDRMaxBandwidth[socket] = gpus.NewReading(float64(response[0]>>20&0xfff) * gigabytesToBytes)
+	stat.SocketDDRBandwidth[socket] = gpus.NewReading(float64(response[0]>>8&0xfff) * gigabytesToBytes)
+	stat.SocketDDRUtilization[socket] = gpus.NewReading(float64(response[0] & 0xff))
+}
+
+// readClocks reads the data fabric and memory clocks given in MHz.
+func (r *Reader) readClocks(stat *gpus.AMDParams, socket int) {
+	response, err := r.send(socket, msgGetFCLKMCLK, 2)
+	if err != nil {
+		stat.SocketFCLK[socket] = errorReading(err)
+		stat.SocketMCLK[socket] = errorReading(err)
+
+		return
+	}
+
+	stat.SocketFCLK[socket] = gpus.NewReading(float64(response[0]) * megahertzToHertz)
+	stat.SocketMCLK[socket] = gpus.NewReading(float64(response[1]) * megahertzToHertz)
+}
+
+// readC0Residency reads the percent of time the cores of the socket are active.
+func (r *Reader) readC0Residency(stat *gpus.AMDParams, socket int) {
+	response, err := r.send(socket, msgGetC0Percent, 1)
+	if err != nil {
+		stat.SocketC0Residency[socket] = errorReading(err)
+
+		return
+	}
+
+	stat.SocketC0Residency[socket] = gpus.NewReading(float64(response[0]))
+}
+
+// readFrequencyLimit reads the frequency limit given in MHz by bits 31:16 of the response
+// and its sources given as a bit mask indexed by source by bits 15:0.
+func (r *Reader) readFrequencyLimit(stat *gpus.AMDParams, socket int) {
+	response, err := r.send(socket, msgGetSocketFreqLim, 1)
+	if err != nil {
+		stat.SocketFrequencyLimit[socket] = errorReading(err)
+
+		for source := range gpus.NumFrequencyLimitSources {
+			stat.SocketFrequencyLimited[source][socket] = errorReading(err)
+		}
+
+		return
+	}
+
+	stat.SocketFrequencyLimit[socket] = gpus.NewReading(float64(response[0]>>16) * megahertzToHertz)
+
+	for source := range gpus.NumFrequencyLimitSources {
+		stat.SocketFrequencyLimited[source][socket] = gpus.NewReading(float64(response[0] >> uint(source) & 1))
+	}
+}
+
+// readSVIPower reads the power of the SVI rails given in milliwatts.
+func (r *Reader) readSVIPower(stat *gpus.AMDParams, socket int) {
+	response, err := r.send(socket, msgGetRailsSVI, 1)
+	if err != nil {
+		stat.SocketSVIPower[socket] = errorReading(err)
+
+		return
+	}
+
+	stat.SocketSVIPower[socket] = gpus.NewReading(float64(response[0]))
+}
+
+// readDIMMs reads the power given in milliwatts by bits 31:17 of the response and the temperature
+// given in quarters of degree celsius by the signed bits 31:21 of the response of each DIMM.
+func (r *Reader) readDIMMs(stat *gpus.AMDParams, socket int) {
+	dimms := make([]gpus.DIMM, 0, len(r.dimmAddresses))
+
+	for _, address := range r.dimmAddresses {
+		dimm := gpus.NewDIMM(address)
+
+		response, err := r.send(socket, msgGetDIMMPower, 1, uint32(address))
+		if err != nil {
+			dimm.Power = errorReading(err)
+		} else {
+			dimm.Power = gpus.NewReading(float64(response[0] >> 17 & 0x7fff))
+		}
+
+		response, err = r.send(socket, msgGetDIMMThermal, 1, uint32(address))
+		if err != nil {
+			dimm.Temperature = errorReading(err)
+		} else {
+			temperature := int(response[0] >> 21 & 0x7ff)
+			if temperature&0x400 != 0 {
+				temperature -= 0x800
+			}
+
+			dimm.Temperature = gpus.NewReading(float64(temperature) * dimmTemperatureScale)
+		}
+
+		dimms = append(dimms, dimm)
+	}
+
+	stat.SocketDIMMs[socket] = dimms
+}
+
+// send sends the given message to the given socket and returns its response.
+func (r *Reader) send(socket int, id uint32, responseSize uint16, args ...uint32) ([maxArgs]uint32, error) {
+	msg := Message{
+		ID:           id,
+		NumArgs:      uint16(len(args)),
+		ResponseSize: responseSize,
+		Socket:       uint16(socket),
+	}
+
+	copy(msg.Args[:], args)
+
+	err := r.transport.Send(&msg)
+
+	return msg.Args, err
+}
+
+// errorReading returns the reading of a message which could not be sent, messages are unsupported
+// when there is no HSMP device or the SMU does not implement them and other errors are failures.
+func errorReading(err error) gpus.Reading {
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ENOMSG) || errors.Is(err, syscall.EOPNOTSUPP) {
+		return gpus.Reading{}
+	}
+
+	return gpus.FailedReading()
+}
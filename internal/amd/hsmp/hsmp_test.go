@@ -0,0 +1,148 @@
+package hsmp_test
+
+import (
+	"syscall"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/hsmp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	// Given
+	// the second socket does not implement DDR bandwidth and fails to report its C0 residency.
+	transport := fakeTransport{
+		{0, 0x14, 0}:    {response: []uint32{460<<20 | 115<<8 | 25}},
+		{0, 0x0f, 0}:    {response: []uint32{2000, 2400}},
+		{0, 0x11, 0}:    {response: []uint32{87}},
+		{0, 0x19, 0}:    {response: []uint32{3100<<16 | 1<<3 | 1<<6}},
+		{0, 0x1b, 0}:    {response: []uint32{41250}},
+		{0, 0x17, 0x80}: {response: []uint32{4500<<17 | 10<<8 | 0x80}},
+		{0, 0x18, 0x80}: {response: []uint32{170<<21 | 10<<8 | 0x80}},
+		{0, 0x17, 0x81}: {response: []uint32{3000<<17 | 10<<8 | 0x81}},
+		{0, 0x18, 0x81}: {response: []uint32{(0x800-20)<<21 | 10<<8 | 0x81}},
+		{1, 0x14, 0}:    {err: syscall.ENOMSG},
+		{1, 0x0f, 0}:    {response: []uint32{1800, 3200}},
+		{1, 0x11, 0}:    {err: syscall.EIO},
+		{1, 0x19, 0}:    {response: []uint32{3700 << 16}},
+		{1, 0x1b, 0}:    {response: []uint32{39000}},
+	}
+
+	reader := hsmp.NewReader(&hsmp.Setup{
+		DIMMAddresses: []uint8{0x80, 0x81},
+		Transport:     transport,
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeCPUs(2, 4)
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(460e9), {}}, got.SocketDDRMaxBandwidth)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(115e9), {}}, got.SocketDDRBandwidth)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(25), {}}, got.SocketDDRUtilization)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(2e9), gpus.NewReading(1.8e9)}, got.SocketFCLK)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(2.4e9), gpus.NewReading(3.2e9)}, got.SocketMCLK)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(87), gpus.FailedReading()}, got.SocketC0Residency)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(3.1e9), gpus.NewReading(3.7e9)}, got.SocketFrequencyLimit)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(1), gpus.NewReading(0)}, got.SocketFrequencyLimited[gpus.FrequencyLimitPPT])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(1), gpus.NewReading(0)}, got.SocketFrequencyLimited[gpus.FrequencyLimitAPML])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(0), gpus.NewReading(0)}, got.SocketFrequencyLimited[gpus.FrequencyLimitProchot])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(41250), gpus.NewReading(39000)}, got.SocketSVIPower)
+	assert.Equal(t, [][]gpus.DIMM{
+		{
+			{Address: 0x80, Power: gpus.NewReading(4500), Temperature: gpus.NewReading(42500)},
+			{Address: 0x81, Power: gpus.NewReading(3000), Temperature: gpus.NewReading(-5000)},
+		},
+		{
+			{Address: 0x80, Power: gpus.FailedReading(), Temperature: gpus.FailedReading()},
+			{Address: 0x81, Power: gpus.FailedReading(), Temperature: gpus.FailedReading()},
+		},
+	}, got.SocketDIMMs)
+}
+
+func TestReadWithoutDevice(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := hsmp.NewReader(&hsmp.Setup{
+		DevicePath:    t.TempDir() + "/hsmp",
+		DIMMAddresses: []uint8{0x80},
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeCPUs(1, 2)
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Equal(t, []gpus.Reading{{}}, got.SocketDDRBandwidth)
+	assert.Equal(t, []gpus.Reading{{}}, got.SocketFCLK)
+	assert.Equal(t, []gpus.Reading{{}}, got.SocketC0Residency)
+	assert.Equal(t, []gpus.Reading{{}}, got.SocketFrequencyLimited[gpus.FrequencyLimitHSMP])
+	assert.Equal(t, [][]gpus.DIMM{{gpus.NewDIMM(0x80)}}, got.SocketDIMMs)
+}
+
+func TestParseDIMMAddresses(t *testing.T) {
+	t.Parallel()
+	// Given
+	values := []string{"0x80", "129", "0x90"}
+
+	// When
+	got, err := hsmp.ParseDIMMAddresses(values)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, []uint8{0x80, 0x81, 0x90}, got)
+}
+
+func TestParseDIMMAddressesInvalid(t *testing.T) {
+	t.Parallel()
+	// Given
+	values := []string{"0x80", "0x100"}
+
+	// When
+	got, err := hsmp.ParseDIMMAddresses(values)
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+// messageKey identifies a message by socket, message identifier and first argument.
+type messageKey struct {
+	socket uint16
+	id     uint32
+	arg    uint32
+}
+
+// fakeResponse is the response or the error of a message.
+type fakeResponse struct {
+	response []uint32
+	err      error
+}
+
+// fakeTransport answers messages from a table, unknown messages fail.
+type fakeTransport map[messageKey]fakeResponse
+
+func (f fakeTransport) Send(msg *hsmp.Message) error {
+	answer, ok := f[messageKey{socket: msg.Socket, id: msg.ID, arg: msg.Args[0]}]
+	if !ok {
+		return syscall.EIO
+	}
+
+	if answer.err != nil {
+		return answer.err
+	}
+
+	copy(msg.Args[:], answer.response)
+
+	return nil
+}
@@ -0,0 +1,177 @@
+// Package k10temp reads AMD CPU temperatures from the hwmon devices registered by the k10temp
+// driver, which binds to the data fabric PCI function of each socket, e.g. 0000:00:18.3 for the
+// first socket and 0000:00:19.3 for the second one.
+package k10temp
+
+import (
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// hwmon files and values.
+const (
+	hwmonPath     string = "class/hwmon"
+	driverName    string = "k10temp"
+	nameFile      string = "name"
+	deviceLink    string = "device"
+	inputSuffix   string = "_input"
+	labelSuffix   string = "_label"
+	tempInputGlob string = "temp*_input"
+)
+
+// k10temp labels Tccd channels from 1, they are exported with zero based CCD indexes.
+var ccdLabelRegex = regexp.MustCompile(`^Tccd([0-9]+)$`)
+
+// socketLabels contains the channel label of each socket sensor.
+var socketLabels = [gpus.NumSocketTemperatureSensors]string{
+	gpus.SocketTemperatureTctl: "Tctl",
+	gpus.SocketTemperatureTdie: "Tdie",
+}
+
+// Reader reads the temperatures of each socket from k10temp hwmon devices.
+type Reader struct {
+	sockets []socket
+}
+
+// socket contains the input files of the sensors of a socket, sensors not provided
+// by the processor have no file.
+type socket struct {
+	sensors [gpus.NumSocketTemperatureSensors]string
+	ccds    []string
+}
+
+// NewReader creates a reader of the k10temp devices found below the given sysfs root, devices
+// are assigned to sockets in PCI address order. The reader does nothing when there is no device.
+func NewReader(sysfsRoot string) *Reader {
+	if sysfsRoot == "" {
+		sysfsRoot = discovery.RootDefault
+	}
+
+	hwmons, err := filepath.Glob(filepath.Join(sysfsRoot, hwmonPath, "hwmon*"))
+	if err != nil {
+		return &Reader{}
+	}
+
+	devices := make(map[string]string)
+
+	for _, hwmon := range hwmons {
+		name, err := os.ReadFile(filepath.Join(hwmon, nameFile))
+		if err != nil || strings.TrimSpace(string(name)) != driverName {
+			continue
+		}
+
+		// hwmon numbering depends on probe order, so sockets are sorted by PCI address.
+		device, err := filepath.EvalSymlinks(filepath.Join(hwmon, deviceLink))
+		if err != nil {
+			device = hwmon
+		}
+
+		devices[filepath.Base(device)] = hwmon
+	}
+
+	var newReader Reader
+
+	for _, device := range slices.Sorted(maps.Keys(devices)) {
+		newReader.sockets = append(newReader.sockets, discoverChannels(devices[device]))
+	}
+
+	return &newReader
+}
+
+// discoverChannels finds the temperature channels of the given hwmon directory by their label.
+func discoverChannels(hwmon string) socket {
+	var result socket
+
+	inputs, err := filepath.Glob(filepath.Join(hwmon, tempInputGlob))
+	if err != nil {
+		return result
+	}
+
+	for _, input := range inputs {
+		content, err := os.ReadFile(strings.TrimSuffix(input, inputSuffix) + labelSuffix)
+		if err != nil {
+			continue
+		}
+
+		label := strings.TrimSpace(string(content))
+
+		for sensor, sensorLabel := range socketLabels {
+			if label == sensorLabel {
+				result.sensors[sensor] = input
+			}
+		}
+
+		match := ccdLabelRegex.FindStringSubmatch(label)
+		if match == nil {
+			continue
+		}
+
+		ccd, err := strconv.Atoi(match[1])
+		if err != nil || ccd < 1 {
+			continue
+		}
+
+		if ccd > len(result.ccds) {
+			result.ccds = append(result.ccds, make([]string, ccd-len(result.ccds))...)
+		}
+
+		result.ccds[ccd-1] = input
+	}
+
+	return result
+}
+
+// Read sets the temperatures of the sockets known by the given params in millidegrees celsius,
+// CCDs which are not populated are unsupported.
+func (r *Reader) Read(stat *gpus.AMDParams) {
+	for i := range min(len(r.sockets), int(stat.Sockets)) {
+		socket := &r.sockets[i]
+
+		for sensor, input := range socket.sensors {
+			if input == "" {
+				continue
+			}
+
+			stat.SocketTemperatures[sensor][i] = readTemperature(input)
+		}
+
+		stat.CCDTemperatures[i] = make([]gpus.Reading, len(socket.ccds))
+
+		for ccd, input := range socket.ccds {
+			if input == "" {
+				continue
+			}
+
+			stat.CCDTemperatures[i][ccd] = readTemperature(input)
+		}
+	}
+}
+
+// readTemperature reads the given temperature input, files removed since they
+// were discovered are unsupported and other errors are failures.
+func readTemperature(path string) gpus.Reading {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return gpus.Reading{}
+	}
+
+	if err != nil {
+		return gpus.FailedReading()
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return gpus.FailedReading()
+	}
+
+	return gpus.NewReading(value)
+}
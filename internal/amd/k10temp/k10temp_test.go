@@ -0,0 +1,83 @@
+package k10temp_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/k10temp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	// hwmon devices are probed in reverse order, the second socket lacks its second CCD.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/hwmon/hwmon1/name":                            "nvme\n",
+		"class/hwmon/hwmon1/temp1_input":                     "35850\n",
+		"class/hwmon/hwmon1/temp1_label":                     "Composite\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/name":        "k10temp\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp1_input": "52125\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp1_label": "Tctl\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp3_input": "47000\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp3_label": "Tccd1\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp5_input": "invalid\n",
+		"devices/pci0000:00/0000:00:19.3/hwmon2/temp5_label": "Tccd3\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/name":        "k10temp\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp1_input": "71000\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp1_label": "Tctl\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp2_input": "61000\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp2_label": "Tdie\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp3_input": "58250\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp3_label": "Tccd1\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp4_input": "59500\n",
+		"devices/pci0000:00/0000:00:18.3/hwmon5/temp4_label": "Tccd2\n",
+	})
+	sysfsfixtures.Symlink(t, root, "devices/pci0000:00/0000:00:19.3/hwmon2", "class/hwmon/hwmon2")
+	sysfsfixtures.Symlink(t, root, "devices/pci0000:00/0000:00:19.3", "devices/pci0000:00/0000:00:19.3/hwmon2/device")
+	sysfsfixtures.Symlink(t, root, "devices/pci0000:00/0000:00:18.3/hwmon5", "class/hwmon/hwmon5")
+	sysfsfixtures.Symlink(t, root, "devices/pci0000:00/0000:00:18.3", "devices/pci0000:00/0000:00:18.3/hwmon5/device")
+
+	reader := k10temp.NewReader(root)
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeCPUs(2, 128)
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(71000), gpus.NewReading(52125)}, got.SocketTemperatures[gpus.SocketTemperatureTctl])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(61000), {}}, got.SocketTemperatures[gpus.SocketTemperatureTdie])
+	assert.Equal(t, [][]gpus.Reading{
+		{gpus.NewReading(58250), gpus.NewReading(59500)},
+		{gpus.NewReading(47000), {}, gpus.FailedReading()},
+	}, got.CCDTemperatures)
+}
+
+func TestReadWithoutSockets(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/hwmon/hwmon0/name":        "k10temp\n",
+		"class/hwmon/hwmon0/temp1_input": "52125\n",
+		"class/hwmon/hwmon0/temp1_label": "Tctl\n",
+	})
+
+	reader := k10temp.NewReader(root)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Empty(t, got.SocketTemperatures[gpus.SocketTemperatureTctl])
+	assert.Empty(t, got.CCDTemperatures)
+}
@@ -0,0 +1,342 @@
+// Package powercap implements an AMD CPU telemetry backend reading RAPL energy counters
+// from the powercap sysfs class and the amd_energy hwmon driver, it is used where E-SMI
+// can not be initialized because the HSMP driver is not available, e.g. within VMs.
+package powercap
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/k10temp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/topology"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "powercap"
+
+// sysfs paths and files.
+const (
+	RootDefault     string  = discovery.RootDefault
+	powercapPath    string  = "class/powercap"
+	hwmonPath       string  = "class/hwmon"
+	zoneGlob        string  = "intel-rapl:*"
+	nameFile        string  = "name"
+	energyFile      string  = "energy_uj"
+	maxEnergyFile   string  = "max_energy_range_uj"
+	powerLimitFile  string  = "constraint_0_power_limit_uw"
+	amdEnergyName   string  = "amd_energy"
+	energyInputGlob string  = "energy*_input"
+	inputSuffix     string  = "_input"
+	labelSuffix     string  = "_label"
+	microToMilli    float64 = 1e3
+)
+
+var (
+	// package zones are named after their socket, e.g. package-0, dies of multi die
+	// packages are named package-0-die-1 and are not read.
+	packageZoneRegex = regexp.MustCompile(`^package-([0-9]+)$`)
+	// amd_energy labels its counters after the core or socket they measure, e.g. Ecore005 or Esocket1.
+	coreLabelRegex = regexp.MustCompile(`^Ecore([0-9]+)$`)
+	errNoZones     = errors.New("no rapl package zone found")
+)
+
+// Backend reads AMD CPU energy from RAPL powercap zones.
+type Backend struct {
+	logger *slog.Logger
+	// sockets contains package zones indexed by socket.
+	sockets []*counter
+	// cores contains amd_energy core counters indexed by core, it is empty when the driver is not loaded.
+	cores        []*counter
+	temperatures *k10temp.Reader
+	topology     *topology.Reader
+}
+
+// NewBackend creates a powercap backend discovering RAPL package zones below the configured sysfs root.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	root := settings.SysfsRoot
+	if root == "" {
+		root = RootDefault
+	}
+
+	sockets, err := discoverPackageZones(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover rapl zones: %w", err)
+	}
+
+	cores := discoverCoreCounters(root)
+
+	settings.Logger.Info(
+		"rapl zones found in sysfs", slog.String("root", root), slog.Int("sockets", len(sockets)), slog.Int("cores", len(cores)),
+	)
+
+	newBackend := Backend{
+		logger:       settings.Logger,
+		sockets:      sockets,
+		cores:        cores,
+		temperatures: k10temp.NewReader(root),
+		topology:     topology.NewReader(root),
+	}
+
+	return &newBackend, nil
+}
+
+// discoverPackageZones finds top level powercap zones of each socket sorted by socket index,
+// sockets without zone are left nil.
+func discoverPackageZones(root string) ([]*counter, error) {
+	matches, err := filepath.Glob(filepath.Join(root, powercapPath, zoneGlob))
+	if err != nil {
+		return nil, fmt.Errorf("listing powercap zones: %w", err)
+	}
+
+	var result []*counter
+
+	for _, zonePath := range matches {
+		// subzones such as intel-rapl:0:0 measure a part of their parent package.
+		if strings.Count(filepath.Base(zonePath), ":") != 1 {
+			continue
+		}
+
+		name, err := os.ReadFile(filepath.Join(zonePath, nameFile))
+		if err != nil {
+			continue
+		}
+
+		match := packageZoneRegex.FindStringSubmatch(strings.TrimSpace(string(name)))
+		if match == nil {
+			continue
+		}
+
+		socket, _ := strconv.Atoi(match[1])
+		if socket >= len(result) {
+			result = append(result, make([]*counter, socket+1-len(result))...)
+		}
+
+		result[socket] = newCounter(zonePath)
+	}
+
+	if len(result) == 0 {
+		return nil, errNoZones
+	}
+
+	return result, nil
+}
+
+// discoverCoreCounters finds the core counters of the amd_energy hwmon driver indexed by core.
+func discoverCoreCounters(root string) []*counter {
+	hwmons, err := filepath.Glob(filepath.Join(root, hwmonPath, "hwmon*"))
+	if err != nil {
+		return nil
+	}
+
+	slices.Sort(hwmons)
+
+	for _, hwmon := range hwmons {
+		name, err := os.ReadFile(filepath.Join(hwmon, nameFile))
+		if err != nil || strings.TrimSpace(string(name)) != amdEnergyName {
+			continue
+		}
+
+		inputs, err := filepath.Glob(filepath.Join(hwmon, energyInputGlob))
+		if err != nil {
+			return nil
+		}
+
+		var result []*counter
+
+		for _, input := range inputs {
+			label, err := os.ReadFile(strings.TrimSuffix(input, inputSuffix) + labelSuffix)
+			if err != nil {
+				continue
+			}
+
+			match := coreLabelRegex.FindStringSubmatch(strings.TrimSpace(string(label)))
+			if match == nil {
+				continue
+			}
+
+			core, _ := strconv.Atoi(match[1])
+			if core >= len(result) {
+				result = append(result, make([]*counter, core+1-len(result))...)
+			}
+
+			result[core] = newHwmonCounter(input)
+		}
+
+		return result
+	}
+
+	return nil
+}
+
+// Devices is not supported by this backend.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	return nil, fmt.Errorf("powercap backend reading gpu devices: %w", amd.ErrNotSupported)
+}
+
+// ReadGPUs is not supported by this backend.
+func (b *Backend) ReadGPUs(_ *gpus.AMDParams) error {
+	return fmt.Errorf("powercap backend reading gpu metrics: %w", amd.ErrNotSupported)
+}
+
+// ReadCPUs reads socket energy, power and power limit from RAPL package zones, core energy
+// from amd_energy counters and temperatures from k10temp when available. Energy is given in
+// microjoules and power in milliwatts as E-SMI does, socket power is averaged since the previous reading.
+// Core counters are read as threads since the driver does not report core siblings, the driver
+// reads the counter of each core on the logical CPU with the same index.
+func (b *Backend) ReadCPUs(stat *gpus.AMDParams) error {
+	now := time.Now()
+
+	stat.ResizeCPUs(uint(len(b.sockets)), uint(len(b.cores)))
+	stat.ThreadsPerCore = 1
+
+	for i, zone := range b.sockets {
+		if zone == nil {
+			continue
+		}
+
+		energy, power := zone.read(now)
+		stat.SocketEnergy[i] = energy
+		stat.SocketPower[i] = power
+		stat.PowerLimit[i] = zone.readPowerLimit()
+	}
+
+	for i, core := range b.cores {
+		if core == nil {
+			continue
+		}
+
+		stat.CoreEnergy[i], _ = core.read(now)
+	}
+
+	b.temperatures.Read(stat)
+	b.topology.Read(stat)
+
+	return nil
+}
+
+// Close does nothing, files are opened on every reading.
+func (b *Backend) Close() error {
+	return nil
+}
+
+// counter accumulates an energy file in microjoules across readings, so it keeps
+// increasing when the file wraps around its maximum range.
+type counter struct {
+	mutex sync.Mutex
+	// energyPath is the file containing the energy in microjoules.
+	energyPath string
+	// zonePath is the powercap zone of the counter, empty for hwmon counters.
+	zonePath  string
+	maxEnergy float64
+	started   bool
+	raw       float64
+	energy    float64
+	timestamp time.Time
+}
+
+// newCounter creates a counter of the given powercap zone.
+func newCounter(zonePath string) *counter {
+	maxEnergy, err := readFloat(filepath.Join(zonePath, maxEnergyFile))
+	if err != nil {
+		maxEnergy = 0
+	}
+
+	return &counter{
+		energyPath: filepath.Join(zonePath, energyFile),
+		zonePath:   zonePath,
+		maxEnergy:  maxEnergy,
+	}
+}
+
+// newHwmonCounter creates a counter of the given hwmon energy input, amd_energy
+// accumulates hardware counters in 64 bits so they are not expected to wrap around.
+func newHwmonCounter(inputPath string) *counter {
+	return &counter{
+		energyPath: inputPath,
+	}
+}
+
+// read returns the accumulated energy in microjoules and the average power in milliwatts
+// since the previous reading, power is unsupported on the first reading.
+func (c *counter) read(now time.Time) (energy, power gpus.Reading) {
+	value, err := readFloat(c.energyPath)
+	if err != nil {
+		return newReading(err), newReading(err)
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.started {
+		c.started = true
+		c.raw = value
+		c.energy = value
+		c.timestamp = now
+
+		return gpus.NewReading(c.energy), gpus.Reading{}
+	}
+
+	delta := value - c.raw
+	if delta < 0 {
+		delta += c.maxEnergy
+	}
+
+	elapsed := now.Sub(c.timestamp).Seconds()
+
+	c.raw = value
+	c.energy += max(delta, 0)
+	c.timestamp = now
+
+	if elapsed <= 0 {
+		return gpus.NewReading(c.energy), gpus.Reading{}
+	}
+
+	return gpus.NewReading(c.energy), gpus.NewReading(max(delta, 0) / elapsed / microToMilli)
+}
+
+// readPowerLimit reads the long term power limit of the zone in milliwatts.
+func (c *counter) readPowerLimit() gpus.Reading {
+	value, err := readFloat(filepath.Join(c.zonePath, powerLimitFile))
+	if err != nil {
+		return newReading(err)
+	}
+
+	return gpus.NewReading(value / microToMilli)
+}
+
+// readFloat reads given file containing a single decimal number.
+func readFloat(path string) (float64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return value, nil
+}
+
+// newReading returns the reading of a file which could not be read, files
+// not provided by the kernel are unsupported and other errors are failures.
+func newReading(err error) gpus.Reading {
+	if errors.Is(err, os.ErrNotExist) {
+		return gpus.Reading{}
+	}
+
+	return gpus.FailedReading()
+}
@@ -0,0 +1,127 @@
+package powercap_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/powercap"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCPUs(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makePowercapFixture(t)
+	backend := newBackend(t, root)
+
+	var first, second gpus.AMDParams
+	first.Init()
+	second.Init()
+
+	// When
+	firstErr := backend.ReadCPUs(&first)
+
+	// the first package wraps around and the second one fails to be read.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/powercap/intel-rapl:0/energy_uj": "500000000\n",
+		"class/powercap/intel-rapl:1/energy_uj": "invalid\n",
+		"class/hwmon/hwmon3/energy1_input":      "3000000\n",
+	})
+
+	secondErr := backend.ReadCPUs(&second)
+
+	// Then
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+
+	assert.Equal(t, uint(2), first.Sockets)
+	assert.Equal(t, uint(2), first.Threads)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(65e9), gpus.NewReading(12e9)}, first.SocketEnergy)
+	assert.Equal(t, []gpus.Reading{{}, {}}, first.SocketPower)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(280e3), {}}, first.PowerLimit)
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(1e6), gpus.NewReading(2e6)}, first.CoreEnergy)
+
+	assert.Equal(t, gpus.NewReading(65e9+1e9), second.SocketEnergy[0])
+	assert.True(t, second.SocketPower[0].Valid())
+	assert.Positive(t, second.SocketPower[0].Value)
+	assert.Equal(t, gpus.FailedReading(), second.SocketEnergy[1])
+	assert.Equal(t, gpus.FailedReading(), second.SocketPower[1])
+	assert.Equal(t, []gpus.Reading{gpus.NewReading(3e6), gpus.NewReading(2e6)}, second.CoreEnergy)
+}
+
+func TestNewBackendWithoutZones(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/powercap/intel-rapl:0:0/name": "core\n",
+		"class/powercap/intel-rapl:1/name":   "psys\n",
+	})
+
+	// When
+	got, err := powercap.NewBackend(&amd.BackendSetup{Logger: testlogs.NewLogger(), SysfsRoot: root})
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestReadGPUsNotSupported(t *testing.T) {
+	t.Parallel()
+	// Given
+	backend := newBackend(t, makePowercapFixture(t))
+
+	var stat gpus.AMDParams
+	stat.Init()
+
+	// When
+	_, devicesErr := backend.Devices()
+	gpusErr := backend.ReadGPUs(&stat)
+
+	// Then
+	assert.True(t, errors.Is(devicesErr, amd.ErrNotSupported))
+	assert.True(t, errors.Is(gpusErr, amd.ErrNotSupported))
+}
+
+func newBackend(t *testing.T, root string) amd.Backend {
+	t.Helper()
+
+	backend, err := powercap.NewBackend(&amd.BackendSetup{Logger: testlogs.NewLogger(), SysfsRoot: root})
+	require.NoError(t, err)
+
+	return backend
+}
+
+// makePowercapFixture creates two RAPL packages, the second one without power limit,
+// a core subzone and an amd_energy hwmon with two cores.
+func makePowercapFixture(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/powercap/intel-rapl:0/name":                        "package-0\n",
+		"class/powercap/intel-rapl:0/energy_uj":                   "65000000000\n",
+		"class/powercap/intel-rapl:0/max_energy_range_uj":         "65500000000\n",
+		"class/powercap/intel-rapl:0/constraint_0_power_limit_uw": "280000000\n",
+		"class/powercap/intel-rapl:0:0/name":                      "core\n",
+		"class/powercap/intel-rapl:0:0/energy_uj":                 "1000\n",
+		"class/powercap/intel-rapl:1/name":                        "package-1\n",
+		"class/powercap/intel-rapl:1/energy_uj":                   "12000000000\n",
+		"class/powercap/intel-rapl:1/max_energy_range_uj":         "65532610987\n",
+		"class/hwmon/hwmon0/name":                                 "k10temp\n",
+		"class/hwmon/hwmon3/name":                                 "amd_energy\n",
+		"class/hwmon/hwmon3/energy1_input":                        "1000000\n",
+		"class/hwmon/hwmon3/energy1_label":                        "Ecore000\n",
+		"class/hwmon/hwmon3/energy2_input":                        "2000000\n",
+		"class/hwmon/hwmon3/energy2_label":                        "Ecore001\n",
+		"class/hwmon/hwmon3/energy3_input":                        "70000000\n",
+		"class/hwmon/hwmon3/energy3_label":                        "Esocket0\n",
+	})
+
+	return root
+}
@@ -0,0 +1,43 @@
+package processes
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const cgroupFile string = "cgroup"
+
+// container runtimes name the cgroup of a container after its id, optionally with a runtime prefix
+// and a systemd scope suffix, e.g. cri-containerd-<id>.scope, crio-<id>.scope or <id>.
+var containerCgroupRegex = regexp.MustCompile(`(?:^|[/-])([0-9a-f]{64})(?:\.scope)?$`)
+
+// readContainerID returns the id of the container running the given process, it is
+// empty when the process runs outside containers or it could not be read.
+func readContainerID(procfsRoot string, pid int) string {
+	content, err := os.ReadFile(filepath.Join(procfsRoot, strconv.Itoa(pid), cgroupFile))
+	if err != nil {
+		return ""
+	}
+
+	return parseContainerID(string(content))
+}
+
+// parseContainerID parses /proc/<pid>/cgroup content made of "hierarchy:controllers:path" lines,
+// containers are looked up in every hierarchy since cgroup v1 hosts have several of them.
+func parseContainerID(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		if matches := containerCgroupRegex.FindStringSubmatch(fields[2]); matches != nil {
+			return matches[1]
+		}
+	}
+
+	return ""
+}
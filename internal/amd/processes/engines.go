@@ -0,0 +1,105 @@
+package processes
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+const nanosecondsPerSecond float64 = 1e9
+
+// containerKey identifies the usage of a GPU by a container.
+type containerKey struct {
+	card        int
+	containerID string
+}
+
+// engineTracker accumulates the engine busy time of DRM clients by container, so the busy time
+// of clients closed while their container uses the GPU is kept. Containers without clients are
+// forgotten and start over from zero when they open a client again.
+type engineTracker struct {
+	mutex sync.Mutex
+	// clients contains the engine busy time in nanoseconds of the previous readings of each client.
+	clients map[clientKey]drmClient
+	// containers contains the engine busy time in seconds of each container.
+	containers map[containerKey][gpus.NumEngines]gpus.Reading
+}
+
+func newEngineTracker() *engineTracker {
+	return &engineTracker{
+		clients:    make(map[clientKey]drmClient),
+		containers: make(map[containerKey][gpus.NumEngines]gpus.Reading),
+	}
+}
+
+// update adds the busy time elapsed since the previous readings of the given clients to their containers
+// and returns the usage of each GPU by container, sorted by container ID.
+func (t *engineTracker) update(clients map[clientKey]drmClient, numGPUs uint) [][]gpus.ContainerUsage {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	containers := make(map[containerKey][gpus.NumEngines]gpus.Reading)
+
+	for key, client := range clients {
+		containerKey := containerKey{card: key.card, containerID: client.containerID}
+
+		seconds, exist := containers[containerKey]
+		if !exist {
+			seconds = t.containers[containerKey]
+		}
+
+		previous := t.clients[key]
+
+		for engine := range gpus.NumEngines {
+			current := client.engines[engine]
+			if current.Failed() {
+				// the previous reading is kept to compute the busy time elapsed until the next reading.
+				client.engines[engine] = previous.engines[engine]
+
+				if !seconds[engine].Valid() {
+					seconds[engine] = current
+				}
+
+				continue
+			}
+
+			if !current.Valid() {
+				continue
+			}
+
+			// a busy time lower than the previous one belongs to a new client reusing the id.
+			elapsed := current.Value
+			if previous.engines[engine].Valid() && current.Value >= previous.engines[engine].Value {
+				elapsed -= previous.engines[engine].Value
+			}
+
+			seconds[engine] = gpus.NewReading(seconds[engine].Value + elapsed/nanosecondsPerSecond)
+		}
+
+		clients[key] = client
+		containers[containerKey] = seconds
+	}
+
+	t.clients = clients
+	t.containers = containers
+
+	result := make([][]gpus.ContainerUsage, numGPUs)
+
+	for key, seconds := range containers {
+		if uint(key.card) >= numGPUs {
+			continue
+		}
+
+		result[key.card] = append(result[key.card], gpus.ContainerUsage{ContainerID: key.containerID, EngineSeconds: seconds})
+	}
+
+	for i := range result {
+		slices.SortFunc(result[i], func(x, y gpus.ContainerUsage) int {
+			return cmp.Compare(x.ContainerID, y.ContainerID)
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,192 @@
+package processes
+
+import (
+	"bufio"
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// DRM client files and fdinfo keys, see Documentation/gpu/drm-usage-stats.rst in the Linux kernel sources.
+const (
+	fdPath            string = "fd"
+	fdinfoPath        string = "fdinfo"
+	drmClassPath      string = "class/drm"
+	drmDevicePrefix   string = "/dev/dri/"
+	drmDriverKey      string = "drm-driver"
+	drmPCIDeviceKey   string = "drm-pdev"
+	drmClientIDKey    string = "drm-client-id"
+	drmEngineKeyStart string = "drm-engine-"
+	amdgpuDriver      string = "amdgpu"
+)
+
+// engineNames contains the engines by the name used by amdgpu in fdinfo keys,
+// the second ring of a video engine is accounted to the engine.
+var engineNames = map[string]gpus.Engine{
+	"gfx":     gpus.EngineGFX,
+	"compute": gpus.EngineCompute,
+	"dma":     gpus.EngineDMA,
+	"dec":     gpus.EngineDecode,
+	"enc":     gpus.EngineEncode,
+	"enc_1":   gpus.EngineEncode,
+	"jpeg":    gpus.EngineJPEG,
+}
+
+// clientKey identifies a DRM client, the same client is reported by every file descriptor
+// sharing it, e.g. after a fork.
+type clientKey struct {
+	card int
+	id   string
+}
+
+// drmClient contains the readings of a DRM client.
+type drmClient struct {
+	containerID string
+	// engines contains the busy time of each engine in nanoseconds since the client was opened.
+	engines [gpus.NumEngines]gpus.Reading
+}
+
+// readDRMClients reads amdgpu DRM clients from the fdinfo of the file descriptors of every process,
+// processes that exit while they are read are skipped.
+func (r *Reader) readDRMClients() map[clientKey]drmClient {
+	entries, err := os.ReadDir(r.procfsRoot)
+	if err != nil {
+		r.logger.Debug("unable to list processes", slog.String("error", err.Error()))
+
+		return nil
+	}
+
+	clients := make(map[clientKey]drmClient)
+
+	for _, entry := range entries {
+		pid, err := strconv.Atoi(entry.Name())
+		if err != nil {
+			continue
+		}
+
+		r.readProcessDRMClients(pid, clients)
+	}
+
+	return clients
+}
+
+// readProcessDRMClients adds the amdgpu DRM clients opened by the given process to the given clients.
+func (r *Reader) readProcessDRMClients(pid int, clients map[clientKey]drmClient) {
+	processPath := filepath.Join(r.procfsRoot, strconv.Itoa(pid))
+
+	fds, err := os.ReadDir(filepath.Join(processPath, fdPath))
+	if err != nil {
+		return
+	}
+
+	containerID := ""
+	containerRead := false
+
+	for _, fd := range fds {
+		target, err := os.Readlink(filepath.Join(processPath, fdPath, fd.Name()))
+		if err != nil || !strings.HasPrefix(target, drmDevicePrefix) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(processPath, fdinfoPath, fd.Name()))
+		if err != nil {
+			continue
+		}
+
+		key, client, ok := r.parseFDInfo(content, r.drmNodeCard(target))
+		if !ok {
+			continue
+		}
+
+		if _, exist := clients[key]; exist {
+			continue
+		}
+
+		if !containerRead {
+			containerID, containerRead = readContainerID(r.procfsRoot, pid), true
+		}
+
+		client.containerID = containerID
+		clients[key] = client
+	}
+}
+
+// drmNodeCard returns the index of the card of the given DRM device node, e.g. /dev/dri/renderD128,
+// resolving the device of the node in sysfs, -1 is returned when the node is not found.
+func (r *Reader) drmNodeCard(node string) int {
+	devicePath, err := filepath.EvalSymlinks(filepath.Join(r.sysfsRoot, drmClassPath, filepath.Base(node), "device"))
+	if err != nil {
+		return -1
+	}
+
+	index, exist := r.deviceIndexes[filepath.Base(devicePath)]
+	if !exist {
+		return -1
+	}
+
+	return index
+}
+
+// parseFDInfo parses fdinfo content made of "key:\tvalue" lines, false is returned when it does not
+// belong to an amdgpu client of a known card. The card is the given node card, when it is known, or
+// the card of the drm-pdev key, which is not reported by partitions other than the first one.
+func (r *Reader) parseFDInfo(content []byte, nodeCard int) (clientKey, drmClient, bool) {
+	var (
+		key    clientKey
+		client drmClient
+		driver string
+		pciBus string
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		switch {
+		case name == drmDriverKey:
+			driver = value
+		case name == drmPCIDeviceKey:
+			pciBus = value
+		case name == drmClientIDKey:
+			key.id = value
+		case strings.HasPrefix(name, drmEngineKeyStart):
+			engine, exist := engineNames[strings.TrimPrefix(name, drmEngineKeyStart)]
+			if !exist || client.engines[engine].Failed() {
+				continue
+			}
+
+			// values are given in nanoseconds, e.g. "drm-engine-gfx:	123456 ns".
+			busy, err := strconv.ParseFloat(strings.TrimSuffix(value, " ns"), 64)
+			if err != nil {
+				client.engines[engine] = gpus.FailedReading()
+
+				continue
+			}
+
+			client.engines[engine] = gpus.NewReading(client.engines[engine].Value + busy)
+		}
+	}
+
+	card, exist := r.deviceIndexes[pciBus]
+	if nodeCard >= 0 {
+		card, exist = nodeCard, true
+	}
+
+	if driver != amdgpuDriver || !exist || key.id == "" {
+		return clientKey{}, drmClient{}, false
+	}
+
+	key.card = card
+
+	return key, client, true
+}
@@ -0,0 +1,159 @@
+// Package processes reads the GPU usage of the processes running within the node, usage is
+// read from KFD process entries and DRM client fdinfo, and processes are attributed to containers
+// through their cgroup.
+package processes
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// default mount points of sysfs and procfs.
+const (
+	SysfsRootDefault  string = "/sys"
+	ProcfsRootDefault string = "/proc"
+)
+
+// kfd process files, vram files are named after the kfd gpu id, e.g. vram_53907.
+const (
+	kfdProcPath    string = "class/kfd/kfd/proc"
+	vramFilePrefix string = "vram_"
+)
+
+// Setup contains parameters required to create a reader.
+type Setup struct {
+	Logger     *slog.Logger
+	SysfsRoot  string
+	ProcfsRoot string
+	// Cards are the GPUs discovered by the backend, processes are matched to them by
+	// KFD GPU id and DRM clients by device id, i.e. PCI bus or partition device.
+	Cards []gpus.Card
+}
+
+// Reader reads the GPU usage of the processes running within the node.
+type Reader struct {
+	logger     *slog.Logger
+	sysfsRoot  string
+	procfsRoot string
+	// cardIndexes contains card indexes by kfd gpu id.
+	cardIndexes map[string]int
+	// deviceIndexes contains card indexes by device id.
+	deviceIndexes map[string]int
+	engines       *engineTracker
+}
+
+// NewReader creates a reader of the processes using the given cards.
+func NewReader(settings *Setup) *Reader {
+	newReader := Reader{
+		logger:        settings.Logger,
+		sysfsRoot:     settings.SysfsRoot,
+		procfsRoot:    settings.ProcfsRoot,
+		cardIndexes:   make(map[string]int, len(settings.Cards)),
+		deviceIndexes: make(map[string]int, len(settings.Cards)),
+		engines:       newEngineTracker(),
+	}
+
+	if newReader.sysfsRoot == "" {
+		newReader.sysfsRoot = SysfsRootDefault
+	}
+
+	if newReader.procfsRoot == "" {
+		newReader.procfsRoot = ProcfsRootDefault
+	}
+
+	for i, card := range settings.Cards {
+		if card.CardGUID != "" {
+			newReader.cardIndexes[card.CardGUID] = i
+		}
+
+		if deviceID := card.DeviceID(); deviceID != "" {
+			newReader.deviceIndexes[deviceID] = i
+		}
+	}
+
+	return &newReader
+}
+
+// ReadProcesses fills given params with the VRAM used by each process registered in KFD
+// and the engine busy time of each container, processes that exit while they are read are skipped.
+func (r *Reader) ReadProcesses(stat *gpus.AMDParams) error {
+	stat.GPUContainers = r.engines.update(r.readDRMClients(), stat.NumGPUs)
+
+	return r.readKFDProcesses(stat)
+}
+
+// readKFDProcesses fills given params with the VRAM used by each process registered in KFD.
+func (r *Reader) readKFDProcesses(stat *gpus.AMDParams) error {
+	pidPaths, err := filepath.Glob(filepath.Join(r.sysfsRoot, kfdProcPath, "*"))
+	if err != nil {
+		return fmt.Errorf("unable to list kfd processes: %w", err)
+	}
+
+	processes := make([][]gpus.Process, stat.NumGPUs)
+
+	for _, pidPath := range pidPaths {
+		pid, err := strconv.Atoi(filepath.Base(pidPath))
+		if err != nil {
+			continue
+		}
+
+		vramPaths, err := filepath.Glob(filepath.Join(pidPath, vramFilePrefix+"*"))
+		if err != nil || len(vramPaths) == 0 {
+			continue
+		}
+
+		containerID := readContainerID(r.procfsRoot, pid)
+
+		for _, vramPath := range vramPaths {
+			index, exist := r.cardIndexes[strings.TrimPrefix(filepath.Base(vramPath), vramFilePrefix)]
+			if !exist || uint(index) >= stat.NumGPUs {
+				continue
+			}
+
+			vramUsed, err := readFloat(vramPath)
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			process := gpus.Process{
+				PID:         pid,
+				ContainerID: containerID,
+				VRAMUsed:    gpus.NewReading(vramUsed),
+			}
+
+			if err != nil {
+				r.logger.Debug("unable to read process vram", slog.Int("pid", pid), slog.String("error", err.Error()))
+
+				process.VRAMUsed = gpus.FailedReading()
+			}
+
+			processes[index] = append(processes[index], process)
+		}
+	}
+
+	stat.GPUProcesses = processes
+
+	return nil
+}
+
+// readFloat reads given file containing a single decimal number.
+func readFloat(path string) (float64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(string(content)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return value, nil
+}
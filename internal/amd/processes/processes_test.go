@@ -0,0 +1,219 @@
+package processes_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/processes"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	containerdID string = "0b5e4c71a0d2a7e6c3f1b9d8e4a2c6f0b5e4c71a0d2a7e6c3f1b9d8e4a2c6f0b"
+	crioID       string = "f3c2a1b0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2"
+)
+
+func TestReadProcesses(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"sys/class/kfd/kfd/proc/100/vram_1000": "1073741824\n",
+		"sys/class/kfd/kfd/proc/100/vram_1001": "0\n",
+		"sys/class/kfd/kfd/proc/100/pasid":     "32768\n",
+		"sys/class/kfd/kfd/proc/200/vram_1000": "536870912\n",
+		"sys/class/kfd/kfd/proc/300/vram_1001": "invalid\n",
+		"sys/class/kfd/kfd/proc/400/vram_9999": "4096\n",
+		"proc/100/cgroup":                      "0::/kubepods.slice/kubepods-pod8e2f.slice/cri-containerd-" + containerdID + ".scope\n",
+		"proc/200/cgroup": "12:memory:/kubepods/burstable/pod8e2f/" + crioID + "\n" +
+			"1:name=systemd:/kubepods/burstable/pod8e2f/" + crioID + "\n",
+		"proc/300/cgroup": "0::/user.slice/user-1000.slice/session-1.scope\n",
+	})
+
+	reader := processes.NewReader(&processes.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  root + "/sys",
+		ProcfsRoot: root + "/proc",
+		Cards:      []gpus.Card{{CardGUID: "1000"}, {CardGUID: "1001"}},
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(2)
+
+	want := [][]gpus.Process{
+		{
+			{PID: 100, ContainerID: containerdID, VRAMUsed: gpus.NewReading(1073741824)},
+			{PID: 200, ContainerID: crioID, VRAMUsed: gpus.NewReading(536870912)},
+		},
+		{
+			{PID: 100, ContainerID: containerdID, VRAMUsed: gpus.NewReading(0)},
+			{PID: 300, VRAMUsed: gpus.FailedReading()},
+		},
+	}
+
+	// When
+	err := reader.ReadProcesses(&got)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, want, got.GPUProcesses)
+}
+
+func TestReadProcessesWithoutKFD(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := processes.NewReader(&processes.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  t.TempDir(),
+		ProcfsRoot: t.TempDir(),
+		Cards:      []gpus.Card{{CardGUID: "1000"}},
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(1)
+
+	// When
+	err := reader.ReadProcesses(&got)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, [][]gpus.Process{nil}, got.GPUProcesses)
+}
+
+func TestReadProcessesEngineSeconds(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"100/cgroup":    "0::/kubepods.slice/kubepods-pod8e2f.slice/cri-containerd-" + containerdID + ".scope\n",
+		"100/fdinfo/3":  "pos:\t0\nflags:\t02\n",
+		"100/fdinfo/4":  amdgpuFDInfo("10", "2000000000", "1000000000"),
+		"100/fdinfo/5":  amdgpuFDInfo("10", "2000000000", "1000000000"),
+		"101/cgroup":    "0::/kubepods.slice/kubepods-pod8e2f.slice/cri-containerd-" + containerdID + ".scope\n",
+		"101/fdinfo/7":  amdgpuFDInfo("11", "0", "3000000000"),
+		"200/cgroup":    "0::/user.slice/user-1000.slice/session-1.scope\n",
+		"200/fdinfo/4":  "drm-driver:\ti915\ndrm-pdev:\t0000:03:00.0\ndrm-client-id:\t20\ndrm-engine-render:\t500000000 ns\n",
+		"300/fdinfo/4":  amdgpuFDInfo("30", "7000000000", "0"),
+		"self/fdinfo/4": amdgpuFDInfo("40", "7000000000", "0"),
+	})
+	symlinkFDs(t, root, map[string]string{
+		"100/fd/3": "/dev/null",
+		"100/fd/4": "/dev/dri/renderD128",
+		"100/fd/5": "/dev/dri/renderD128",
+		"101/fd/7": "/dev/dri/renderD128",
+		"200/fd/4": "/dev/dri/renderD129",
+	})
+
+	reader := processes.NewReader(&processes.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  t.TempDir(),
+		ProcfsRoot: root,
+		Cards:      []gpus.Card{{PCIBus: "0000:03:00.0"}, {PCIBus: "0000:83:00.0"}},
+	})
+
+	var first, second gpus.AMDParams
+	first.Init()
+	first.ResizeGPUs(2)
+	second.Init()
+	second.ResizeGPUs(2)
+
+	want := [][]gpus.ContainerUsage{
+		{
+			{
+				ContainerID: containerdID,
+				EngineSeconds: [gpus.NumEngines]gpus.Reading{
+					gpus.EngineGFX:     gpus.NewReading(2.5),
+					gpus.EngineCompute: gpus.NewReading(4.5),
+				},
+			},
+		},
+		nil,
+	}
+
+	// When
+	err := reader.ReadProcesses(&first)
+	require.NoError(t, err)
+
+	require.NoError(t, os.RemoveAll(filepath.Join(root, "101")))
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"100/fdinfo/4": amdgpuFDInfo("10", "2500000000", "1500000000"),
+		"100/fdinfo/5": amdgpuFDInfo("10", "2500000000", "1500000000"),
+	})
+
+	err = reader.ReadProcesses(&second)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, gpus.NewReading(4), first.GPUContainers[0][0].EngineSeconds[gpus.EngineCompute])
+	assert.Equal(t, want, second.GPUContainers)
+}
+
+func TestReadProcessesPartitionEngineSeconds(t *testing.T) {
+	t.Parallel()
+	// Given
+	procfsRoot := t.TempDir()
+	sysfsfixtures.WriteFiles(t, procfsRoot, map[string]string{
+		"100/cgroup":   "0::/kubepods.slice/kubepods-pod8e2f.slice/cri-containerd-" + containerdID + ".scope\n",
+		"100/fdinfo/4": "drm-driver:\tamdgpu\ndrm-client-id:\t10\ndrm-engine-compute:\t3000000000 ns\n",
+	})
+	symlinkFDs(t, procfsRoot, map[string]string{
+		"100/fd/4": "/dev/dri/renderD129",
+	})
+
+	// the render node of the second partition belongs to its platform device.
+	sysfsRoot := t.TempDir()
+	sysfsfixtures.WriteFiles(t, sysfsRoot, map[string]string{
+		"devices/platform/amdgpu_xcp_0/drm/renderD129/dev": "226:129\n",
+	})
+	sysfsfixtures.Symlink(t, sysfsRoot, "devices/platform/amdgpu_xcp_0", "class/drm/renderD129/device")
+
+	reader := processes.NewReader(&processes.Setup{
+		Logger:     testlogs.NewLogger(),
+		SysfsRoot:  sysfsRoot,
+		ProcfsRoot: procfsRoot,
+		Cards: []gpus.Card{
+			{PCIBus: "0000:03:00.0"},
+			{PCIBus: "0000:03:00.0", PartitionID: 1, PartitionDevice: "amdgpu_xcp_0"},
+		},
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(2)
+
+	// When
+	err := reader.ReadProcesses(&got)
+
+	// Then
+	require.NoError(t, err)
+	assert.Empty(t, got.GPUContainers[0])
+	require.Len(t, got.GPUContainers[1], 1)
+	assert.Equal(t, gpus.NewReading(3), got.GPUContainers[1][0].EngineSeconds[gpus.EngineCompute])
+}
+
+// amdgpuFDInfo returns the fdinfo of an amdgpu client of the card at 0000:03:00.0 with the given
+// gfx and compute busy time in nanoseconds.
+func amdgpuFDInfo(clientID, gfx, compute string) string {
+	return "pos:\t0\nflags:\t02100002\nmnt_id:\t24\n" +
+		"drm-driver:\tamdgpu\ndrm-client-id:\t" + clientID + "\ndrm-pdev:\t0000:03:00.0\npasid:\t32769\n" +
+		"drm-memory-vram:\t1048576 KiB\ndrm-memory-gtt:\t2048 KiB\n" +
+		"drm-engine-gfx:\t" + gfx + " ns\ndrm-engine-compute:\t" + compute + " ns\n"
+}
+
+// symlinkFDs creates file descriptor links below root directory pointing to the given device paths.
+func symlinkFDs(t *testing.T, root string, fds map[string]string) {
+	t.Helper()
+
+	for fd, target := range fds {
+		path := filepath.Join(root, fd)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.Symlink(target, path))
+	}
+}
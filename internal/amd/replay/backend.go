@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "replay"
+
+// SpeedDefault plays recordings at their original pace.
+const SpeedDefault float64 = 1
+
+var errReplayFileRequired = errors.New("replay file is required")
+
+// Backend plays a recording returning its snapshots at the original or an accelerated pace.
+type Backend struct {
+	logger    *slog.Logger
+	recording *Recording
+	speed     float64
+	start     time.Time
+	// scanned and played are the params of the last scan and the snapshot played for it, so GPU
+	// and CPU readings of a scan come from the same snapshot.
+	scanned *gpus.AMDParams
+	played  *gpus.AMDParams
+	mutex   sync.Mutex
+}
+
+// NewBackend creates a replay backend loading the configured recording file.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	if settings.ReplayFile == "" {
+		return nil, errReplayFileRequired
+	}
+
+	recording, err := Load(settings.ReplayFile)
+	if err != nil {
+		return nil, err
+	}
+
+	speed := settings.ReplaySpeed
+	if speed <= 0 {
+		speed = SpeedDefault
+	}
+
+	settings.Logger.Info(
+		"replaying amd metrics",
+		slog.String("file", settings.ReplayFile),
+		slog.Int("snapshots", len(recording.Snapshots)),
+		slog.Duration("period", recording.Period()),
+		slog.Float64("speed", speed),
+	)
+
+	newBackend := Backend{
+		logger:    settings.Logger,
+		recording: recording,
+		speed:     speed,
+		start:     time.Now(),
+	}
+
+	return &newBackend, nil
+}
+
+// Devices returns the recorded GPU card inventory.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	return b.recording.Cards, nil
+}
+
+// ReadGPUs fills given params with GPU readings of the snapshot being played, readings
+// omitted by the recording are unsupported.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	stat.CopyGPUs(b.snapshot(stat))
+	stat.ResizeGPUs(stat.NumGPUs)
+
+	return nil
+}
+
+// ReadCPUs fills given params with CPU readings of the snapshot being played.
+func (b *Backend) ReadCPUs(stat *gpus.AMDParams) error {
+	stat.CopyCPUs(b.snapshot(stat))
+
+	return nil
+}
+
+// snapshot returns the snapshot being played based on the time elapsed since the backend was created,
+// the snapshot is picked once for the given params which are filled by a single scan.
+func (b *Backend) snapshot(stat *gpus.AMDParams) *gpus.AMDParams {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if stat != b.scanned {
+		offset := time.Duration(float64(time.Since(b.start)) * b.speed)
+		b.scanned = stat
+		b.played = b.recording.At(offset)
+	}
+
+	return b.played
+}
+
+// Close does nothing, the recording is loaded in memory.
+func (b *Backend) Close() error {
+	return nil
+}
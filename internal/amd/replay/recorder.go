@@ -0,0 +1,102 @@
+package replay
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// RecorderSetup contains parameters required to create a recorder.
+type RecorderSetup struct {
+	Logger *slog.Logger
+	// Path is the file where snapshots are recorded, it is truncated if it exists.
+	Path  string
+	Cards []gpus.Card
+}
+
+// Recorder writes metric snapshots into a json lines file.
+type Recorder struct {
+	logger  *slog.Logger
+	file    *os.File
+	encoder *json.Encoder
+	mutex   sync.Mutex
+}
+
+// NewRecorder creates the recording file writing the given GPU card inventory.
+func NewRecorder(settings *RecorderSetup) (*Recorder, error) {
+	file, err := os.Create(settings.Path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create recording file: %w", err)
+	}
+
+	newRecorder := Recorder{
+		logger:  settings.Logger,
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}
+
+	err = newRecorder.write(&record{
+		Time:  time.Now(),
+		Cards: settings.Cards,
+	})
+	if err != nil {
+		file.Close()
+
+		return nil, err
+	}
+
+	return &newRecorder, nil
+}
+
+// Record writes given snapshot.
+func (r *Recorder) Record(params *gpus.AMDParams) error {
+	return r.write(&record{
+		Time:   time.Now(),
+		Params: params,
+	})
+}
+
+// Wrap returns a handler recording every snapshot returned by the given handler.
+func (r *Recorder) Wrap(handler gpus.AMDParamsHandler) gpus.AMDParamsHandler {
+	return func() *gpus.AMDParams {
+		params := handler()
+
+		err := r.Record(params)
+		if err != nil {
+			r.logger.Error("recording amd metrics snapshot", slog.String("error", err.Error()))
+		}
+
+		return params
+	}
+}
+
+// write encodes given record as a new line.
+func (r *Recorder) write(line *record) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err := r.encoder.Encode(line)
+	if err != nil {
+		return fmt.Errorf("unable to write recording: %w", err)
+	}
+
+	return nil
+}
+
+// Close closes the recording file.
+func (r *Recorder) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err := r.file.Close()
+	if err != nil {
+		return fmt.Errorf("unable to close recording file: %w", err)
+	}
+
+	return nil
+}
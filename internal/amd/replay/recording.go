@@ -0,0 +1,147 @@
+// Package replay records AMD metric snapshots into a json lines file and replays
+// them as a telemetry backend, it allows reproducing production incidents, building
+// dashboards and writing regression tests in environments without AMD hardware.
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+/* recording file sample, the first line contains the GPU card inventory and
+the following ones contain a metric snapshot each.
+{"time":"2024-06-01T10:00:00Z","cards":[{"cardseries":"AMD Instinct MI210","pcibus":"0000:03:00.0"}]}
+{"time":"2024-06-01T10:00:00Z","params":{"NumGPUs":1,"GPUPower":[98000000],"GPUUsage":[37]}}
+{"time":"2024-06-01T10:00:15Z","params":{"NumGPUs":1,"GPUPower":[301000000],"GPUUsage":[99]}}
+*/
+
+var (
+	errNoSnapshots   = errors.New("recording does not contain snapshots")
+	errNoCardsRecord = errors.New("recording does not start with a cards record")
+)
+
+// record is a line of the recording file.
+type record struct {
+	Time   time.Time       `json:"time"`
+	Cards  []gpus.Card     `json:"cards,omitempty"`
+	Params *gpus.AMDParams `json:"params,omitempty"`
+}
+
+// Snapshot contains readings taken at a given time.
+type Snapshot struct {
+	// Offset is the time elapsed since the first snapshot was recorded.
+	Offset time.Duration
+	Params gpus.AMDParams
+}
+
+// Recording contains GPU card inventory and snapshots recorded on a node.
+type Recording struct {
+	Cards     []gpus.Card
+	Snapshots []Snapshot
+}
+
+// Load reads the recording stored in the given file.
+func Load(path string) (*Recording, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open recording: %w", err)
+	}
+	defer file.Close()
+
+	recording, err := Read(file)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read recording %s: %w", path, err)
+	}
+
+	return recording, nil
+}
+
+// Read decodes a recording from given json lines stream.
+func Read(reader io.Reader) (*Recording, error) {
+	decoder := json.NewDecoder(reader)
+
+	var inventory record
+
+	err := decoder.Decode(&inventory)
+	if err != nil {
+		return nil, fmt.Errorf("decoding cards record: %w", err)
+	}
+
+	if inventory.Params != nil {
+		return nil, errNoCardsRecord
+	}
+
+	result := Recording{
+		Cards: inventory.Cards,
+	}
+
+	var start time.Time
+
+	for {
+		var snapshot record
+
+		err := decoder.Decode(&snapshot)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("decoding snapshot %d: %w", len(result.Snapshots), err)
+		}
+
+		if snapshot.Params == nil {
+			continue
+		}
+
+		if len(result.Snapshots) == 0 {
+			start = snapshot.Time
+		}
+
+		result.Snapshots = append(result.Snapshots, Snapshot{
+			Offset: snapshot.Time.Sub(start),
+			Params: *snapshot.Params,
+		})
+	}
+
+	if len(result.Snapshots) == 0 {
+		return nil, errNoSnapshots
+	}
+
+	return &result, nil
+}
+
+// Period returns the time taken to play the whole recording, the last snapshot
+// lasts the average interval between snapshots.
+func (r *Recording) Period() time.Duration {
+	if len(r.Snapshots) < 2 {
+		return 0
+	}
+
+	last := r.Snapshots[len(r.Snapshots)-1].Offset
+
+	return last + last/time.Duration(len(r.Snapshots)-1)
+}
+
+// At returns the snapshot being played at the given offset, the recording
+// is played in a loop.
+func (r *Recording) At(offset time.Duration) *gpus.AMDParams {
+	period := r.Period()
+	if period <= 0 {
+		return &r.Snapshots[0].Params
+	}
+
+	offset %= period
+
+	i := sort.Search(len(r.Snapshots), func(i int) bool {
+		return r.Snapshots[i].Offset > offset
+	})
+
+	return &r.Snapshots[max(i-1, 0)].Params
+}
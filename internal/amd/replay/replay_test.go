@@ -0,0 +1,221 @@
+package replay_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/replay"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/metrics"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/metricfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const mi210RecordingPath = "testdata/mi210.jsonl"
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	// When
+	got, err := replay.Load(mi210RecordingPath)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, "0000:03:00.0", got.Cards[0].PCIBus)
+	assert.Equal(t, "0000:83:00.0", got.Cards[1].PCIBus)
+	assert.Len(t, got.Cards, 2)
+	require.Len(t, got.Snapshots, 3)
+	assert.Equal(t, time.Duration(0), got.Snapshots[0].Offset)
+	assert.Equal(t, 15*time.Second, got.Snapshots[1].Offset)
+	assert.Equal(t, 30*time.Second, got.Snapshots[2].Offset)
+	assert.Equal(t, 45*time.Second, got.Period())
+}
+
+func TestRecordingAt(t *testing.T) {
+	t.Parallel()
+	// Given
+	recording, err := replay.Load(mi210RecordingPath)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		offset time.Duration
+		want   float64
+	}{
+		"first snapshot":      {offset: 0, want: 98e6},
+		"between snapshots":   {offset: 20 * time.Second, want: 301e6},
+		"last snapshot":       {offset: 44 * time.Second, want: 299e6},
+		"recording in a loop": {offset: 50 * time.Second, want: 98e6},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			got := recording.At(tt.offset)
+
+			// Then
+			assert.Equal(t, gpus.NewReading(tt.want), got.GPUPower[0])
+		})
+	}
+}
+
+func TestRecordAndLoad(t *testing.T) {
+	t.Parallel()
+	// Given
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	cards := []gpus.Card{
+		{Cardseries: "AMD Instinct MI300X", PCIBus: "0000:0c:00.0"},
+	}
+
+	var params gpus.AMDParams
+	params.Init()
+	params.ResizeGPUs(1)
+	params.GPUPower[0] = gpus.NewReading(612e6)
+	params.GPUTemperature[0] = gpus.FailedReading()
+
+	recorder, err := replay.NewRecorder(&replay.RecorderSetup{
+		Logger: testlogs.NewLogger(),
+		Path:   path,
+		Cards:  cards,
+	})
+	require.NoError(t, err)
+
+	handler := recorder.Wrap(func() *gpus.AMDParams { return &params })
+
+	// When
+	handler()
+	handler()
+	require.NoError(t, recorder.Close())
+
+	got, err := replay.Load(path)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, cards, got.Cards)
+	require.Len(t, got.Snapshots, 2)
+	assert.Equal(t, params, got.Snapshots[0].Params)
+	assert.Equal(t, params, got.Snapshots[1].Params)
+}
+
+func TestLoadWithoutSnapshots(t *testing.T) {
+	t.Parallel()
+	// Given
+	path := filepath.Join(t.TempDir(), "recording.jsonl")
+
+	recorder, err := replay.NewRecorder(&replay.RecorderSetup{
+		Logger: testlogs.NewLogger(),
+		Path:   path,
+	})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Close())
+
+	// When
+	got, err := replay.Load(path)
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestBackend(t *testing.T) {
+	t.Parallel()
+	// Given
+	backend, err := replay.NewBackend(&amd.BackendSetup{
+		Logger:      testlogs.NewLogger(),
+		ReplayFile:  mi210RecordingPath,
+		ReplaySpeed: replay.SpeedDefault,
+	})
+	require.NoError(t, err)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	cards, err := backend.Devices()
+	require.NoError(t, err)
+	require.NoError(t, backend.ReadCPUs(&got))
+	require.NoError(t, backend.ReadGPUs(&got))
+
+	// Then
+	assert.Equal(t, "AMD Instinct MI210", cards[0].Cardseries)
+	assert.Equal(t, uint(1), got.Sockets)
+	assert.Equal(t, uint(2), got.Threads)
+	assert.Equal(t, uint(2), got.NumGPUs)
+	assert.Equal(t, gpus.NewReading(98e6), got.GPUPower[0])
+	assert.Equal(t, gpus.NewReading(180e3), got.SocketPower[0])
+}
+
+func TestBackendReadsSingleSnapshotPerScan(t *testing.T) {
+	t.Parallel()
+	// Given
+	// snapshots are played every few microseconds, so a scan would often cross a snapshot boundary.
+	backend, err := replay.NewBackend(&amd.BackendSetup{
+		Logger:      testlogs.NewLogger(),
+		ReplayFile:  mi210RecordingPath,
+		ReplaySpeed: 1e7,
+	})
+	require.NoError(t, err)
+
+	socketPowers := map[float64]float64{98e6: 180e3, 301e6: 210e3, 299e6: 215e3}
+
+	for range 100 {
+		var got gpus.AMDParams
+		got.Init()
+
+		// When
+		require.NoError(t, backend.ReadCPUs(&got))
+		time.Sleep(time.Microsecond)
+		require.NoError(t, backend.ReadGPUs(&got))
+
+		// Then
+		assert.Equal(t, socketPowers[got.GPUPower[0].Value], got.SocketPower[0].Value)
+	}
+}
+
+func TestNewBackendWithoutReplayFile(t *testing.T) {
+	t.Parallel()
+
+	// When
+	got, err := replay.NewBackend(&amd.BackendSetup{Logger: testlogs.NewLogger()})
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func TestReplayThroughMetrics(t *testing.T) {
+	t.Parallel()
+	// Given
+	recording, err := replay.Load(mi210RecordingPath)
+	require.NoError(t, err)
+
+	next := 0
+	amdMetrics := metrics.NewAMDMetrics(&metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			params := &recording.Snapshots[next].Params
+			next++
+
+			return params
+		},
+		Logger: testlogs.NewLogger(),
+	})
+	amdMetrics.CardsInfo = recording.Cards
+
+	labelValues := []string{"0", "AMD Instinct MI210", "amd0"}
+	want := []float64{41, 78, 95}
+
+	for i := range recording.Snapshots {
+		// When
+		got := amdMetrics.CollectAndBuildMetrics()
+
+		// Then
+		assert.Contains(t, got, metricfixtures.ConstGaugeMetric(
+			"gpu_current_temperature", want[i], metricfixtures.GPULabels("gpu_current_temperature")[:3], labelValues,
+		))
+	}
+}
@@ -0,0 +1,84 @@
+// Package simulator implements an AMD telemetry backend generating realistic,
+// time-varying readings for a simulated fleet of GPUs and CPU sockets, it allows
+// running the whole exporter for development and demos without AMD hardware.
+package simulator
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "simulator"
+
+// Backend returns readings of a simulator advanced by the time elapsed between readings.
+type Backend struct {
+	logger    *slog.Logger
+	simulator *Simulator
+	lastStep  time.Time
+	mutex     sync.Mutex
+}
+
+// NewBackend creates a simulator backend for the configured fleet.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	simulator, err := NewSimulator(&settings.Simulator)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.Logger.Info(
+		"simulating amd fleet",
+		slog.Int("gpus", settings.Simulator.GPUs),
+		slog.String("gpu-model", settings.Simulator.GPUModel),
+		slog.Int("sockets", settings.Simulator.Sockets),
+		slog.Int("threads-per-socket", settings.Simulator.ThreadsPerSocket),
+	)
+
+	newBackend := Backend{
+		logger:    settings.Logger,
+		simulator: simulator,
+		lastStep:  time.Now(),
+	}
+
+	return &newBackend, nil
+}
+
+// Devices returns the simulated GPU card inventory.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	return b.simulator.Cards(), nil
+}
+
+// ReadGPUs fills given params with simulated GPU readings.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	b.step()
+	b.simulator.ReadGPUs(stat)
+
+	return nil
+}
+
+// ReadCPUs fills given params with simulated CPU readings.
+func (b *Backend) ReadCPUs(stat *gpus.AMDParams) error {
+	b.step()
+	b.simulator.ReadCPUs(stat)
+
+	return nil
+}
+
+// step advances the simulation by the time elapsed since the last reading.
+func (b *Backend) step() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.simulator.Step(now.Sub(b.lastStep))
+	b.lastStep = now
+}
+
+// Close does nothing.
+func (b *Backend) Close() error {
+	return nil
+}
@@ -0,0 +1,90 @@
+package simulator
+
+import "strings"
+
+// megahertz to hertz, watts to microwatts and gibibytes to bytes conversion factors.
+const (
+	mhz float64 = 1e6
+	w   float64 = 1e6
+	gib float64 = 1 << 30
+)
+
+// gpuModel contains the characteristics of a simulated GPU.
+type gpuModel struct {
+	name        string
+	deviceID    uint64
+	subsystemID uint64
+	sku         string
+	gfxVersion  string
+	// powerCap and idlePower are given in microwatts.
+	powerCap  float64
+	idlePower float64
+	// vram is given in bytes.
+	vram float64
+	// pcieSpeed is given in GT/s.
+	pcieSpeed float64
+	pcieWidth float64
+	// xgmiSpeed is given in Gb/s per lane, it is zero for GPUs without XGMI links.
+	xgmiSpeed float64
+	xgmiWidth float64
+	// sclkLevels and mclkLevels are the DPM levels in hertz sorted from lowest to highest.
+	sclkLevels []float64
+	mclkLevels []float64
+}
+
+// gpuModels contains the GPUs supported by the simulator indexed by their upper case short name.
+var gpuModels = map[string]gpuModel{
+	"MI300X": {
+		name:        "AMD Instinct MI300X",
+		deviceID:    0x74a1,
+		subsystemID: 0x74a1,
+		sku:         "M3000100",
+		gfxVersion:  "gfx942",
+		powerCap:    750 * w,
+		idlePower:   140 * w,
+		vram:        192 * gib,
+		pcieSpeed:   32,
+		pcieWidth:   16,
+		xgmiSpeed:   32,
+		xgmiWidth:   16,
+		sclkLevels:  []float64{500 * mhz, 800 * mhz, 1200 * mhz, 1700 * mhz, 2100 * mhz},
+		mclkLevels:  []float64{900 * mhz, 1100 * mhz, 1300 * mhz},
+	},
+	"MI250X": {
+		name:        "AMD Instinct MI250X / MI250",
+		deviceID:    0x740c,
+		subsystemID: 0x0b0c,
+		sku:         "D65210",
+		gfxVersion:  "gfx90a",
+		powerCap:    560 * w,
+		idlePower:   90 * w,
+		vram:        64 * gib,
+		pcieSpeed:   16,
+		pcieWidth:   16,
+		xgmiSpeed:   25,
+		xgmiWidth:   16,
+		sclkLevels:  []float64{500 * mhz, 800 * mhz, 1300 * mhz, 1700 * mhz},
+		mclkLevels:  []float64{400 * mhz, 1600 * mhz},
+	},
+	"MI210": {
+		name:        "AMD Instinct MI210",
+		deviceID:    0x740f,
+		subsystemID: 0x0c34,
+		sku:         "D67301",
+		gfxVersion:  "gfx90a",
+		powerCap:    300 * w,
+		idlePower:   40 * w,
+		vram:        64 * gib,
+		pcieSpeed:   16,
+		pcieWidth:   16,
+		sclkLevels:  []float64{500 * mhz, 800 * mhz, 1300 * mhz, 1700 * mhz},
+		mclkLevels:  []float64{400 * mhz, 1600 * mhz},
+	},
+}
+
+// findGPUModel returns the model with the given name, e.g. mi300x or MI300X.
+func findGPUModel(name string) (gpuModel, bool) {
+	model, exist := gpuModels[strings.ToUpper(name)]
+
+	return model, exist
+}
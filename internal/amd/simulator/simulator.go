@@ -0,0 +1,509 @@
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// simulated card inventory values.
+const (
+	cardVendor     string = "Advanced Micro Devices, Inc. [AMD/ATI]"
+	cardsPerDomain int    = 8
+	firstBus       int    = 0x0c
+	busStride      int    = 0x1d
+	firstGUID      int    = 2000
+	uniqueIDBase   uint64 = 0x5b2a6c0172bd8d00
+)
+
+var (
+	errUnknownGPUModel = errors.New("unknown simulated gpu model")
+	errInvalidFleet    = errors.New("invalid simulated fleet")
+)
+
+// simulation constants, temperatures are given in degrees celsius and times in seconds.
+const (
+	ambientTemperature     float64 = 30
+	temperatureRise        float64 = 55
+	throttleTemperature    float64 = 90
+	junctionTempRise       float64 = 12
+	memoryTempRise         float64 = 10
+	memoryTempDrop         float64 = 5
+	junctionCriticalTemp   float64 = 100
+	junctionEmergencyTemp  float64 = 110
+	memoryCriticalTemp     float64 = 105
+	memoryEmergencyTemp    float64 = 115
+	gfxMinVoltage          float64 = 650  // millivolts
+	gfxMaxVoltage          float64 = 1100 // millivolts
+	socVoltage             float64 = 900  // millivolts
+	temperatureTimeConst   float64 = 45
+	utilizationTimeConst   float64 = 2
+	utilizationNoise       float64 = 3
+	powerNoise             float64 = 0.02
+	busyPhaseMinDuration   float64 = 30
+	busyPhaseMaxDuration   float64 = 180
+	idlePhaseMinDuration   float64 = 10
+	idlePhaseMaxDuration   float64 = 90
+	busyMinUtilization     float64 = 70
+	idleMaxUtilization     float64 = 5
+	memoryUtilizationRatio float64 = 0.6
+	memoryBusyUtilization  float64 = 5
+	vramTimeConst          float64 = 5
+	busyMinVRAMRatio       float64 = 0.3
+	busyMaxVRAMRatio       float64 = 0.95
+	idleVRAMUsed           float64 = 283 << 20 // bytes
+	gttBaseUsed            float64 = 21 << 20  // bytes
+	gttVRAMRatio           float64 = 0.001
+	correctableErrorPeriod float64 = 3600
+	pcieBusyLinkRatio      float64 = 0.4
+	xgmiBusyLinkRatio      float64 = 0.6
+	gigatransfersToBytes   float64 = 1e9 / 8
+	gigabitsToBytes        float64 = 1e9 / 8
+	cpuIdlePower           float64 = 110e3 // milliwatts
+	cpuPowerLimit          float64 = 400e3 // milliwatts
+	cpuBoostLimit          float64 = 3700  // megahertz
+	cpuUtilizationStep     float64 = 0.05
+	prochotPowerRatio      float64 = 0.98
+	powerThrottleRatio     float64 = 0.98
+	threadsPerCore         int     = 2
+	coresPerCCX            int     = 8
+	millidegrees           float64 = 1e3
+	milliwattsToMicrojoule float64 = 1e3
+)
+
+// gpuState contains the simulated readings of a GPU.
+type gpuState struct {
+	busy              bool
+	phaseRemaining    float64
+	targetUtilization float64
+	utilization       float64
+	memoryUtilization float64
+	power             float64
+	temperature       float64
+	sclk              float64
+	mclk              float64
+	// vramFootprint is the memory held by the workload of the current phase in bytes.
+	vramFootprint float64
+	vramUsed      float64
+	gttUsed       float64
+	// umcCorrectableErrors are memory errors corrected by ECC, other blocks do not report errors.
+	umcCorrectableErrors float64
+	// xgmiLinkBytes is the data read and written through each XGMI link in bytes.
+	xgmiLinkBytes float64
+	// throttled and throttledSeconds are indexed by throttle reason, only thermal
+	// and power throttling are simulated.
+	throttled        [gpus.NumThrottleReasons]bool
+	throttledSeconds [gpus.NumThrottleReasons]float64
+}
+
+// socketState contains the simulated readings of a CPU socket.
+type socketState struct {
+	utilization float64
+	power       float64
+	energy      float64
+	coreEnergy  []float64
+}
+
+// Simulator generates time-varying readings for a fleet of GPUs and CPU sockets,
+// utilization alternates between busy and idle phases, power follows utilization,
+// temperature follows power with a lag and clocks step through DPM levels.
+type Simulator struct {
+	model   gpuModel
+	random  *rand.Rand
+	gpus    []gpuState
+	sockets []socketState
+	mutex   sync.Mutex
+}
+
+// NewSimulator creates a simulator for the given fleet, readings are reproducible for a given seed.
+func NewSimulator(settings *amd.SimulatorSetup) (*Simulator, error) {
+	model, exist := findGPUModel(settings.GPUModel)
+	if !exist {
+		return nil, fmt.Errorf("%w: %q", errUnknownGPUModel, settings.GPUModel)
+	}
+
+	err := validateFleet(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := settings.Seed
+	if seed == 0 {
+		seed = rand.Uint64()
+	}
+
+	newSimulator := Simulator{
+		model:   model,
+		random:  rand.New(rand.NewPCG(seed, seed)),
+		gpus:    make([]gpuState, settings.GPUs),
+		sockets: make([]socketState, settings.Sockets),
+	}
+
+	for i := range newSimulator.gpus {
+		gpu := &newSimulator.gpus[i]
+		gpu.temperature = ambientTemperature
+		gpu.power = model.idlePower
+		gpu.sclk = model.sclkLevels[0]
+		gpu.mclk = model.mclkLevels[0]
+		gpu.vramUsed = idleVRAMUsed
+		gpu.gttUsed = gttBaseUsed
+		// phases are staggered so GPUs do not start bursts at the same time.
+		newSimulator.startPhase(gpu)
+		gpu.phaseRemaining *= newSimulator.random.Float64()
+	}
+
+	for i := range newSimulator.sockets {
+		newSimulator.sockets[i] = socketState{
+			utilization: newSimulator.random.Float64(),
+			power:       cpuIdlePower,
+			coreEnergy:  make([]float64, settings.ThreadsPerSocket),
+		}
+	}
+
+	return &newSimulator, nil
+}
+
+// validateFleet checks the number of simulated devices.
+func validateFleet(settings *amd.SimulatorSetup) error {
+	if settings.GPUs < 0 || settings.Sockets < 0 || settings.ThreadsPerSocket < 0 {
+		return fmt.Errorf(
+			"%w: %d gpus, %d sockets, %d threads per socket",
+			errInvalidFleet, settings.GPUs, settings.Sockets, settings.ThreadsPerSocket,
+		)
+	}
+
+	return nil
+}
+
+// Cards returns the inventory of simulated GPU cards.
+func (s *Simulator) Cards() []gpus.Card {
+	result := make([]gpus.Card, len(s.gpus))
+
+	for i := range s.gpus {
+		result[i] = gpus.Card{
+			Cardseries: s.model.name,
+			Cardmodel:  fmt.Sprintf("0x%04x", s.model.subsystemID),
+			Cardvendor: cardVendor,
+			CardSKU:    s.model.sku,
+			PCIBus:     pciBus(i),
+			CardGUID:   strconv.Itoa(firstGUID + i),
+			UniqueID:   fmt.Sprintf("0x%016x", uniqueIDBase+uint64(i)),
+			GFXVersion: s.model.gfxVersion,
+		}
+
+		// GPUs are spread evenly over the sockets, each socket being a NUMA node.
+		if len(s.sockets) > 0 {
+			socket := i * len(s.sockets) / len(s.gpus)
+			result[i].NUMANode = strconv.Itoa(socket)
+			result[i].Socket = strconv.Itoa(socket)
+			result[i].LocalCPUs = s.localCPUs(socket)
+			result[i].RootComplex = fmt.Sprintf("%04x:00", i/cardsPerDomain)
+		}
+	}
+
+	return result
+}
+
+// localCPUs returns the list of threads of the given socket, threads are numbered socket after socket.
+func (s *Simulator) localCPUs(socket int) string {
+	threads := len(s.sockets[socket].coreEnergy)
+	if threads == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d-%d", socket*threads, (socket+1)*threads-1)
+}
+
+// pciBus builds a pci bus address for the given card index, cards are spread
+// over the bus range as they are on real nodes, e.g. 0000:0c:00.0, 0000:29:00.0.
+func pciBus(index int) string {
+	return fmt.Sprintf("%04x:%02x:00.0", index/cardsPerDomain, firstBus+(index%cardsPerDomain)*busStride)
+}
+
+// Step advances the simulation by the given duration.
+func (s *Simulator) Step(elapsed time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	dt := elapsed.Seconds()
+	if dt <= 0 {
+		return
+	}
+
+	for i := range s.gpus {
+		s.stepGPU(&s.gpus[i], dt)
+	}
+
+	for i := range s.sockets {
+		s.stepSocket(&s.sockets[i], dt)
+	}
+}
+
+// startPhase toggles busy and idle phases choosing a random duration and target utilization.
+func (s *Simulator) startPhase(gpu *gpuState) {
+	gpu.busy = !gpu.busy
+
+	if gpu.busy {
+		gpu.phaseRemaining = s.uniform(busyPhaseMinDuration, busyPhaseMaxDuration)
+		gpu.targetUtilization = s.uniform(busyMinUtilization, 100)
+		gpu.vramFootprint = s.model.vram * s.uniform(busyMinVRAMRatio, busyMaxVRAMRatio)
+
+		return
+	}
+
+	gpu.phaseRemaining = s.uniform(idlePhaseMinDuration, idlePhaseMaxDuration)
+	gpu.targetUtilization = s.uniform(0, idleMaxUtilization)
+	gpu.vramFootprint = idleVRAMUsed
+}
+
+// stepGPU advances GPU readings by dt seconds.
+func (s *Simulator) stepGPU(gpu *gpuState, dt float64) {
+	gpu.phaseRemaining -= dt
+	if gpu.phaseRemaining <= 0 {
+		s.startPhase(gpu)
+	}
+
+	gpu.utilization += (gpu.targetUtilization - gpu.utilization) * lag(dt, utilizationTimeConst)
+	gpu.utilization = clamp(gpu.utilization+s.noise(utilizationNoise), 0, 100)
+	gpu.memoryUtilization = clamp(gpu.utilization*memoryUtilizationRatio+s.noise(utilizationNoise), 0, 100)
+
+	dynamicPower := (s.model.powerCap - s.model.idlePower) * gpu.utilization / 100
+	gpu.power = clamp(
+		s.model.idlePower+dynamicPower+s.noise(s.model.powerCap*powerNoise),
+		s.model.idlePower/2,
+		s.model.powerCap,
+	)
+
+	targetTemperature := ambientTemperature + temperatureRise*gpu.power/s.model.powerCap
+	gpu.temperature += (targetTemperature - gpu.temperature) * lag(dt, temperatureTimeConst)
+
+	gpu.throttled[gpus.ThrottleReasonThermal] = gpu.temperature > throttleTemperature
+	gpu.throttled[gpus.ThrottleReasonPower] = gpu.power >= s.model.powerCap*powerThrottleRatio
+
+	for reason, throttled := range gpu.throttled {
+		if throttled {
+			gpu.throttledSeconds[reason] += dt
+		}
+	}
+
+	level := int(math.Round(gpu.utilization / 100 * float64(len(s.model.sclkLevels)-1)))
+	if gpu.throttled[gpus.ThrottleReasonThermal] {
+		level = max(level-1, 0)
+	}
+
+	gpu.sclk = s.model.sclkLevels[level]
+
+	gpu.mclk = s.model.mclkLevels[0]
+	if gpu.memoryUtilization > memoryBusyUtilization {
+		gpu.mclk = s.model.mclkLevels[len(s.model.mclkLevels)-1]
+	}
+
+	gpu.vramUsed += (gpu.vramFootprint - gpu.vramUsed) * lag(dt, vramTimeConst)
+	gpu.gttUsed = gttBaseUsed + gpu.vramUsed*gttVRAMRatio
+
+	if s.random.Float64() < dt/correctableErrorPeriod {
+		gpu.umcCorrectableErrors++
+	}
+
+	gpu.xgmiLinkBytes += s.xgmiLinkBandwidth(gpu) * dt
+}
+
+// stepSocket advances CPU socket readings by dt seconds, utilization follows a random walk.
+func (s *Simulator) stepSocket(socket *socketState, dt float64) {
+	socket.utilization = clamp(socket.utilization+s.noise(cpuUtilizationStep), 0, 1)
+	socket.power = cpuIdlePower + (cpuPowerLimit-cpuIdlePower)*socket.utilization
+
+	energy := socket.power * milliwattsToMicrojoule * dt
+	socket.energy += energy
+
+	for i := range socket.coreEnergy {
+		socket.coreEnergy[i] += energy / float64(len(socket.coreEnergy))
+	}
+}
+
+// ReadGPUs fills given params with simulated GPU readings.
+func (s *Simulator) ReadGPUs(stat *gpus.AMDParams) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stat.ResizeGPUs(uint(len(s.gpus)))
+
+	for i, gpu := range s.gpus {
+		stat.GPUDevID[i] = gpus.NewReading(float64(s.model.deviceID))
+		stat.GPUPowerCap[i] = gpus.NewReading(s.model.powerCap)
+		stat.GPUPower[i] = gpus.NewReading(math.Round(gpu.power))
+		stat.GPUTemperature[i] = gpus.NewReading(math.Round(gpu.temperature * millidegrees))
+		stat.GPUSCLK[i] = gpus.NewReading(gpu.sclk)
+		stat.GPUMCLK[i] = gpus.NewReading(gpu.mclk)
+		stat.GPUUsage[i] = gpus.NewReading(math.Round(gpu.utilization))
+		stat.GPUMemoryUsage[i] = gpus.NewReading(math.Round(gpu.memoryUtilization))
+		stat.GPUVRAMTotal[i] = gpus.NewReading(s.model.vram)
+		stat.GPUVRAMUsed[i] = gpus.NewReading(math.Round(gpu.vramUsed))
+		// visible VRAM covers the whole VRAM on large BAR systems.
+		stat.GPUVisVRAMUsed[i] = gpus.NewReading(math.Round(gpu.vramUsed))
+		stat.GPUGTTUsed[i] = gpus.NewReading(math.Round(gpu.gttUsed))
+		stat.GPURetiredPages[i] = gpus.NewReading(0)
+		stat.GPUPCIeSpeed[i] = gpus.NewReading(s.model.pcieSpeed)
+		stat.GPUPCIeMaxSpeed[i] = gpus.NewReading(s.model.pcieSpeed)
+		stat.GPUPCIeWidth[i] = gpus.NewReading(s.model.pcieWidth)
+		stat.GPUPCIeMaxWidth[i] = gpus.NewReading(s.model.pcieWidth)
+		stat.GPUPCIeReplays[i] = gpus.NewReading(0)
+		stat.GPUPCIeNAKSent[i] = gpus.NewReading(0)
+		stat.GPUPCIeNAKReceived[i] = gpus.NewReading(0)
+		stat.GPUPCIeBandwidth[i] = gpus.NewReading(math.Round(s.pcieBandwidth(&gpu)))
+
+		for block := range gpus.NumRASBlocks {
+			stat.GPUECCCorrectable[block][i] = gpus.NewReading(0)
+			stat.GPUECCUncorrectable[block][i] = gpus.NewReading(0)
+		}
+
+		stat.GPUECCCorrectable[gpus.RASBlockUMC][i] = gpus.NewReading(gpu.umcCorrectableErrors)
+		stat.GPUXGMILinks[i] = s.xgmiLinks(i, &gpu)
+
+		if len(stat.GPUXGMILinks[i]) > 0 {
+			stat.GPUXGMIWidth[i] = gpus.NewReading(s.model.xgmiWidth)
+			stat.GPUXGMISpeed[i] = gpus.NewReading(s.model.xgmiSpeed)
+		}
+
+		// junction is hotter than edge under load and memory follows memory utilization.
+		junction := gpu.temperature + junctionTempRise*gpu.power/s.model.powerCap
+		memory := gpu.temperature - memoryTempDrop + memoryTempRise*gpu.memoryUtilization/100
+		stat.GPUTemperatures[gpus.TemperatureSensorEdge][i] = gpus.NewReading(math.Round(gpu.temperature * millidegrees))
+		stat.GPUTemperatures[gpus.TemperatureSensorJunction][i] = gpus.NewReading(math.Round(junction * millidegrees))
+		stat.GPUTemperatures[gpus.TemperatureSensorMemory][i] = gpus.NewReading(math.Round(memory * millidegrees))
+		stat.GPUTemperatureCritical[gpus.TemperatureSensorJunction][i] = gpus.NewReading(junctionCriticalTemp * millidegrees)
+		stat.GPUTemperatureEmergency[gpus.TemperatureSensorJunction][i] = gpus.NewReading(junctionEmergencyTemp * millidegrees)
+		stat.GPUTemperatureCritical[gpus.TemperatureSensorMemory][i] = gpus.NewReading(memoryCriticalTemp * millidegrees)
+		stat.GPUTemperatureEmergency[gpus.TemperatureSensorMemory][i] = gpus.NewReading(memoryEmergencyTemp * millidegrees)
+		stat.GPUVoltage[gpus.VoltageRailGFX][i] = gpus.NewReading(math.Round(s.gfxVoltage(&gpu)))
+		stat.GPUVoltage[gpus.VoltageRailSoC][i] = gpus.NewReading(socVoltage)
+
+		for reason, throttled := range gpu.throttled {
+			stat.GPUThrottled[reason][i] = gpus.NewReading(0)
+			if throttled {
+				stat.GPUThrottled[reason][i] = gpus.NewReading(1)
+			}
+
+			stat.GPUThrottledSeconds[reason][i] = gpus.NewReading(gpu.throttledSeconds[reason])
+		}
+	}
+}
+
+// xgmiLinks returns the links of the given GPU, GPUs of the same pci domain form
+// a hive where every GPU is linked to the others.
+func (s *Simulator) xgmiLinks(index int, gpu *gpuState) []gpus.XGMILink {
+	if s.model.xgmiSpeed == 0 {
+		return nil
+	}
+
+	var links []gpus.XGMILink
+
+	first := index / cardsPerDomain * cardsPerDomain
+	for peer := first; peer < min(first+cardsPerDomain, len(s.gpus)); peer++ {
+		if peer == index {
+			continue
+		}
+
+		links = append(links, gpus.XGMILink{
+			Peer:       peer,
+			Status:     gpus.NewReading(1),
+			ReadBytes:  gpus.NewReading(math.Round(gpu.xgmiLinkBytes)),
+			WriteBytes: gpus.NewReading(math.Round(gpu.xgmiLinkBytes)),
+		})
+	}
+
+	return links
+}
+
+// ReadCPUs fills given params with simulated CPU readings.
+func (s *Simulator) ReadCPUs(stat *gpus.AMDParams) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	threads := 0
+	for _, socket := range s.sockets {
+		threads += len(socket.coreEnergy)
+	}
+
+	stat.ResizeCPUs(uint(len(s.sockets)), uint(threads))
+	stat.ThreadsPerCore = uint(threadsPerCore)
+	stat.CPUTopology = make([]gpus.CPUPlacement, 0, threads)
+
+	thread := 0
+
+	for i, socket := range s.sockets {
+		// the first thread of every core of the socket comes first and SMT siblings follow.
+		cores := max(len(socket.coreEnergy)/threadsPerCore, 1)
+		ccxs := (cores + coresPerCCX - 1) / coresPerCCX
+
+		for j := range socket.coreEnergy {
+			core := j % cores
+			stat.CPUTopology = append(stat.CPUTopology, gpus.NewCPUPlacement(i, core, i*ccxs+core/coresPerCCX))
+		}
+
+		stat.SocketEnergy[i] = gpus.NewReading(math.Round(socket.energy))
+		stat.SocketPower[i] = gpus.NewReading(math.Round(socket.power))
+		stat.PowerLimit[i] = gpus.NewReading(cpuPowerLimit)
+		stat.ProchotStatus[i] = gpus.NewReading(0)
+
+		if socket.power >= cpuPowerLimit*prochotPowerRatio {
+			stat.ProchotStatus[i] = gpus.NewReading(1)
+		}
+
+		for _, energy := range socket.coreEnergy {
+			stat.CoreEnergy[thread] = gpus.NewReading(math.Round(energy))
+			stat.CoreBoost[thread] = gpus.NewReading(cpuBoostLimit)
+			thread++
+		}
+	}
+}
+
+// pcieBandwidth returns the pcie throughput of the given GPU in bytes per second, it follows utilization.
+func (s *Simulator) pcieBandwidth(gpu *gpuState) float64 {
+	linkBandwidth := s.model.pcieSpeed * s.model.pcieWidth * gigatransfersToBytes
+
+	return linkBandwidth * pcieBusyLinkRatio * gpu.utilization / 100
+}
+
+// gfxVoltage returns the gfx voltage of the given GPU in millivolts, it follows the gfx clock.
+func (s *Simulator) gfxVoltage(gpu *gpuState) float64 {
+	lowest := s.model.sclkLevels[0]
+	highest := s.model.sclkLevels[len(s.model.sclkLevels)-1]
+
+	return gfxMinVoltage + (gfxMaxVoltage-gfxMinVoltage)*(gpu.sclk-lowest)/(highest-lowest)
+}
+
+// xgmiLinkBandwidth returns the throughput of each XGMI link of the given GPU in bytes per second, it follows utilization.
+func (s *Simulator) xgmiLinkBandwidth(gpu *gpuState) float64 {
+	linkBandwidth := s.model.xgmiSpeed * s.model.xgmiWidth * gigabitsToBytes
+
+	return linkBandwidth * xgmiBusyLinkRatio * gpu.utilization / 100
+}
+
+// uniform returns a random number in [low, high).
+func (s *Simulator) uniform(low, high float64) float64 {
+	return low + (high-low)*s.random.Float64()
+}
+
+// noise returns a random number in [-amplitude, amplitude).
+func (s *Simulator) noise(amplitude float64) float64 {
+	return s.uniform(-amplitude, amplitude)
+}
+
+// lag returns the fraction of the distance to a target covered after dt seconds
+// by a first order system with the given time constant.
+func lag(dt, timeConstant float64) float64 {
+	return 1 - math.Exp(-dt/timeConstant)
+}
+
+// clamp limits value to [low, high].
+func clamp(value, low, high float64) float64 {
+	return math.Min(math.Max(value, low), high)
+}
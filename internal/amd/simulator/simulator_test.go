@@ -0,0 +1,262 @@
+package simulator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/simulator"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/fakekubelet"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/k8sfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1alpha1"
+)
+
+func TestSimulatorCards(t *testing.T) {
+	t.Parallel()
+	// Given
+	sim, err := simulator.NewSimulator(makeFleetFixture(t))
+	require.NoError(t, err)
+
+	// When
+	got := sim.Cards()
+
+	// Then
+	pciBuses := make(map[string]bool)
+	for i := range 8 {
+		assert.Equal(t, "AMD Instinct MI300X", got[i].Cardseries)
+		assert.Equal(t, "0x74a1", got[i].Cardmodel)
+		assert.NotEmpty(t, got[i].PCIBus)
+		pciBuses[got[i].PCIBus] = true
+	}
+	assert.Len(t, pciBuses, 8)
+	assert.Equal(t, "0000:0c:00.0", got[0].PCIBus)
+	assert.Equal(t, "0", got[3].Socket)
+	assert.Equal(t, "0-63", got[3].LocalCPUs)
+	assert.Equal(t, "1", got[4].NUMANode)
+	assert.Equal(t, "64-127", got[4].LocalCPUs)
+	assert.Len(t, got, 8)
+}
+
+func TestSimulatorReadings(t *testing.T) {
+	t.Parallel()
+	// Given
+	sim, err := simulator.NewSimulator(makeFleetFixture(t))
+	require.NoError(t, err)
+
+	var previous gpus.AMDParams
+	sim.ReadGPUs(&previous)
+	sim.ReadCPUs(&previous)
+
+	var busy, idle bool
+
+	// When
+	for range 600 {
+		sim.Step(time.Second)
+
+		var got gpus.AMDParams
+		got.Init()
+		sim.ReadGPUs(&got)
+		sim.ReadCPUs(&got)
+
+		// Then
+		require.Equal(t, uint(8), got.NumGPUs)
+		require.Equal(t, uint(2), got.Sockets)
+		require.Equal(t, uint(128), got.Threads)
+		require.Len(t, got.CPUTopology, 128)
+		require.Equal(t, gpus.NewCPUPlacement(1, 0, 4), got.CPUTopology[96])
+
+		for i := range got.NumGPUs {
+			assert.True(t, got.GPUTemperature[i].Valid())
+			assert.InDelta(t, float64(0x74a1), got.GPUDevID[i].Value, 0)
+			assert.InDelta(t, 750e6, got.GPUPowerCap[i].Value, 0)
+			assert.LessOrEqual(t, got.GPUPower[i].Value, got.GPUPowerCap[i].Value)
+			assert.GreaterOrEqual(t, got.GPUPower[i].Value, 70e6)
+			assert.GreaterOrEqual(t, got.GPUUsage[i].Value, float64(0))
+			assert.LessOrEqual(t, got.GPUUsage[i].Value, float64(100))
+			assert.Contains(t, []float64{500e6, 800e6, 1200e6, 1700e6, 2100e6}, got.GPUSCLK[i].Value)
+			assert.Contains(t, []float64{900e6, 1300e6}, got.GPUMCLK[i].Value)
+			assert.InDelta(t, float64(192<<30), got.GPUVRAMTotal[i].Value, 0)
+			assert.LessOrEqual(t, got.GPUVRAMUsed[i].Value, got.GPUVRAMTotal[i].Value)
+			assert.Greater(t, got.GPUGTTUsed[i].Value, float64(0))
+			// temperature lags behind power.
+			assert.InDelta(t, previous.GPUTemperature[i].Value, got.GPUTemperature[i].Value, 2e3)
+			// junction sensor is the hottest spot of the die.
+			edge := got.GPUTemperatures[gpus.TemperatureSensorEdge][i].Value
+			assert.InDelta(t, got.GPUTemperature[i].Value, edge, 0)
+			assert.GreaterOrEqual(t, got.GPUTemperatures[gpus.TemperatureSensorJunction][i].Value, edge)
+			assert.Less(t, got.GPUTemperatures[gpus.TemperatureSensorJunction][i].Value, got.GPUTemperatureCritical[gpus.TemperatureSensorJunction][i].Value)
+			assert.GreaterOrEqual(t, got.GPUVoltage[gpus.VoltageRailGFX][i].Value, float64(650))
+			assert.LessOrEqual(t, got.GPUVoltage[gpus.VoltageRailGFX][i].Value, float64(1100))
+			// every GPU is linked to the other GPUs of the hive.
+			require.Len(t, got.GPUXGMILinks[i], 7)
+			assert.NotEqual(t, int(i), got.GPUXGMILinks[i][0].Peer)
+			assert.GreaterOrEqual(t, got.GPUXGMILinks[i][0].ReadBytes.Value, previous.GPUXGMILinks[i][0].ReadBytes.Value)
+			// throttled time only grows while clocks are throttled.
+			for reason := range gpus.NumThrottleReasons {
+				seconds := got.GPUThrottledSeconds[reason][i].Value - previous.GPUThrottledSeconds[reason][i].Value
+				assert.InDelta(t, got.GPUThrottled[reason][i].Value, seconds, 0)
+			}
+
+			busy = busy || got.GPUUsage[i].Value > 70
+			idle = idle || got.GPUUsage[i].Value < 10
+		}
+
+		for i := range got.Sockets {
+			assert.Greater(t, got.SocketEnergy[i].Value, previous.SocketEnergy[i].Value)
+			assert.LessOrEqual(t, got.SocketPower[i].Value, got.PowerLimit[i].Value)
+		}
+
+		for i := range got.Threads {
+			assert.Greater(t, got.CoreEnergy[i].Value, previous.CoreEnergy[i].Value)
+		}
+
+		previous = got
+	}
+
+	assert.True(t, busy)
+	assert.True(t, idle)
+}
+
+func TestSimulatorReproducible(t *testing.T) {
+	t.Parallel()
+	// Given
+	first, err := simulator.NewSimulator(makeFleetFixture(t))
+	require.NoError(t, err)
+	second, err := simulator.NewSimulator(makeFleetFixture(t))
+	require.NoError(t, err)
+
+	var want, got gpus.AMDParams
+
+	// When
+	first.Step(time.Minute)
+	second.Step(time.Minute)
+	first.ReadGPUs(&want)
+	second.ReadGPUs(&got)
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestNewSimulatorWithInvalidSettings(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]amd.SimulatorSetup{
+		"unknown gpu model":      {GPUs: 1, GPUModel: "MI999"},
+		"negative gpu number":    {GPUs: -1, GPUModel: "MI300X"},
+		"negative socket number": {GPUs: 1, GPUModel: "MI300X", Sockets: -1},
+	}
+
+	for name, settings := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			got, err := simulator.NewSimulator(&settings)
+
+			// Then
+			require.Error(t, err)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestExporterWithFakeKubelet(t *testing.T) {
+	t.Parallel()
+	// Given
+	backend, err := simulator.NewBackend(&amd.BackendSetup{
+		Logger:    testlogs.NewLogger(),
+		Simulator: *makeFleetFixture(t),
+	})
+	require.NoError(t, err)
+
+	cards, err := backend.Devices()
+	require.NoError(t, err)
+
+	k8sClient := fakekubelet.New(t,
+		fakekubelet.WithPodResources([]*podresourcesapi.PodResources{
+			{
+				Name:      "trainer-0",
+				Namespace: "team-a",
+				Containers: []*podresourcesapi.ContainerResources{
+					{
+						Name: "container-1",
+						Devices: []*podresourcesapi.ContainerDevices{
+							{ResourceName: "amd.com/gpu", DeviceIds: []string{cards[0].PCIBus, cards[1].PCIBus}},
+						},
+					},
+				},
+			},
+		}),
+		fakekubelet.WithNodeName("node-1"),
+		fakekubelet.WithClientSet(fake.NewClientset(k8sfixtures.ExistingPodsWithoutLabelsFixture(t)...)),
+	)
+
+	scanner := amd.NewScanner(&amd.ScannerSetup{
+		Logger:     testlogs.NewLogger(),
+		GPUBackend: backend,
+		CPUBackend: backend,
+	})
+	exporter := exporters.NewExporter(&exporters.Setup{
+		K8SClient:      k8sClient,
+		CardsInfo:      cards,
+		Logger:         testlogs.NewLogger(),
+		WithKubernetes: true,
+		GetMetricsFunc: scanner.Scan,
+	})
+
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(exporter))
+
+	// When
+	families, err := registry.Gather()
+
+	// Then
+	require.NoError(t, err)
+
+	podsByDevice := make(map[string]string)
+
+	for _, family := range families {
+		if family.GetName() != "amd_gpu_power_watts" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			podsByDevice[labels["device"]] = labels["exported_pod"]
+		}
+	}
+
+	want := map[string]string{
+		"amd0": "trainer-0",
+		"amd1": "trainer-0",
+		"amd2": "",
+		"amd3": "",
+		"amd4": "",
+		"amd5": "",
+		"amd6": "",
+		"amd7": "",
+	}
+	assert.Equal(t, want, podsByDevice)
+}
+
+func makeFleetFixture(t *testing.T) *amd.SimulatorSetup {
+	t.Helper()
+
+	return &amd.SimulatorSetup{
+		GPUs:             8,
+		GPUModel:         "MI300X",
+		Sockets:          2,
+		ThreadsPerSocket: 64,
+		Seed:             42,
+	}
+}
@@ -0,0 +1,6 @@
+// Package smilib implements an AMD telemetry backend on top of the go_amd_smi
+// binding, which wraps E-SMI (CPU) and ROCm SMI (GPU) C libraries.
+package smilib
+
+// BackendName is the name used to register this backend.
+const BackendName string = "goamdsmi"
@@ -0,0 +1,101 @@
+package smilib
+
+import "github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+
+var UINT16_MAX = uint16(0xFFFF)
+var UINT32_MAX = uint32(0xFFFFFFFF)
+var UINT64_MAX = uint64(0xFFFFFFFFFFFFFFFF)
+
+// rocm-smi temperature metrics.
+const (
+	rsmiTempCurrent   int = 0
+	rsmiTempCritical  int = 5
+	rsmiTempEmergency int = 7
+)
+
+// rsmiTemperatureSensors contains rocm-smi temperature sensor types indexed by sensor.
+var rsmiTemperatureSensors = [gpus.NumTemperatureSensors]int{
+	gpus.TemperatureSensorEdge:     0,
+	gpus.TemperatureSensorJunction: 1,
+	gpus.TemperatureSensorMemory:   2,
+}
+
+// GPULibrary reads the GPUs of the given rocm-smi index, values are UINT16_MAX, UINT32_MAX or UINT64_MAX
+// when the reading failed. It is implemented by the go_amd_smi binding in cgo enabled builds.
+type GPULibrary interface {
+	DevID(gpu int) uint16
+	PowerCap(gpu int) uint64
+	Power(gpu int) uint64
+	TempMetric(gpu, sensor, metric int) uint64
+	SCLK(gpu int) uint64
+	MCLK(gpu int) uint64
+	BusyPercent(gpu int) uint32
+	MemoryBusyPercent(gpu int) uint64
+	MemoryTotal(gpu int) uint64
+	MemoryUsage(gpu int) uint64
+}
+
+// ReadGPU reads the GPU of the given rocm-smi index into the readings of the given card. The library
+// reports the power, temperatures, clocks, activity and memory of the whole GPU for every compute
+// partition, so they are only read for the first partition, as the sysfs backend does, and are left
+// unsupported for the other partitions of the GPU.
+func ReadGPU(library GPULibrary, stat *gpus.AMDParams, card, gpu, partitionID int) {
+	stat.GPUDevID[card] = newReading16(library.DevID(gpu))
+
+	if partitionID > 0 {
+		return
+	}
+
+	stat.GPUPowerCap[card] = newReading64(library.PowerCap(gpu))
+	stat.GPUPower[card] = newReading64(library.Power(gpu))
+
+	//Get the value for GPU current temperature. Sensor = 0(GPU), Metric = 0(current)
+	value64 := library.TempMetric(gpu, 0, 0)
+	if UINT64_MAX == value64 {
+		//Sensor = 1 (GPU Junction Temp)
+		value64 = library.TempMetric(gpu, 1, 0)
+	}
+	stat.GPUTemperature[card] = newReading64(value64)
+
+	for sensor, sensorType := range rsmiTemperatureSensors {
+		stat.GPUTemperatures[sensor][card] = newReading64(library.TempMetric(gpu, sensorType, rsmiTempCurrent))
+		stat.GPUTemperatureCritical[sensor][card] = newReading64(library.TempMetric(gpu, sensorType, rsmiTempCritical))
+		stat.GPUTemperatureEmergency[sensor][card] = newReading64(library.TempMetric(gpu, sensorType, rsmiTempEmergency))
+	}
+
+	stat.GPUSCLK[card] = newReading64(library.SCLK(gpu))
+	stat.GPUMCLK[card] = newReading64(library.MCLK(gpu))
+	stat.GPUUsage[card] = newReading32(library.BusyPercent(gpu))
+	stat.GPUMemoryUsage[card] = newReading64(library.MemoryBusyPercent(gpu))
+
+	// visible VRAM and GTT usage, RAS error counts, fans and voltages are not provided by the library.
+	stat.GPUVRAMTotal[card] = newReading64(library.MemoryTotal(gpu))
+	stat.GPUVRAMUsed[card] = newReading64(library.MemoryUsage(gpu))
+}
+
+// newReading16 returns a reading of the given value, UINT16_MAX is returned by the library when the reading failed.
+func newReading16(value uint16) gpus.Reading {
+	if UINT16_MAX == value {
+		return gpus.FailedReading()
+	}
+
+	return gpus.NewReading(float64(value))
+}
+
+// newReading32 returns a reading of the given value, UINT32_MAX is returned by the library when the reading failed.
+func newReading32(value uint32) gpus.Reading {
+	if UINT32_MAX == value {
+		return gpus.FailedReading()
+	}
+
+	return gpus.NewReading(float64(value))
+}
+
+// newReading64 returns a reading of the given value, UINT64_MAX is returned by the library when the reading failed.
+func newReading64(value uint64) gpus.Reading {
+	if UINT64_MAX == value {
+		return gpus.FailedReading()
+	}
+
+	return gpus.NewReading(float64(value))
+}
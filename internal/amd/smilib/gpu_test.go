@@ -0,0 +1,118 @@
+package smilib_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/smilib"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+)
+
+const failed64 = uint64(0xFFFFFFFFFFFFFFFF)
+
+func TestReadGPU(t *testing.T) {
+	t.Parallel()
+	// Given
+	// the edge sensor of the GPU fails, so its junction temperature is reported.
+	library := fakeLibrary{
+		temperatures: map[[2]int]uint64{
+			{0, 0}: failed64, {1, 0}: 48e3, {2, 0}: 45e3,
+			{0, 5}: failed64, {1, 5}: 105e3, {2, 5}: failed64,
+			{0, 7}: failed64, {1, 7}: 110e3, {2, 7}: failed64,
+		},
+	}
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(2)
+
+	// When
+	smilib.ReadGPU(library, &got, 1, 3, 0)
+
+	// Then
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(0x74a1)}, got.GPUDevID)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(750e6)}, got.GPUPowerCap)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(300e6)}, got.GPUPower)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(48e3)}, got.GPUTemperature)
+	assert.Equal(t, []gpus.Reading{{}, gpus.FailedReading()}, got.GPUTemperatures[gpus.TemperatureSensorEdge])
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(48e3)}, got.GPUTemperatures[gpus.TemperatureSensorJunction])
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(45e3)}, got.GPUTemperatures[gpus.TemperatureSensorMemory])
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(105e3)}, got.GPUTemperatureCritical[gpus.TemperatureSensorJunction])
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(110e3)}, got.GPUTemperatureEmergency[gpus.TemperatureSensorJunction])
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(2100e6)}, got.GPUSCLK)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(1300e6)}, got.GPUMCLK)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(87)}, got.GPUUsage)
+	assert.Equal(t, []gpus.Reading{{}, gpus.FailedReading()}, got.GPUMemoryUsage)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(192 << 30)}, got.GPUVRAMTotal)
+	assert.Equal(t, []gpus.Reading{{}, gpus.NewReading(64 << 30)}, got.GPUVRAMUsed)
+}
+
+func TestReadGPUPartition(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		partitionID int
+		want        []gpus.Reading
+	}{
+		"first partition reads the whole GPU": {
+			partitionID: 0,
+			want:        []gpus.Reading{gpus.NewReading(300e6)},
+		},
+		"other partitions do not repeat the readings of the whole GPU": {
+			partitionID: 1,
+			want:        []gpus.Reading{{}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			library := fakeLibrary{temperatures: map[[2]int]uint64{{0, 0}: 41e3}}
+
+			var got gpus.AMDParams
+			got.Init()
+			got.ResizeGPUs(1)
+
+			// When
+			smilib.ReadGPU(library, &got, 0, 0, tt.partitionID)
+
+			// Then
+			assert.Equal(t, []gpus.Reading{gpus.NewReading(0x74a1)}, got.GPUDevID)
+			assert.Equal(t, tt.want, got.GPUPower)
+
+			for _, readings := range [][]gpus.Reading{
+				got.GPUPowerCap, got.GPUTemperature, got.GPUTemperatures[gpus.TemperatureSensorEdge],
+				got.GPUSCLK, got.GPUMCLK, got.GPUUsage, got.GPUVRAMTotal, got.GPUVRAMUsed,
+			} {
+				assert.Equal(t, tt.partitionID > 0, readings[0] == gpus.Reading{})
+			}
+		})
+	}
+}
+
+// fakeLibrary reports the same readings for every GPU, temperatures are indexed by sensor and metric
+// and missing ones have failed.
+type fakeLibrary struct {
+	temperatures map[[2]int]uint64
+}
+
+func (fakeLibrary) DevID(int) uint16    { return 0x74a1 }
+func (fakeLibrary) PowerCap(int) uint64 { return 750e6 }
+func (fakeLibrary) Power(int) uint64    { return 300e6 }
+func (fakeLibrary) SCLK(int) uint64     { return 2100e6 }
+func (fakeLibrary) MCLK(int) uint64     { return 1300e6 }
+
+func (fakeLibrary) BusyPercent(int) uint32       { return 87 }
+func (fakeLibrary) MemoryBusyPercent(int) uint64 { return failed64 }
+func (fakeLibrary) MemoryTotal(int) uint64       { return 192 << 30 }
+func (fakeLibrary) MemoryUsage(int) uint64       { return 64 << 30 }
+
+func (f fakeLibrary) TempMetric(_, sensor, metric int) uint64 {
+	value, ok := f.temperatures[[2]int{sensor, metric}]
+	if !ok {
+		return failed64
+	}
+
+	return value
+}
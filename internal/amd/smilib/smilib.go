@@ -0,0 +1,267 @@
+//go:build cgo
+
+/*
+ *
+ * Copyright (c) 2022, Advanced Micro Devices, Inc.
+ * Original work developed by:
+ *                 AMD Research and AMD Software Development
+ *
+ *                 Advanced Micro Devices, Inc.
+ *                 www.amd.com
+ *
+ * Modified work by:
+ *                 Open Innovation AI
+ *                 www.openinnovationai.com
+ * All rights reserved.
+ */
+
+package smilib
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	goamdsmi "github.com/amd/go_amd_smi"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/hsmp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/k10temp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/powercap"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/sysfs"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/topology"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+var (
+	errCPUInit = errors.New("unable to initialize e-smi cpu library")
+	errGPUInit = errors.New("unable to initialize rocm-smi gpu library")
+)
+
+// Backend reads AMD metrics through the go_amd_smi binding of E-SMI and ROCm SMI libraries.
+// CPU metrics are read from RAPL powercap zones when E-SMI can not be initialized.
+type Backend struct {
+	logger       *slog.Logger
+	sysfsRoot    string
+	temperatures *k10temp.Reader
+	topology     *topology.Reader
+	// telemetry reads the socket telemetry the binding does not expose.
+	telemetry *hsmp.Reader
+	// cpuFallback is nil when there are no RAPL zones either.
+	cpuFallback  amd.Backend
+	fallbackOnce sync.Once
+	// gpuIndexes contains the rocm-smi index of every card, -1 for cards the library does not monitor.
+	// Cards are read in rocm-smi order when it is nil.
+	gpuIndexes []int
+	// devices contains the cards returned by Devices, their pcie readings are read from sysfs.
+	devices []discovery.Device
+}
+
+// NewBackend creates a go_amd_smi backend.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	cpuFallback, err := powercap.NewBackend(settings)
+	if err != nil {
+		settings.Logger.Debug("powercap fallback is not available", slog.String("error", err.Error()))
+	}
+
+	newBackend := Backend{
+		logger:       settings.Logger,
+		sysfsRoot:    settings.SysfsRoot,
+		temperatures: k10temp.NewReader(settings.SysfsRoot),
+		topology:     topology.NewReader(settings.SysfsRoot),
+		telemetry:    hsmp.NewReader(&hsmp.Setup{DIMMAddresses: settings.DIMMAddresses}),
+		cpuFallback:  cpuFallback,
+	}
+
+	return &newBackend, nil
+}
+
+// Devices gets GPU cards information from pci sysfs files, cards are sorted by drm card index
+// and matched to rocm-smi devices by pci bus address, since the library may enumerate them in
+// another order.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	devices, err := discovery.Discover(b.sysfsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get GPU product names: %w", err)
+	}
+
+	b.devices = devices
+
+	if !goamdsmi.GO_gpu_init() {
+		b.logger.Warn("rocm-smi gpu library is not available, cards are assumed to be in rocm-smi order")
+
+		return discovery.Cards(devices), nil
+	}
+
+	bdfids := make([]uint64, int(goamdsmi.GO_gpu_num_monitor_devices()))
+	for i := range bdfids {
+		bdfids[i] = uint64(goamdsmi.GO_gpu_dev_pci_id_get(i))
+	}
+
+	b.gpuIndexes = discovery.MatchBDFIDs(devices, bdfids)
+
+	for i, index := range b.gpuIndexes {
+		if index < 0 {
+			b.logger.Warn("card is not monitored by rocm-smi library", slog.String("pci_bus", devices[i].Address))
+		}
+	}
+
+	return discovery.Cards(devices), nil
+}
+
+// ReadCPUs reads CPU metrics from E-SMI library, or from RAPL powercap zones when
+// the library can not be initialized, e.g. because the HSMP driver is not loaded.
+// Temperatures are read from k10temp hwmon devices since E-SMI does not provide them, and
+// the E-SMI readings missing from the binding are read through HSMP messages.
+func (b *Backend) ReadCPUs(stat *gpus.AMDParams) error {
+	initialized := goamdsmi.GO_cpu_init()
+	b.logger.Debug("GO_cpu_init", slog.Bool("value", initialized))
+
+	if !initialized {
+		if b.cpuFallback == nil {
+			return errCPUInit
+		}
+
+		b.fallbackOnce.Do(func() {
+			b.logger.Info("e-smi cpu library is not available, reading cpu metrics from powercap", slog.String("backend", powercap.BackendName))
+		})
+
+		err := b.cpuFallback.ReadCPUs(stat)
+		if err != nil {
+			return fmt.Errorf("unable to read cpu metrics from fallback: %w", err)
+		}
+
+		return nil
+	}
+
+	num_sockets := int(goamdsmi.GO_cpu_number_of_sockets_get())
+	num_threads := int(goamdsmi.GO_cpu_number_of_threads_get())
+	num_threads_per_core := int(goamdsmi.GO_cpu_threads_per_core_get())
+
+	stat.ResizeCPUs(uint(num_sockets), uint(num_threads))
+	stat.ThreadsPerCore = uint(num_threads_per_core)
+
+	for i := 0; i < num_threads; i++ {
+		stat.CoreEnergy[i] = newReading64(uint64(goamdsmi.GO_cpu_core_energy_get(i)))
+		stat.CoreBoost[i] = newReading32(uint32(goamdsmi.GO_cpu_core_boostlimit_get(i)))
+	}
+
+	for i := 0; i < num_sockets; i++ {
+		stat.SocketEnergy[i] = newReading64(uint64(goamdsmi.GO_cpu_socket_energy_get(i)))
+		stat.SocketPower[i] = newReading32(uint32(goamdsmi.GO_cpu_socket_power_get(i)))
+		stat.PowerLimit[i] = newReading32(uint32(goamdsmi.GO_cpu_socket_power_cap_get(i)))
+		stat.ProchotStatus[i] = newReading32(uint32(goamdsmi.GO_cpu_prochot_status_get(i)))
+	}
+
+	b.temperatures.Read(stat)
+	b.topology.Read(stat)
+	b.telemetry.Read(stat)
+
+	return nil
+}
+
+// ReadGPUs reads GPU metrics from ROCm SMI library, readings are indexed by the cards returned by Devices.
+// PCIe readings are read from sysfs since the binding does not provide them, and readings of the whole GPU
+// are only read for the first compute partition of partitioned GPUs.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	initialized := goamdsmi.GO_gpu_init()
+	b.logger.Debug("GO_gpu_init", slog.Bool("value", initialized))
+
+	if !initialized {
+		return errGPUInit
+	}
+
+	num_gpus := int(goamdsmi.GO_gpu_num_monitor_devices())
+	indexes := b.gpuIndexes
+	if indexes == nil {
+		indexes = make([]int, num_gpus)
+		for i := range indexes {
+			indexes[i] = i
+		}
+	}
+
+	stat.ResizeGPUs(uint(len(indexes)))
+
+	// pcie readings are read concurrently since pcie_bw takes a second to be sampled by the driver.
+	var wg sync.WaitGroup
+
+	for card := range min(len(indexes), len(b.devices)) {
+		if b.devices[card].PartitionID > 0 {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sysfs.ReadPCIe(b.devices[card].Path, card, stat)
+		}()
+	}
+
+	for card, gpu := range indexes {
+		if gpu < 0 || gpu >= num_gpus {
+			continue
+		}
+
+		partitionID := 0
+		if card < len(b.devices) {
+			partitionID = b.devices[card].PartitionID
+		}
+
+		ReadGPU(binding{}, stat, card, gpu, partitionID)
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// Close does nothing, libraries are released when process ends.
+func (b *Backend) Close() error {
+	return nil
+}
+
+// binding reads GPUs through the go_amd_smi binding.
+type binding struct{}
+
+func (binding) DevID(gpu int) uint16 {
+	return uint16(goamdsmi.GO_gpu_dev_id_get(gpu))
+}
+
+func (binding) PowerCap(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_power_cap_get(gpu))
+}
+
+func (binding) Power(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_power_get(gpu))
+}
+
+func (binding) TempMetric(gpu, sensor, metric int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_temp_metric_get(gpu, sensor, metric))
+}
+
+func (binding) SCLK(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_gpu_clk_freq_get_sclk(gpu))
+}
+
+func (binding) MCLK(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_gpu_clk_freq_get_mclk(gpu))
+}
+
+func (binding) BusyPercent(gpu int) uint32 {
+	return uint32(goamdsmi.GO_gpu_dev_gpu_busy_percent_get(gpu))
+}
+
+func (binding) MemoryBusyPercent(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_gpu_memory_busy_percent_get(gpu))
+}
+
+func (binding) MemoryTotal(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_gpu_memory_total_get(gpu))
+}
+
+func (binding) MemoryUsage(gpu int) uint64 {
+	return uint64(goamdsmi.GO_gpu_dev_gpu_memory_usage_get(gpu))
+}
@@ -0,0 +1,16 @@
+//go:build !cgo
+
+package smilib
+
+import (
+	"errors"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+)
+
+var errCGORequired = errors.New("go_amd_smi backend requires a cgo enabled build")
+
+// NewBackend returns an error, go_amd_smi library can only be used in cgo enabled builds.
+func NewBackend(_ *amd.BackendSetup) (amd.Backend, error) {
+	return nil, errCGORequired
+}
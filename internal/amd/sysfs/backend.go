@@ -0,0 +1,379 @@
+// Package sysfs implements an AMD GPU telemetry backend reading amdgpu driver
+// files from sysfs, it does not require ROCm libraries to be installed.
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/gpumetrics"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// BackendName is the name used to register this backend.
+const BackendName string = "sysfs"
+
+// sysfs paths and values.
+const (
+	RootDefault     string = discovery.RootDefault
+	hwmonFolderName string = "hwmon"
+)
+
+// amdgpu device files.
+const (
+	gpuBusyFile      string = "gpu_busy_percent"
+	memBusyFile      string = "mem_busy_percent"
+	sclkFile         string = "pp_dpm_sclk"
+	mclkFile         string = "pp_dpm_mclk"
+	vramTotalFile    string = "mem_info_vram_total"
+	vramUsedFile     string = "mem_info_vram_used"
+	visVRAMUsedFile  string = "mem_info_vis_vram_used"
+	gttUsedFile      string = "mem_info_gtt_used"
+	powerAverageFile string = "power1_average"
+	powerInputFile   string = "power1_input"
+	powerCapFile     string = "power1_cap"
+	gpuMetricsFile   string = "gpu_metrics"
+)
+
+const megahertzToHertz float64 = 1e6
+
+var (
+	// current DPM level is marked with an asterisk, e.g. "1: 800Mhz *" or "S: 19Mhz *".
+	currentDPMLevelRegex = regexp.MustCompile(`(?m)^\s*\w+:\s*([0-9]+)\s*[Mm][Hh]z\s*\*\s*$`)
+	errCurrentDPMLevel   = errors.New("current dpm level not found")
+)
+
+// Backend reads AMD GPU metrics from amdgpu sysfs files.
+type Backend struct {
+	logger *slog.Logger
+	cards  []card
+}
+
+// card contains sysfs paths of an amdgpu card.
+type card struct {
+	device     discovery.Device
+	devicePath string
+	hwmonPath  string
+	// xgmiPeers contains card indexes of the GPUs linked to this one by XGMI.
+	xgmiPeers []int
+	throttle  *gpumetrics.ThrottleTracker
+	// partitions is the number of compute partitions of the GPU of the card.
+	partitions int
+	// xgmiLinks contains the index of the gpu_metrics XGMI link to each peer, which is the index
+	// of the peer among the GPUs of the node as amd-smi assumes.
+	xgmiLinks []int
+}
+
+// NewBackend creates a sysfs backend discovering amdgpu cards below the configured sysfs root.
+func NewBackend(settings *amd.BackendSetup) (amd.Backend, error) {
+	root := settings.SysfsRoot
+	if root == "" {
+		root = RootDefault
+	}
+
+	cards, err := discoverCards(root)
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover amdgpu cards: %w", err)
+	}
+
+	settings.Logger.Info("amdgpu cards found in sysfs", slog.String("root", root), slog.Int("cards", len(cards)))
+
+	newBackend := Backend{
+		logger: settings.Logger,
+		cards:  cards,
+	}
+
+	return &newBackend, nil
+}
+
+// discoverCards finds amdgpu cards within drm class directory sorted by card index.
+func discoverCards(root string) ([]card, error) {
+	devices, err := discovery.Discover(root)
+	if err != nil {
+		return nil, fmt.Errorf("discovering amdgpu devices: %w", err)
+	}
+
+	result := make([]card, 0, len(devices))
+	indexes := make(map[string]int, len(devices))
+	gpuIndexes := make(map[string]int, len(devices))
+	partitions := make(map[string]int, len(devices))
+
+	for i, device := range devices {
+		result = append(result, card{
+			device:     device,
+			devicePath: device.Path,
+			hwmonPath:  findHwmonPath(device.Path),
+			throttle:   gpumetrics.NewThrottleTracker(),
+		})
+		partitions[device.Address]++
+
+		// peers are linked to the first partition of the GPU.
+		if device.PartitionID == 0 {
+			indexes[device.Address] = i
+			gpuIndexes[device.Address] = len(gpuIndexes)
+		}
+	}
+
+	for i := range result {
+		result[i].partitions = partitions[result[i].device.Address]
+
+		for _, peer := range result[i].device.XGMIPeers {
+			if index, exist := indexes[peer]; exist {
+				result[i].xgmiPeers = append(result[i].xgmiPeers, index)
+				result[i].xgmiLinks = append(result[i].xgmiLinks, gpuIndexes[peer])
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// findHwmonPath returns the first hwmon directory of the given device, empty if there is none.
+func findHwmonPath(devicePath string) string {
+	matches, err := filepath.Glob(filepath.Join(devicePath, hwmonFolderName, hwmonFolderName+"*"))
+	if err != nil || len(matches) == 0 {
+		return ""
+	}
+
+	slices.Sort(matches)
+
+	return matches[0]
+}
+
+// Devices returns information about amdgpu cards found in sysfs.
+func (b *Backend) Devices() ([]gpus.Card, error) {
+	result := make([]gpus.Card, len(b.cards))
+
+	for i := range b.cards {
+		result[i] = b.cards[i].device.Card()
+	}
+
+	return result, nil
+}
+
+// ReadGPUs reads GPU metrics from amdgpu sysfs files, cards are read concurrently
+// since some files such as pcie_bw take a second to be sampled by the driver.
+func (b *Backend) ReadGPUs(stat *gpus.AMDParams) error {
+	stat.ResizeGPUs(uint(len(b.cards)))
+
+	var wg sync.WaitGroup
+
+	for i := range b.cards {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			b.readCard(&b.cards[i], i, stat)
+		}()
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// readCard reads metrics of the given card, readings of missing files are left unsupported
+// and readings of files that could not be read or parsed are set as failed. Readings of the
+// whole GPU are reported by its first compute partition, and the GPU usage of partitioned
+// GPUs is the activity of the XCDs of each partition.
+func (b *Backend) readCard(c *card, i int, stat *gpus.AMDParams) {
+	stat.GPUDevID[i] = gpus.NewReading(float64(c.device.DeviceID))
+
+	if c.device.PartitionID > 0 {
+		b.readGPUMetrics(c, i, stat)
+
+		return
+	}
+
+	if c.partitions == 1 {
+		stat.GPUUsage[i] = newReading(readFloat(filepath.Join(c.devicePath, gpuBusyFile)))
+	}
+
+	stat.GPUMemoryUsage[i] = newReading(readFloat(filepath.Join(c.devicePath, memBusyFile)))
+	stat.GPUSCLK[i] = newReading(readCurrentDPMClock(filepath.Join(c.devicePath, sclkFile)))
+	stat.GPUMCLK[i] = newReading(readCurrentDPMClock(filepath.Join(c.devicePath, mclkFile)))
+
+	// memory values are given in bytes.
+	stat.GPUVRAMTotal[i] = newReading(readFloat(filepath.Join(c.devicePath, vramTotalFile)))
+	stat.GPUVRAMUsed[i] = newReading(readFloat(filepath.Join(c.devicePath, vramUsedFile)))
+	stat.GPUVisVRAMUsed[i] = newReading(readFloat(filepath.Join(c.devicePath, visVRAMUsedFile)))
+	stat.GPUGTTUsed[i] = newReading(readFloat(filepath.Join(c.devicePath, gttUsedFile)))
+
+	readRAS(c.devicePath, i, stat)
+	readPCIe(c.devicePath, i, stat)
+	readXGMILinks(c, i, stat)
+	b.readGPUMetrics(c, i, stat)
+
+	if c.hwmonPath == "" {
+		b.logger.Debug("hwmon directory not found", slog.String("device", c.devicePath))
+
+		return
+	}
+
+	// power values are given in microwatts.
+	stat.GPUPower[i] = newReading(readFirstFloat(c.hwmonPath, powerAverageFile, powerInputFile))
+	stat.GPUPowerCap[i] = newReading(readFloat(filepath.Join(c.hwmonPath, powerCapFile)))
+
+	readSensors(c.hwmonPath, i, stat)
+}
+
+// readXGMILinks sets XGMI links found in kfd topology for the given card, their readings
+// are read from the gpu_metrics table and left unsupported when it does not provide them.
+func readXGMILinks(c *card, i int, stat *gpus.AMDParams) {
+	var links []gpus.XGMILink
+
+	for _, peer := range c.xgmiPeers {
+		links = append(links, gpus.NewXGMILink(peer))
+	}
+
+	stat.GPUXGMILinks[i] = links
+}
+
+// setTableClocks sets the average clocks of the given card from the given gpu_metrics table.
+func setTableClocks(table *gpumetrics.Table, i int, stat *gpus.AMDParams) {
+	for clock := range gpus.NumClockDomains {
+		stat.GPUAverageClocks[clock][i] = table.AverageClocks[clock]
+	}
+}
+
+// setTableXGMI sets the width, speed and the data transferred by the XGMI links of the given card from the given
+// gpu_metrics table, the state of each link is only reported by tables from content revision 7.
+func setTableXGMI(c *card, table *gpumetrics.Table, i int, stat *gpus.AMDParams) {
+	if len(stat.GPUXGMILinks[i]) > 0 {
+		stat.GPUXGMIWidth[i] = table.XGMILinkWidth
+		stat.GPUXGMISpeed[i] = table.XGMILinkSpeed
+	}
+
+	for l := range stat.GPUXGMILinks[i] {
+		link := &stat.GPUXGMILinks[i][l]
+		index := c.xgmiLinks[l]
+
+		if index < len(table.XGMIReadBytes) {
+			link.ReadBytes = table.XGMIReadBytes[index]
+			link.WriteBytes = table.XGMIWriteBytes[index]
+		}
+
+		if index < len(table.XGMILinkStatus) {
+			link.Status = table.XGMILinkStatus[index]
+		}
+	}
+}
+
+// readGPUMetrics reads throttle reasons, energy, pcie counters, XGMI links and partition activity of the given card from its
+// gpu_metrics table, readings are left unsupported when the table or its version is not available.
+func (b *Backend) readGPUMetrics(c *card, i int, stat *gpus.AMDParams) {
+	data, err := os.ReadFile(filepath.Join(c.devicePath, gpuMetricsFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+
+	var table *gpumetrics.Table
+
+	if err == nil {
+		table, err = gpumetrics.Parse(data)
+	}
+
+	partitioned := c.partitions > 1
+	firstPartition := c.device.PartitionID == 0
+
+	switch {
+	case err == nil:
+		if firstPartition {
+			c.throttle.Update(table, i, stat)
+			stat.GPUEnergy[i] = table.Energy
+			setTableClocks(table, i, stat)
+			setTablePCIe(table, i, stat)
+			setTableXGMI(c, table, i, stat)
+		}
+
+		if partitioned {
+			stat.GPUUsage[i] = table.PartitionActivity(c.device.PartitionID)
+		}
+	case errors.Is(err, gpumetrics.ErrUnsupportedVersion):
+		b.logger.Debug("gpu_metrics table not supported", slog.String("device", c.devicePath), slog.String("error", err.Error()))
+	default:
+		b.logger.Debug("unable to read gpu_metrics table", slog.String("device", c.devicePath), slog.String("error", err.Error()))
+
+		if partitioned {
+			stat.GPUUsage[i] = gpus.FailedReading()
+		}
+
+		if !firstPartition {
+			return
+		}
+
+		stat.GPUEnergy[i] = gpus.FailedReading()
+
+		for clock := range gpus.NumClockDomains {
+			stat.GPUAverageClocks[clock][i] = gpus.FailedReading()
+		}
+
+		for reason := range gpus.NumThrottleReasons {
+			stat.GPUThrottled[reason][i] = gpus.FailedReading()
+			stat.GPUThrottledSeconds[reason][i] = gpus.FailedReading()
+		}
+
+		if len(stat.GPUXGMILinks[i]) > 0 {
+			stat.GPUXGMIWidth[i] = gpus.FailedReading()
+			stat.GPUXGMISpeed[i] = gpus.FailedReading()
+		}
+	}
+}
+
+// newReading returns a reading of the given value, missing files are unsupported
+// readings and any other error is a failed reading.
+func newReading(value float64, err error) gpus.Reading {
+	switch {
+	case err == nil:
+		return gpus.NewReading(value)
+	case errors.Is(err, os.ErrNotExist):
+		return gpus.Reading{}
+	default:
+		return gpus.FailedReading()
+	}
+}
+
+// ReadCPUs is not supported by this backend.
+func (b *Backend) ReadCPUs(_ *gpus.AMDParams) error {
+	return fmt.Errorf("sysfs backend reading cpu metrics: %w", amd.ErrNotSupported)
+}
+
+// Close does nothing, files are opened and closed on every reading.
+func (b *Backend) Close() error {
+	return nil
+}
+
+// readCurrentDPMClock reads the current clock level of pp_dpm_* files in hertz.
+func readCurrentDPMClock(path string) (float64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return parseCurrentDPMClock(string(content))
+}
+
+// parseCurrentDPMClock parses pp_dpm_* files content returning current clock in hertz.
+func parseCurrentDPMClock(content string) (float64, error) {
+	matches := currentDPMLevelRegex.FindStringSubmatch(content)
+	if matches == nil {
+		return 0, errCurrentDPMLevel
+	}
+
+	megahertz, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse dpm clock %q: %w", matches[1], err)
+	}
+
+	return megahertz * megahertzToHertz, nil
+}
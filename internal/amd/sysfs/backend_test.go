@@ -0,0 +1,513 @@
+package sysfs_test
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/sysfs"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDevices(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makeSysfsFixture(t)
+	backend := newBackend(t, root)
+
+	want := make([]gpus.Card, 2)
+	want[0] = gpus.Card{
+		Cardseries: "AMD Instinct MI210",
+		Cardmodel:  "0x0c34",
+		Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+		CardSKU:    "D67301",
+		PCIBus:     "0000:03:00.0",
+		CardGUID:   "1000",
+		UniqueID:   "0x5b2a6c0172bd8d66",
+
+		VBIOSVersion: "113-D67301-063",
+
+		RootComplex: "0000:00",
+	}
+	want[1] = gpus.Card{
+		Cardseries: "AMD Instinct MI250X / MI250",
+		Cardmodel:  "0x0000",
+		Cardvendor: "Advanced Micro Devices, Inc. [AMD/ATI]",
+		PCIBus:     "0000:83:00.0",
+		CardGUID:   "1001",
+
+		RootComplex: "0000:00",
+	}
+
+	// When
+	got, err := backend.Devices()
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestReadGPUs(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makeSysfsFixture(t)
+	backend := newBackend(t, root)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	err := backend.ReadGPUs(&got)
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, uint(2), got.NumGPUs)
+
+	assert.Equal(t, gpus.NewReading(0x740f), got.GPUDevID[0])
+	assert.Equal(t, gpus.NewReading(37), got.GPUUsage[0])
+	assert.Equal(t, gpus.NewReading(12), got.GPUMemoryUsage[0])
+	assert.Equal(t, gpus.NewReading(1700e6), got.GPUSCLK[0])
+	assert.Equal(t, gpus.NewReading(1600e6), got.GPUMCLK[0])
+	assert.Equal(t, gpus.NewReading(98e6), got.GPUPower[0])
+	assert.Equal(t, gpus.NewReading(300e6), got.GPUPowerCap[0])
+	assert.Equal(t, gpus.NewReading(41e3), got.GPUTemperature[0])
+	assert.Equal(t, gpus.NewReading(68702699520), got.GPUVRAMTotal[0])
+	assert.Equal(t, gpus.NewReading(17179869184), got.GPUVRAMUsed[0])
+	assert.Equal(t, gpus.NewReading(17179869184), got.GPUVisVRAMUsed[0])
+	assert.Equal(t, gpus.NewReading(33554432), got.GPUGTTUsed[0])
+	assert.Equal(t, gpus.NewReading(3), got.GPUECCCorrectable[gpus.RASBlockUMC][0])
+	assert.Equal(t, gpus.NewReading(0), got.GPUECCUncorrectable[gpus.RASBlockUMC][0])
+	assert.Equal(t, gpus.NewReading(1), got.GPUECCUncorrectable[gpus.RASBlockGFX][0])
+	assert.Equal(t, gpus.NewReading(0), got.GPUECCCorrectable[gpus.RASBlockXGMI][0])
+	assert.Equal(t, gpus.Reading{}, got.GPUECCCorrectable[gpus.RASBlockSDMA][0])
+	assert.Equal(t, gpus.NewReading(2), got.GPURetiredPages[0])
+	assert.Equal(t, gpus.NewReading(16), got.GPUPCIeSpeed[0])
+	assert.Equal(t, gpus.NewReading(32), got.GPUPCIeMaxSpeed[0])
+	assert.Equal(t, gpus.NewReading(8), got.GPUPCIeWidth[0])
+	assert.Equal(t, gpus.NewReading(16), got.GPUPCIeMaxWidth[0])
+	assert.Equal(t, gpus.NewReading(2), got.GPUPCIeReplays[0])
+	assert.Equal(t, gpus.NewReading((1000+2000)*256), got.GPUPCIeBandwidth[0])
+	assert.Equal(t, gpus.Reading{}, got.GPUPCIeNAKSent[0])
+	assert.Equal(t, []gpus.XGMILink{{Peer: 1}}, got.GPUXGMILinks[0])
+	assert.Equal(t, gpus.NewReading(41e3), got.GPUTemperatures[gpus.TemperatureSensorEdge][0])
+	assert.Equal(t, gpus.NewReading(48e3), got.GPUTemperatures[gpus.TemperatureSensorJunction][0])
+	assert.Equal(t, gpus.NewReading(45e3), got.GPUTemperatures[gpus.TemperatureSensorMemory][0])
+	assert.Equal(t, gpus.NewReading(100e3), got.GPUTemperatureCritical[gpus.TemperatureSensorEdge][0])
+	assert.Equal(t, gpus.NewReading(105e3), got.GPUTemperatureCritical[gpus.TemperatureSensorJunction][0])
+	assert.Equal(t, gpus.NewReading(110e3), got.GPUTemperatureEmergency[gpus.TemperatureSensorJunction][0])
+	assert.Equal(t, gpus.Reading{}, got.GPUTemperatureEmergency[gpus.TemperatureSensorEdge][0])
+	assert.Equal(t, gpus.NewReading(806), got.GPUVoltage[gpus.VoltageRailGFX][0])
+	assert.Equal(t, gpus.NewReading(900), got.GPUVoltage[gpus.VoltageRailSoC][0])
+	assert.Equal(t, gpus.Reading{}, got.GPUVoltage[gpus.VoltageRailMemory][0])
+	assert.Equal(t, gpus.NewReading(1200), got.GPUFanSpeed[0])
+	assert.Equal(t, gpus.NewReading(20), got.GPUFanSpeedPercent[0])
+	assert.Equal(t, gpus.NewReading(1), got.GPUThrottled[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.NewReading(0), got.GPUThrottled[gpus.ThrottleReasonThermal][0])
+	assert.Equal(t, gpus.NewReading(0), got.GPUThrottledSeconds[gpus.ThrottleReasonPower][0])
+	assert.Equal(t, gpus.NewReading(15.3e6), got.GPUEnergy[0])
+	assert.Equal(t, gpus.NewReading(1700e6), got.GPUAverageClocks[gpus.ClockDomainGFX][0])
+
+	// second card has no power cap nor gpu busy files, memory busy could not be parsed
+	// and edge temperature is not available but junction temperature.
+	assert.Equal(t, gpus.NewReading(0x740c), got.GPUDevID[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUUsage[1])
+	assert.Equal(t, gpus.FailedReading(), got.GPUMemoryUsage[1])
+	assert.Equal(t, gpus.NewReading(500e6), got.GPUSCLK[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUMCLK[1])
+	assert.Equal(t, gpus.NewReading(75e6), got.GPUPower[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUPowerCap[1])
+	assert.Equal(t, gpus.NewReading(52e3), got.GPUTemperature[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUTemperatures[gpus.TemperatureSensorEdge][1])
+	assert.Equal(t, gpus.NewReading(52e3), got.GPUTemperatures[gpus.TemperatureSensorJunction][1])
+	assert.Equal(t, gpus.NewReading(61e3), got.GPUTemperatures[gpus.TemperatureSensorMemory][1])
+	// channels are found by their label.
+	assert.Equal(t, gpus.NewReading(750), got.GPUVoltage[gpus.VoltageRailGFX][1])
+	assert.Equal(t, gpus.Reading{}, got.GPUVoltage[gpus.VoltageRailSoC][1])
+	assert.Equal(t, gpus.NewReading(1200), got.GPUVoltage[gpus.VoltageRailMemory][1])
+	assert.Equal(t, gpus.Reading{}, got.GPUFanSpeedPercent[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUVRAMUsed[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUECCCorrectable[gpus.RASBlockUMC][1])
+	assert.Equal(t, gpus.Reading{}, got.GPURetiredPages[1])
+	// link speed is unknown when the link is down.
+	assert.Equal(t, gpus.FailedReading(), got.GPUPCIeSpeed[1])
+	assert.Equal(t, []gpus.XGMILink{{Peer: 0}}, got.GPUXGMILinks[1])
+	assert.Equal(t, gpus.FailedReading(), got.GPUXGMIWidth[1])
+	assert.Equal(t, gpus.FailedReading(), got.GPUThrottled[gpus.ThrottleReasonPower][1])
+	assert.Equal(t, gpus.FailedReading(), got.GPUEnergy[1])
+	assert.Equal(t, gpus.FailedReading(), got.GPUAverageClocks[gpus.ClockDomainGFX][1])
+
+	assert.Len(t, got.GPUDevID, 2)
+}
+
+func TestReadGPUsPartitions(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	sysfsfixtures.AMDGPUDevice(t, root, "card0", "0000:0c:00.0", map[string]string{
+		"device":                    "0x74a1\n",
+		"gpu_busy_percent":          "99\n",
+		"current_compute_partition": "DPX\n",
+		"current_memory_partition":  "NPS1\n",
+		"drm/renderD128/dev":        "226:128\n",
+		"hwmon/hwmon0/power1_input": "350000000\n",
+		"gpu_metrics":               gpuMetricsV16([][]uint32{{40, 60}, {10, 30}}),
+	})
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"devices/platform/amdgpu_xcp_0/drm/renderD129/dev": "226:129\n",
+		"class/kfd/kfd/topology/nodes/1/gpu_id":            "1001\n",
+		"class/kfd/kfd/topology/nodes/1/properties":        "location_id 3072\ndomain 0\ndrm_render_minor 128\n",
+		"class/kfd/kfd/topology/nodes/2/gpu_id":            "1002\n",
+		"class/kfd/kfd/topology/nodes/2/properties":        "location_id 3072\ndomain 0\ndrm_render_minor 129\n",
+	})
+	sysfsfixtures.Symlink(t, root, "devices/platform/amdgpu_xcp_0", "class/drm/card1/device")
+
+	backend := newBackend(t, root)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	cards, devicesErr := backend.Devices()
+	err := backend.ReadGPUs(&got)
+
+	// Then
+	require.NoError(t, devicesErr)
+	require.NoError(t, err)
+	require.Len(t, cards, 2)
+	assert.Equal(t, "0000:0c:00.0", cards[0].DeviceID())
+	assert.Equal(t, "amdgpu_xcp_0", cards[1].DeviceID())
+	assert.Equal(t, "DPX", cards[1].ComputePartition)
+	assert.Equal(t, "1002", cards[1].CardGUID)
+
+	assert.Equal(t, uint(2), got.NumGPUs)
+	assert.Equal(t, gpus.NewReading(50), got.GPUUsage[0])
+	assert.Equal(t, gpus.NewReading(20), got.GPUUsage[1])
+	// readings of the whole GPU are reported by its first partition.
+	assert.Equal(t, gpus.NewReading(350e6), got.GPUPower[0])
+	assert.Equal(t, gpus.Reading{}, got.GPUPower[1])
+	assert.Equal(t, gpus.NewReading(0x74a1), got.GPUDevID[1])
+}
+
+func TestReadPCIe(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+	devicePath := sysfsfixtures.AMDGPUDevice(t, root, "card0", "0000:0c:00.0", map[string]string{
+		"current_link_speed": "32.0 GT/s PCIe\n",
+		"current_link_width": "16\n",
+		"pcie_replay_count":  "2\n",
+		"gpu_metrics":        gpuMetricsV15(12, 9, 4, 3),
+	})
+
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(1)
+
+	// When
+	sysfs.ReadPCIe(filepath.Join(root, devicePath), 0, &got)
+
+	// Then
+	assert.Equal(t, gpus.NewReading(32), got.GPUPCIeSpeed[0])
+	assert.Equal(t, gpus.NewReading(16), got.GPUPCIeWidth[0])
+	assert.Equal(t, gpus.Reading{}, got.GPUPCIeMaxWidth[0])
+	// counters accumulated by the firmware replace the ones of pci device files.
+	assert.Equal(t, gpus.NewReading(9), got.GPUPCIeReplays[0])
+	assert.Equal(t, gpus.NewReading(4), got.GPUPCIeNAKSent[0])
+	assert.Equal(t, gpus.NewReading(3), got.GPUPCIeNAKReceived[0])
+	assert.Equal(t, gpus.NewReading(12e9), got.GPUPCIeBandwidth[0])
+}
+
+func TestReadGPUsXGMILinks(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		gpuMetrics string
+		want       [][]gpus.XGMILink
+	}{
+		"v1.5 tables do not report the state of each link": {
+			gpuMetrics: gpuMetricsV15(0, 0, 0, 0),
+			want: [][]gpus.XGMILink{
+				{{Peer: 1, ReadBytes: gpus.NewReading(2 << 20), WriteBytes: gpus.NewReading(3 << 20)}},
+				{{Peer: 0, ReadBytes: gpus.NewReading(1 << 20), WriteBytes: gpus.NewReading(2 << 20)}},
+			},
+		},
+		"v1.7 tables report the state of each link": {
+			gpuMetrics: gpuMetricsV17([]uint16{1, 0}),
+			want: [][]gpus.XGMILink{
+				{{
+					Peer: 1, Status: gpus.NewReading(0),
+					ReadBytes: gpus.NewReading(2 << 20), WriteBytes: gpus.NewReading(3 << 20),
+				}},
+				{{
+					Peer: 0, Status: gpus.NewReading(1),
+					ReadBytes: gpus.NewReading(1 << 20), WriteBytes: gpus.NewReading(2 << 20),
+				}},
+			},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Given
+			root := t.TempDir()
+
+			for _, card := range []struct{ name, address string }{{"card0", "0000:0c:00.0"}, {"card1", "0000:22:00.0"}} {
+				sysfsfixtures.AMDGPUDevice(t, root, card.name, card.address, map[string]string{
+					"gpu_metrics": tt.gpuMetrics,
+				})
+			}
+
+			sysfsfixtures.WriteFiles(t, root, map[string]string{
+				"class/kfd/kfd/topology/nodes/1/gpu_id":                "1000\n",
+				"class/kfd/kfd/topology/nodes/1/properties":            "location_id 3072\ndomain 0\n",
+				"class/kfd/kfd/topology/nodes/1/io_links/0/properties": "type 11\nnode_from 1\nnode_to 2\n",
+				"class/kfd/kfd/topology/nodes/2/gpu_id":                "1001\n",
+				"class/kfd/kfd/topology/nodes/2/properties":            "location_id 8704\ndomain 0\n",
+				"class/kfd/kfd/topology/nodes/2/io_links/0/properties": "type 11\nnode_from 2\nnode_to 1\n",
+			})
+
+			backend := newBackend(t, root)
+
+			var got gpus.AMDParams
+			got.Init()
+
+			// When
+			err := backend.ReadGPUs(&got)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.GPUXGMILinks)
+			assert.Equal(t, []gpus.Reading{gpus.NewReading(16), gpus.NewReading(16)}, got.GPUXGMIWidth)
+			assert.Equal(t, []gpus.Reading{gpus.NewReading(32), gpus.NewReading(32)}, got.GPUXGMISpeed)
+		})
+	}
+}
+
+func TestReadCPUsNotSupported(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := makeSysfsFixture(t)
+	backend := newBackend(t, root)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	err := backend.ReadCPUs(&got)
+
+	// Then
+	require.ErrorIs(t, err, amd.ErrNotSupported)
+}
+
+func TestNewBackendWithoutDRMClass(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := amd.BackendSetup{
+		Logger:    testlogs.NewLogger(),
+		SysfsRoot: t.TempDir(),
+	}
+
+	// When
+	got, err := sysfs.NewBackend(&settings)
+
+	// Then
+	require.Error(t, err)
+	assert.Nil(t, got)
+}
+
+func newBackend(t *testing.T, root string) amd.Backend {
+	t.Helper()
+
+	settings := amd.BackendSetup{
+		Logger:    testlogs.NewLogger(),
+		SysfsRoot: root,
+	}
+
+	backend, err := sysfs.NewBackend(&settings)
+	require.NoError(t, err)
+
+	return backend
+}
+
+func makeSysfsFixture(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+
+	sysfsfixtures.AMDGPUDevice(t, root, "card1", "0000:83:00.0", map[string]string{
+		"device":                      "0x740c\n",
+		"mem_busy_percent":            "N/A\n",
+		"current_link_speed":          "Unknown\n",
+		"pp_dpm_sclk":                 "S: 19Mhz\n0: 500Mhz *\n1: 1700Mhz\n",
+		"hwmon/hwmon5/power1_input":   "75000000\n",
+		"hwmon/hwmon5/temp2_input":    "52000\n",
+		"hwmon/hwmon5/temp2_label":    "junction\n",
+		"hwmon/hwmon5/temp3_input":    "61000\n",
+		"hwmon/hwmon5/temp3_label":    "mem\n",
+		"hwmon/hwmon5/in0_input":      "750\n",
+		"hwmon/hwmon5/in0_label":      "vddgfx\n",
+		"hwmon/hwmon5/in2_input":      "1200\n",
+		"hwmon/hwmon5/in2_label":      "vddmem\n",
+		"hwmon/hwmon5/power1_cap_max": "300000000\n",
+		"gpu_metrics":                 "\x78\x00",
+	})
+	sysfsfixtures.AMDGPUDevice(t, root, "card0", "0000:03:00.0", map[string]string{
+		"device":                       "0x740f\n",
+		"revision":                     "0x02\n",
+		"subsystem_device":             "0x0c34\n",
+		"vbios_version":                "113-D67301-063\n",
+		"unique_id":                    "0x5b2a6c0172bd8d66\n",
+		"gpu_busy_percent":             "37\n",
+		"mem_busy_percent":             "12\n",
+		"pp_dpm_sclk":                  "0: 500Mhz\n1: 1700Mhz *\n",
+		"pp_dpm_mclk":                  "0: 400Mhz\n1: 1600Mhz *\n",
+		"mem_info_vram_total":          "68702699520\n",
+		"mem_info_vram_used":           "17179869184\n",
+		"mem_info_vis_vram_used":       "17179869184\n",
+		"mem_info_gtt_used":            "33554432\n",
+		"current_link_speed":           "16.0 GT/s PCIe\n",
+		"max_link_speed":               "32.0 GT/s PCIe\n",
+		"current_link_width":           "8\n",
+		"max_link_width":               "16\n",
+		"pcie_replay_count":            "2\n",
+		"pcie_bw":                      "1000 2000 256\n",
+		"ras/umc_err_count":            "ue: 0\nce: 3\n",
+		"ras/gfx_err_count":            "ue: 1\nce: 0\n",
+		"ras/xgmi_wafl_err_count":      "ue: 0\nce: 0\nde: 0\n",
+		"ras/gpu_vram_bad_pages":       "0x00000001 : 0x00001000 : R\n0x00000002 : 0x00001000 : R\n0x00000003 : 0x00001000 : P\n",
+		"hwmon/hwmon4/power1_average":  "98000000\n",
+		"hwmon/hwmon4/power1_cap":      "300000000\n",
+		"hwmon/hwmon4/temp1_input":     "41000\n",
+		"hwmon/hwmon4/temp2_input":     "48000\n",
+		"hwmon/hwmon4/temp3_input":     "45000\n",
+		"hwmon/hwmon4/temp1_crit":      "100000\n",
+		"hwmon/hwmon4/temp2_crit":      "105000\n",
+		"hwmon/hwmon4/temp2_emergency": "110000\n",
+		"hwmon/hwmon4/in0_input":       "806\n",
+		"hwmon/hwmon4/in1_input":       "900\n",
+		"hwmon/hwmon4/fan1_input":      "1200\n",
+		"hwmon/hwmon4/pwm1":            "51\n",
+		"hwmon/hwmon4/pwm1_max":        "255\n",
+		"gpu_metrics":                  gpuMetricsV13(1 << 1),
+	})
+	// not amd device and drm connectors must be ignored.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/drm/card2/device/vendor": "0x10de\n",
+		"class/drm/card0-DP-1/status":   "disconnected\n",
+		"class/drm/renderD128/dev":      "226:128\n",
+	})
+	// both cards are linked by xgmi.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"class/kfd/kfd/topology/nodes/1/gpu_id":                "1000\n",
+		"class/kfd/kfd/topology/nodes/1/properties":            "location_id 768\ndomain 0\n",
+		"class/kfd/kfd/topology/nodes/1/io_links/0/properties": "type 11\nnode_from 1\nnode_to 2\n",
+		"class/kfd/kfd/topology/nodes/2/gpu_id":                "1001\n",
+		"class/kfd/kfd/topology/nodes/2/properties":            "location_id 33536\ndomain 0\n",
+		"class/kfd/kfd/topology/nodes/2/io_links/0/properties": "type 11\nnode_from 2\nnode_to 1\n",
+	})
+
+	return root
+}
+
+// gpuMetricsV13 returns a gpu_metrics v1.3 table with the given ASIC independent throttle status,
+// an energy accumulator of 1e6 units and an average gfx clock of 1700 MHz.
+func gpuMetricsV13(throttleStatus uint64) string {
+	table := make([]byte, 120)
+	binary.LittleEndian.PutUint16(table, uint16(len(table)))
+	table[2], table[3] = 1, 3
+	binary.LittleEndian.PutUint64(table[24:], 1e6)
+	binary.LittleEndian.PutUint64(table[32:], 1e12)
+	binary.LittleEndian.PutUint16(table[40:], 1700)
+	binary.LittleEndian.PutUint64(table[112:], throttleStatus)
+
+	return string(table)
+}
+
+// gpuMetricsV15 returns a gpu_metrics v1.5 table with the given pcie bandwidth in gigabytes per second
+// and pcie counters, XGMI links of 16 lanes at 32 Gb/s whose link N read N+1 MB and wrote N+2 MB,
+// and members not given unset.
+func gpuMetricsV15(bandwidth, replays uint64, naksSent, naksReceived uint32) string {
+	const numXGMILinks = 8
+
+	table := make([]byte, 360)
+	for i := range table {
+		table[i] = 0xff
+	}
+
+	binary.LittleEndian.PutUint16(table, uint16(len(table)))
+	table[2], table[3] = 1, 5
+	binary.LittleEndian.PutUint16(table[116:], 16)
+	binary.LittleEndian.PutUint16(table[118:], 32)
+	binary.LittleEndian.PutUint64(table[136:], bandwidth)
+	binary.LittleEndian.PutUint64(table[152:], replays)
+	binary.LittleEndian.PutUint32(table[168:], naksSent)
+	binary.LittleEndian.PutUint32(table[172:], naksReceived)
+
+	for link := range numXGMILinks {
+		binary.LittleEndian.PutUint64(table[176+link*8:], uint64(link+1)<<10)
+		binary.LittleEndian.PutUint64(table[240+link*8:], uint64(link+2)<<10)
+	}
+
+	return string(table)
+}
+
+// gpuMetricsV16 returns a gpu_metrics v1.6 table with the given XCD activity of each compute partition.
+func gpuMetricsV16(partitions [][]uint32) string {
+	const (
+		xcpStatsOffset = 312
+		xcpStatsSize   = 168
+		maxXCC         = 8
+	)
+
+	table := make([]byte, 1664)
+	binary.LittleEndian.PutUint16(table, uint16(len(table)))
+	table[2], table[3] = 1, 6
+	binary.LittleEndian.PutUint16(table[306:], uint16(len(partitions)))
+
+	for partition, activity := range partitions {
+		for xcd := range maxXCC {
+			value := uint32(0xffffffff)
+			if xcd < len(activity) {
+				value = activity[xcd]
+			}
+
+			binary.LittleEndian.PutUint32(table[xcpStatsOffset+partition*xcpStatsSize+xcd*4:], value)
+		}
+	}
+
+	return string(table)
+}
+
+// gpuMetricsV17 returns a gpu_metrics v1.7 table with XGMI links of 16 lanes at 32 Gb/s whose link N
+// read N+1 MB, wrote N+2 MB and has the given status, and members not given unset.
+func gpuMetricsV17(linkStatus []uint16) string {
+	const numXGMILinks = 8
+
+	table := make([]byte, 2208)
+	for i := range table {
+		table[i] = 0xff
+	}
+
+	binary.LittleEndian.PutUint16(table, uint16(len(table)))
+	table[2], table[3] = 1, 7
+	binary.LittleEndian.PutUint16(table[72:], 16)
+	binary.LittleEndian.PutUint16(table[74:], 32)
+
+	for link := range numXGMILinks {
+		binary.LittleEndian.PutUint64(table[136+link*8:], uint64(link+1)<<10)
+		binary.LittleEndian.PutUint64(table[200+link*8:], uint64(link+2)<<10)
+	}
+
+	for link, status := range linkStatus {
+		binary.LittleEndian.PutUint16(table[264+link*2:], status)
+	}
+
+	return string(table)
+}
@@ -0,0 +1,60 @@
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var errNoFileFound = errors.New("none of the files could be read")
+
+// readString reads given file returning its content without surrounding spaces.
+func readString(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return strings.TrimSpace(string(content)), nil
+}
+
+// readFloat reads given file containing a single decimal number.
+func readFloat(path string) (float64, error) {
+	content, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	value, err := strconv.ParseFloat(content, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return value, nil
+}
+
+// readFirstFloat reads the first file available from the given names within dir,
+// the error of the first file found is returned when none of them could be read.
+func readFirstFloat(dir string, names ...string) (float64, error) {
+	var foundErr error
+
+	for _, name := range names {
+		value, err := readFloat(filepath.Join(dir, name))
+		if err == nil {
+			return value, nil
+		}
+
+		if foundErr == nil && !errors.Is(err, os.ErrNotExist) {
+			foundErr = err
+		}
+	}
+
+	if foundErr != nil {
+		return 0, foundErr
+	}
+
+	return 0, fmt.Errorf("%w: %v: %w", errNoFileFound, names, os.ErrNotExist)
+}
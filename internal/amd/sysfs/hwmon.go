@@ -0,0 +1,128 @@
+package sysfs
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// amdgpu hwmon files.
+const (
+	fanInputFile    string = "fan1_input"
+	pwmFile         string = "pwm1"
+	pwmMaxFile      string = "pwm1_max"
+	inputSuffix     string = "_input"
+	labelSuffix     string = "_label"
+	critSuffix      string = "_crit"
+	emergencySuffix string = "_emergency"
+	// pwmMaxDefault is the maximum pwm value used when pwm1_max file is not available.
+	pwmMaxDefault float64 = 255
+)
+
+// amdgpu hwmon channel types.
+const (
+	temperatureChannel string = "temp"
+	voltageChannel     string = "in"
+)
+
+// amdgpu labels the hwmon channel of each temperature sensor and voltage rail, channels vary by
+// ASIC, e.g. MI300 series have no edge sensor and some GPUs report the memory rail as in2.
+var (
+	temperatureLabels = [gpus.NumTemperatureSensors]string{
+		gpus.TemperatureSensorEdge:     "edge",
+		gpus.TemperatureSensorJunction: "junction",
+		gpus.TemperatureSensorMemory:   "mem",
+	}
+	voltageLabels = [gpus.NumVoltageRails]string{
+		gpus.VoltageRailGFX:    "vddgfx",
+		gpus.VoltageRailSoC:    "vddnb",
+		gpus.VoltageRailMemory: "vddmem",
+	}
+)
+
+// channels used by drivers that do not label them, rails without channel are not provided.
+var (
+	temperatureChannels = [gpus.NumTemperatureSensors]string{
+		gpus.TemperatureSensorEdge:     "temp1",
+		gpus.TemperatureSensorJunction: "temp2",
+		gpus.TemperatureSensorMemory:   "temp3",
+	}
+	voltageChannels = [gpus.NumVoltageRails]string{
+		gpus.VoltageRailGFX: "in0",
+		gpus.VoltageRailSoC: "in1",
+	}
+)
+
+// readSensors reads temperatures, thresholds, fan speed and voltages of the given hwmon directory,
+// temperatures are given in millidegrees celsius and voltages in millivolts. The temperature of
+// the card is the edge temperature, or the junction temperature when there is no edge sensor.
+func readSensors(hwmonPath string, i int, stat *gpus.AMDParams) {
+	temperatures := findChannels(hwmonPath, temperatureChannel, temperatureLabels[:], temperatureChannels[:])
+	for sensor, channel := range temperatures {
+		if channel == "" {
+			continue
+		}
+
+		stat.GPUTemperatures[sensor][i] = newReading(readFloat(filepath.Join(hwmonPath, channel+inputSuffix)))
+		stat.GPUTemperatureCritical[sensor][i] = newReading(readFloat(filepath.Join(hwmonPath, channel+critSuffix)))
+		stat.GPUTemperatureEmergency[sensor][i] = newReading(readFloat(filepath.Join(hwmonPath, channel+emergencySuffix)))
+	}
+
+	stat.GPUTemperature[i] = stat.GPUTemperatures[gpus.TemperatureSensorEdge][i]
+	if !stat.GPUTemperature[i].Valid() && stat.GPUTemperatures[gpus.TemperatureSensorJunction][i].Valid() {
+		stat.GPUTemperature[i] = stat.GPUTemperatures[gpus.TemperatureSensorJunction][i]
+	}
+
+	for rail, channel := range findChannels(hwmonPath, voltageChannel, voltageLabels[:], voltageChannels[:]) {
+		if channel == "" {
+			continue
+		}
+
+		stat.GPUVoltage[rail][i] = newReading(readFloat(filepath.Join(hwmonPath, channel+inputSuffix)))
+	}
+
+	stat.GPUFanSpeed[i] = newReading(readFloat(filepath.Join(hwmonPath, fanInputFile)))
+	stat.GPUFanSpeedPercent[i] = newReading(readFanSpeedPercent(hwmonPath))
+}
+
+// findChannels returns the hwmon channel of the given type labelled with each of the given labels, e.g. temp2
+// for junction, an empty channel for labels not found. Given unlabelled channels are returned when the
+// directory has no label files for the type.
+func findChannels(hwmonPath, channelType string, labels, unlabelled []string) []string {
+	paths, err := filepath.Glob(filepath.Join(hwmonPath, channelType+"[0-9]*"+labelSuffix))
+	if err != nil || len(paths) == 0 {
+		return unlabelled
+	}
+
+	result := make([]string, len(labels))
+
+	for _, path := range paths {
+		label, err := readString(path)
+		if err != nil {
+			continue
+		}
+
+		if index := slices.Index(labels, label); index >= 0 {
+			result[index] = strings.TrimSuffix(filepath.Base(path), labelSuffix)
+		}
+	}
+
+	return result
+}
+
+// readFanSpeedPercent reads fan pwm duty cycle as a percent of its maximum value.
+func readFanSpeedPercent(hwmonPath string) (float64, error) {
+	pwm, err := readFloat(filepath.Join(hwmonPath, pwmFile))
+	if err != nil {
+		return 0, err
+	}
+
+	pwmMax, err := readFloat(filepath.Join(hwmonPath, pwmMaxFile))
+	if err != nil || pwmMax <= 0 {
+		pwmMax = pwmMaxDefault
+	}
+
+	return pwm / pwmMax * 100, nil
+}
@@ -0,0 +1,135 @@
+package sysfs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/gpumetrics"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// pci device files.
+const (
+	currentLinkSpeedFile string = "current_link_speed"
+	maxLinkSpeedFile     string = "max_link_speed"
+	currentLinkWidthFile string = "current_link_width"
+	maxLinkWidthFile     string = "max_link_width"
+	pcieReplayCountFile  string = "pcie_replay_count"
+	pcieBandwidthFile    string = "pcie_bw"
+	linkSpeedUnit        string = "GT/s"
+	pcieBandwidthFields  int    = 3
+)
+
+var (
+	errUnexpectedLinkSpeed     = errors.New("unexpected link speed")
+	errUnexpectedPCIeBandwidth = errors.New("unexpected pcie bandwidth")
+)
+
+// readPCIe reads pcie link state, replay count and bandwidth of the given card.
+func readPCIe(devicePath string, i int, stat *gpus.AMDParams) {
+	stat.GPUPCIeSpeed[i] = newReading(readLinkSpeed(filepath.Join(devicePath, currentLinkSpeedFile)))
+	stat.GPUPCIeMaxSpeed[i] = newReading(readLinkSpeed(filepath.Join(devicePath, maxLinkSpeedFile)))
+	stat.GPUPCIeWidth[i] = newReading(readFloat(filepath.Join(devicePath, currentLinkWidthFile)))
+	stat.GPUPCIeMaxWidth[i] = newReading(readFloat(filepath.Join(devicePath, maxLinkWidthFile)))
+	stat.GPUPCIeReplays[i] = newReading(readFloat(filepath.Join(devicePath, pcieReplayCountFile)))
+	stat.GPUPCIeBandwidth[i] = newReading(readPCIeBandwidth(filepath.Join(devicePath, pcieBandwidthFile)))
+}
+
+// ReadPCIe reads pcie readings of the card of the given pci device directory from pci device files
+// and its gpu_metrics table, it lets backends whose library does not provide them read them from sysfs.
+func ReadPCIe(devicePath string, i int, stat *gpus.AMDParams) {
+	readPCIe(devicePath, i, stat)
+
+	data, err := os.ReadFile(filepath.Join(devicePath, gpuMetricsFile))
+	if err != nil {
+		return
+	}
+
+	table, err := gpumetrics.Parse(data)
+	if err != nil {
+		return
+	}
+
+	setTablePCIe(table, i, stat)
+}
+
+// setTablePCIe sets the pcie readings provided by the given gpu_metrics table, they replace the readings of pci
+// device files since the firmware accumulates them and MI300 series do not provide pcie_bw nor NAK counters.
+func setTablePCIe(table *gpumetrics.Table, i int, stat *gpus.AMDParams) {
+	for _, reading := range []struct {
+		value  gpus.Reading
+		target []gpus.Reading
+	}{
+		{value: table.PCIeReplays, target: stat.GPUPCIeReplays},
+		{value: table.PCIeNAKsSent, target: stat.GPUPCIeNAKSent},
+		{value: table.PCIeNAKsReceived, target: stat.GPUPCIeNAKReceived},
+		{value: table.PCIeBandwidth, target: stat.GPUPCIeBandwidth},
+	} {
+		if reading.value.Valid() {
+			reading.target[i] = reading.value
+		}
+	}
+}
+
+// readLinkSpeed reads pci link speed files in GT/s.
+func readLinkSpeed(path string) (float64, error) {
+	content, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseLinkSpeed(content)
+}
+
+// parseLinkSpeed parses pci link speed files content, e.g. "16.0 GT/s PCIe", returning GT/s.
+func parseLinkSpeed(content string) (float64, error) {
+	fields := strings.Fields(content)
+	if len(fields) < 2 || fields[1] != linkSpeedUnit {
+		return 0, fmt.Errorf("%w: %q", errUnexpectedLinkSpeed, content)
+	}
+
+	speed, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse link speed %q: %w", content, err)
+	}
+
+	return speed, nil
+}
+
+// readPCIeBandwidth reads pcie_bw file in bytes per second, the driver takes a second to sample it.
+func readPCIeBandwidth(path string) (float64, error) {
+	content, err := readString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return parsePCIeBandwidth(content)
+}
+
+// parsePCIeBandwidth parses pcie_bw content, which contains the number of packets received
+// and sent during a second and the maximum payload size in bytes, e.g. "1024 2048 256".
+func parsePCIeBandwidth(content string) (float64, error) {
+	fields := strings.Fields(content)
+	if len(fields) != pcieBandwidthFields {
+		return 0, fmt.Errorf("%w: %q", errUnexpectedPCIeBandwidth, content)
+	}
+
+	values := make([]float64, len(fields))
+
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse pcie bandwidth %q: %w", content, err)
+		}
+
+		values[i] = value
+	}
+
+	received, sent, maxPayloadSize := values[0], values[1], values[2]
+
+	return (received + sent) * maxPayloadSize, nil
+}
@@ -0,0 +1,115 @@
+package sysfs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// amdgpu ras files.
+const (
+	rasFolderName    string = "ras"
+	errCountSuffix   string = "_err_count"
+	badPagesFile     string = "gpu_vram_bad_pages"
+	retiredPageFlag  string = "R"
+	uncorrectableKey string = "ue"
+	correctableKey   string = "ce"
+)
+
+// rasBlockFiles contains the prefix of ras error count files indexed by block.
+var rasBlockFiles = [gpus.NumRASBlocks]string{
+	gpus.RASBlockUMC:   "umc",
+	gpus.RASBlockGFX:   "gfx",
+	gpus.RASBlockSDMA:  "sdma",
+	gpus.RASBlockMMHUB: "mmhub",
+	gpus.RASBlockXGMI:  "xgmi_wafl",
+}
+
+var errErrorCountNotFound = errors.New("error count not found")
+
+// errorCounts contains the counts read from a ras error count file.
+type errorCounts struct {
+	correctable   gpus.Reading
+	uncorrectable gpus.Reading
+}
+
+// readRAS reads ecc error counts per block and retired pages of the given card.
+func readRAS(devicePath string, i int, stat *gpus.AMDParams) {
+	rasPath := filepath.Join(devicePath, rasFolderName)
+
+	for block := range gpus.NumRASBlocks {
+		counts := readErrorCounts(filepath.Join(rasPath, rasBlockFiles[block]+errCountSuffix))
+		stat.GPUECCCorrectable[block][i] = counts.correctable
+		stat.GPUECCUncorrectable[block][i] = counts.uncorrectable
+	}
+
+	stat.GPURetiredPages[i] = newReading(readRetiredPages(filepath.Join(rasPath, badPagesFile)))
+}
+
+// readErrorCounts reads a ras error count file.
+func readErrorCounts(path string) errorCounts {
+	content, err := readString(path)
+	if err != nil {
+		reading := newReading(0, err)
+
+		return errorCounts{correctable: reading, uncorrectable: reading}
+	}
+
+	return errorCounts{
+		correctable:   newReading(parseErrorCount(content, correctableKey)),
+		uncorrectable: newReading(parseErrorCount(content, uncorrectableKey)),
+	}
+}
+
+// parseErrorCount parses the count with the given key from ras error count files content,
+// e.g. "ue: 0\nce: 2\n".
+func parseErrorCount(content, key string) (float64, error) {
+	for _, line := range strings.Split(content, "\n") {
+		name, value, found := strings.Cut(line, ":")
+		if !found || strings.TrimSpace(name) != key {
+			continue
+		}
+
+		count, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return 0, fmt.Errorf("unable to parse %s error count: %w", key, err)
+		}
+
+		return count, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s", errErrorCountNotFound, key)
+}
+
+// readRetiredPages counts retired pages listed in gpu_vram_bad_pages file, each line contains
+// page address, size and status, e.g. "0x00000001 : 0x00001000 : R", pending pages are not counted.
+func readRetiredPages(path string) (float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var count float64
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Split(scanner.Text(), ":")
+		if strings.TrimSpace(fields[len(fields)-1]) == retiredPageFlag {
+			count++
+		}
+	}
+
+	err = scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return count, nil
+}
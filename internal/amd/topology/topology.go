@@ -0,0 +1,124 @@
+// Package topology reads the placement of logical CPUs within their core, core complex and socket
+// from sysfs, core complexes are identified by the L3 cache shared by their cores.
+package topology
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/discovery"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+)
+
+// sysfs files.
+const (
+	cpuPath     string = "devices/system/cpu"
+	packageFile string = "topology/physical_package_id"
+	coreFile    string = "topology/core_id"
+	cacheGlob   string = "cache/index*"
+	levelFile   string = "level"
+	idFile      string = "id"
+	l3Level     string = "3"
+)
+
+var cpuRegex = regexp.MustCompile(`^cpu([0-9]+)$`)
+
+// Reader sets the placement of the logical CPUs of the host.
+type Reader struct {
+	placements []gpus.CPUPlacement
+}
+
+// NewReader creates a reader of the CPUs found below the given sysfs root, placements are read once
+// since they do not change while CPUs are online. CPUs without topology files, e.g. offline
+// CPUs, or without L3 cache have an unknown placement.
+func NewReader(sysfsRoot string) *Reader {
+	if sysfsRoot == "" {
+		sysfsRoot = discovery.RootDefault
+	}
+
+	entries, err := os.ReadDir(filepath.Join(sysfsRoot, cpuPath))
+	if err != nil {
+		return &Reader{}
+	}
+
+	var newReader Reader
+
+	for _, entry := range entries {
+		match := cpuRegex.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		cpu, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+
+		if cpu >= len(newReader.placements) {
+			newReader.placements = append(newReader.placements, make([]gpus.CPUPlacement, cpu+1-len(newReader.placements))...)
+		}
+
+		newReader.placements[cpu] = readPlacement(filepath.Join(sysfsRoot, cpuPath, entry.Name()))
+	}
+
+	return &newReader
+}
+
+// readPlacement reads the placement of the CPU of the given sysfs directory.
+func readPlacement(dir string) gpus.CPUPlacement {
+	socket, err := readInt(filepath.Join(dir, packageFile))
+	if err != nil {
+		return gpus.CPUPlacement{}
+	}
+
+	core, err := readInt(filepath.Join(dir, coreFile))
+	if err != nil {
+		return gpus.CPUPlacement{}
+	}
+
+	caches, err := filepath.Glob(filepath.Join(dir, cacheGlob))
+	if err != nil {
+		return gpus.CPUPlacement{}
+	}
+
+	for _, cache := range caches {
+		level, err := os.ReadFile(filepath.Join(cache, levelFile))
+		if err != nil || strings.TrimSpace(string(level)) != l3Level {
+			continue
+		}
+
+		ccx, err := readInt(filepath.Join(cache, idFile))
+		if err != nil {
+			return gpus.CPUPlacement{}
+		}
+
+		return gpus.NewCPUPlacement(socket, core, ccx)
+	}
+
+	return gpus.CPUPlacement{}
+}
+
+// Read sets the placement of every logical CPU of the host.
+func (r *Reader) Read(stat *gpus.AMDParams) {
+	stat.CPUTopology = slices.Clone(r.placements)
+}
+
+// readInt reads the integer of the given sysfs file.
+func readInt(path string) (int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(string(content)))
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	return value, nil
+}
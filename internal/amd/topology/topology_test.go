@@ -0,0 +1,72 @@
+package topology_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/topology"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/sysfsfixtures"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	// Given
+	root := t.TempDir()
+
+	// cpu2 is offline so it has no topology, cpu3 is the SMT sibling of cpu0 and cpu4 runs on the second socket.
+	sysfsfixtures.WriteFiles(t, root, map[string]string{
+		"devices/system/cpu/cpu0/topology/physical_package_id": "0\n",
+		"devices/system/cpu/cpu0/topology/core_id":             "0\n",
+		"devices/system/cpu/cpu0/cache/index0/level":           "1\n",
+		"devices/system/cpu/cpu0/cache/index0/id":              "0\n",
+		"devices/system/cpu/cpu0/cache/index3/level":           "3\n",
+		"devices/system/cpu/cpu0/cache/index3/id":              "0\n",
+		"devices/system/cpu/cpu1/topology/physical_package_id": "0\n",
+		"devices/system/cpu/cpu1/topology/core_id":             "8\n",
+		"devices/system/cpu/cpu1/cache/index3/level":           "3\n",
+		"devices/system/cpu/cpu1/cache/index3/id":              "1\n",
+		"devices/system/cpu/cpu2/online":                       "0\n",
+		"devices/system/cpu/cpu3/topology/physical_package_id": "0\n",
+		"devices/system/cpu/cpu3/topology/core_id":             "0\n",
+		"devices/system/cpu/cpu3/cache/index3/level":           "3\n",
+		"devices/system/cpu/cpu3/cache/index3/id":              "0\n",
+		"devices/system/cpu/cpu4/topology/physical_package_id": "1\n",
+		"devices/system/cpu/cpu4/topology/core_id":             "0\n",
+		"devices/system/cpu/cpu4/cache/index3/level":           "3\n",
+		"devices/system/cpu/cpu4/cache/index3/id":              "16\n",
+		"devices/system/cpu/cpufreq/boost":                     "1\n",
+	})
+
+	reader := topology.NewReader(root)
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Equal(t, []gpus.CPUPlacement{
+		gpus.NewCPUPlacement(0, 0, 0),
+		gpus.NewCPUPlacement(0, 8, 1),
+		{},
+		gpus.NewCPUPlacement(0, 0, 0),
+		gpus.NewCPUPlacement(1, 0, 16),
+	}, got.CPUTopology)
+}
+
+func TestReadWithoutCPUs(t *testing.T) {
+	t.Parallel()
+	// Given
+	reader := topology.NewReader(t.TempDir())
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	reader.Read(&got)
+
+	// Then
+	assert.Empty(t, got.CPUTopology)
+}
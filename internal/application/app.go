@@ -8,11 +8,22 @@ import (
 	"syscall"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/amd"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/amdsmicli"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/cpustat"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/fake"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/hsmp"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/powercap"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/processes"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/replay"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/simulator"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/smilib"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/amd/sysfs"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/application/logs"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/application/settings"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/application/web"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/metrics"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/kubernetes"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -29,7 +40,10 @@ type Application struct {
 	webServer     *web.Server
 	k8sClient     *kubernetes.Client
 	exporter      *exporters.Exporter
-	gpuCards      [24]gpus.Card
+	gpuBackend    amd.Backend
+	cpuBackend    amd.Backend
+	recorder      *replay.Recorder
+	gpuCards      []gpus.Card
 
 	version    string
 	buildDate  string
@@ -71,6 +85,13 @@ func (a *Application) Run() error {
 
 	defer a.closeResources()
 
+	err = a.initializeBackends()
+	if err != nil {
+		a.logger.Error("initializing metric backends", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to start exporter: %w", err)
+	}
+
 	err = a.initializeGPUInformation()
 	if err != nil {
 		a.logger.Error("initializing gpu products information", slog.String("error", err.Error()))
@@ -78,7 +99,13 @@ func (a *Application) Run() error {
 		return fmt.Errorf("unable to start exporter: %w", err)
 	}
 
-	a.initializeExporter()
+	err = a.initializeExporter()
+	if err != nil {
+		a.logger.Error("initializing the metrics exporter", slog.String("error", err.Error()))
+
+		return fmt.Errorf("unable to start exporter: %w", err)
+	}
+
 	a.registryPrometheusExporter()
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
@@ -199,8 +226,93 @@ func (a *Application) initializeK8SConnection() error {
 	return nil
 }
 
+// newBackendRegistry returns a registry with all backends supported by this exporter.
+func newBackendRegistry() *amd.Registry {
+	registry := amd.NewRegistry()
+	registry.Register(smilib.BackendName, smilib.NewBackend)
+	registry.Register(sysfs.BackendName, sysfs.NewBackend)
+	registry.Register(powercap.BackendName, powercap.NewBackend)
+	registry.Register(amdsmicli.BackendName, amdsmicli.NewBackend)
+	registry.Register(fake.BackendName, fake.NewBackend)
+	registry.Register(replay.BackendName, replay.NewBackend)
+	registry.Register(simulator.BackendName, simulator.NewBackend)
+
+	return registry
+}
+
+// initializeBackends creates the backends configured to read GPU and CPU metrics.
+func (a *Application) initializeBackends() error {
+	registry := newBackendRegistry()
+
+	dimmAddresses, err := hsmp.ParseDIMMAddresses(a.configuration.DIMMAddresses)
+	if err != nil {
+		return fmt.Errorf("unable to initialize backends: %w", err)
+	}
+
+	backendSettings := amd.BackendSetup{
+		Logger:      a.logger,
+		SysfsRoot:   a.configuration.SysfsRoot,
+		ReplayFile:  a.configuration.ReplayFile,
+		ReplaySpeed: a.configuration.ReplaySpeed,
+		Simulator: amd.SimulatorSetup{
+			GPUs:             a.configuration.SimulatorGPUs,
+			GPUModel:         a.configuration.SimulatorGPUModel,
+			Sockets:          a.configuration.SimulatorSockets,
+			ThreadsPerSocket: a.configuration.SimulatorThreadsPerSocket,
+			Seed:             a.configuration.SimulatorSeed,
+		},
+		DIMMAddresses: dimmAddresses,
+		AMDSMITimeout: a.configuration.AMDSMITimeout,
+	}
+
+	a.logger.Info("initializing gpu metrics backend", slog.String("backend", a.configuration.Backend))
+
+	gpuBackend, err := registry.New(a.configuration.Backend, &backendSettings)
+	if err != nil {
+		return fmt.Errorf("unable to initialize gpu backend: %w", err)
+	}
+
+	a.gpuBackend = gpuBackend
+	a.cpuBackend = gpuBackend
+
+	if a.configuration.CPUBackend == "" || a.configuration.CPUBackend == a.configuration.Backend {
+		return nil
+	}
+
+	a.logger.Info("initializing cpu metrics backend", slog.String("backend", a.configuration.CPUBackend))
+
+	cpuBackend, err := registry.New(a.configuration.CPUBackend, &backendSettings)
+	if err != nil {
+		return fmt.Errorf("unable to initialize cpu backend: %w", err)
+	}
+
+	a.cpuBackend = cpuBackend
+
+	return nil
+}
+
+// cpuBackendName returns the name of the backend reading CPU metrics.
+func (a *Application) cpuBackendName() string {
+	if a.configuration.CPUBackend == "" {
+		return a.configuration.Backend
+	}
+
+	return a.configuration.CPUBackend
+}
+
+// readsHostDevices reports whether the given backend reads the devices of the host, readings of host
+// CPUs and GPU processes would be mixed up with the readings of backends generating or playing them.
+func readsHostDevices(backend string) bool {
+	switch backend {
+	case fake.BackendName, replay.BackendName, simulator.BackendName:
+		return false
+	default:
+		return true
+	}
+}
+
 func (a *Application) initializeGPUInformation() error {
-	gpuCards, err := amd.GetGpuProductNames()
+	gpuCards, err := a.gpuBackend.Devices()
 	if err != nil {
 		return fmt.Errorf("unable to get gpu products from environment: %w", err)
 	}
@@ -210,22 +322,83 @@ func (a *Application) initializeGPUInformation() error {
 	return nil
 }
 
-func (a *Application) initializeExporter() {
+func (a *Application) initializeExporter() error {
 	a.logger.Info("initializing the metrics exporter")
 
-	amdScanner := amd.NewScanner(a.logger)
+	scannerSettings := amd.ScannerSetup{
+		Logger:     a.logger,
+		GPUBackend: a.gpuBackend,
+		CPUBackend: a.cpuBackend,
+	}
+
+	if a.configuration.WithProcesses && a.configuration.WithKubernetes && readsHostDevices(a.configuration.Backend) {
+		scannerSettings.ProcessReader = processes.NewReader(&processes.Setup{
+			Logger:     a.logger,
+			SysfsRoot:  a.configuration.SysfsRoot,
+			ProcfsRoot: a.configuration.ProcfsRoot,
+			Cards:      a.gpuCards,
+		})
+	}
+
+	if a.configuration.WithCPUStats && readsHostDevices(a.cpuBackendName()) {
+		scannerSettings.CPUStatReader = cpustat.NewReader(&cpustat.Setup{
+			Logger:     a.logger,
+			SysfsRoot:  a.configuration.SysfsRoot,
+			ProcfsRoot: a.configuration.ProcfsRoot,
+		})
+	}
+
+	cpuAggregation, err := metrics.ParseCPUAggregation(a.configuration.CPUAggregation)
+	if err != nil {
+		return fmt.Errorf("unable to initialize exporter: %w", err)
+	}
+
+	amdScanner := amd.NewScanner(&scannerSettings)
+
+	getMetricsFunc, err := a.recordMetrics(amdScanner.Scan)
+	if err != nil {
+		return fmt.Errorf("unable to record metrics: %w", err)
+	}
+
 	settings := exporters.Setup{
-		K8SClient:      a.k8sClient,
-		CardsInfo:      a.gpuCards,
-		Logger:         a.logger,
-		OIPLabels:      a.configuration.PodLabels,
-		WithKubernetes: a.configuration.WithKubernetes,
-		GetMetricsFunc: func() gpus.AMDParams {
-			return amdScanner.Scan()
-		},
+		K8SClient:          a.k8sClient,
+		CardsInfo:          a.gpuCards,
+		Logger:             a.logger,
+		OIPLabels:          a.configuration.PodLabels,
+		WithKubernetes:     a.configuration.WithKubernetes,
+		GetMetricsFunc:     getMetricsFunc,
+		DeprecatedGPUPower: a.configuration.DeprecatedGPUPower,
+		CPUAggregation:     cpuAggregation,
+		GPUNUMALabels:      a.configuration.GPUNUMALabels,
 	}
 
 	a.exporter = exporters.NewExporter(&settings)
+
+	return nil
+}
+
+// recordMetrics wraps given handler to record every metrics snapshot when a record file is configured.
+func (a *Application) recordMetrics(handler gpus.AMDParamsHandler) (gpus.AMDParamsHandler, error) {
+	if a.configuration.RecordFile == "" {
+		return handler, nil
+	}
+
+	a.logger.Info("recording amd metrics", slog.String("file", a.configuration.RecordFile))
+
+	recorderSettings := replay.RecorderSetup{
+		Logger: a.logger,
+		Path:   a.configuration.RecordFile,
+		Cards:  a.gpuCards,
+	}
+
+	recorder, err := replay.NewRecorder(&recorderSettings)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create metrics recorder: %w", err)
+	}
+
+	a.recorder = recorder
+
+	return recorder.Wrap(handler), nil
 }
 
 func (a *Application) registryPrometheusExporter() {
@@ -241,4 +414,36 @@ func (a *Application) closeResources() {
 		a.logger.Info("closing kubernetes connection")
 		a.k8sClient.Close()
 	}
+
+	a.closeBackends()
+
+	if a.recorder != nil {
+		a.logger.Info("closing metrics recorder")
+
+		err := a.recorder.Close()
+		if err != nil {
+			a.logger.Error("closing metrics recorder", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// closeBackends releases resources held by metric backends.
+func (a *Application) closeBackends() {
+	if a.gpuBackend != nil {
+		a.logger.Info("closing gpu metrics backend")
+
+		err := a.gpuBackend.Close()
+		if err != nil {
+			a.logger.Error("closing gpu metrics backend", slog.String("error", err.Error()))
+		}
+	}
+
+	if a.cpuBackend != nil && a.cpuBackend != a.gpuBackend {
+		a.logger.Info("closing cpu metrics backend")
+
+		err := a.cpuBackend.Close()
+		if err != nil {
+			a.logger.Error("closing cpu metrics backend", slog.String("error", err.Error()))
+		}
+	}
 }
@@ -2,6 +2,7 @@ package settings
 
 import (
 	"fmt"
+	"time"
 
 	env "github.com/caarlos0/env/v11"
 )
@@ -33,6 +34,41 @@ type Configuration struct {
 	PodNamespace string `env:"AMD_EXPORTER_NAMESPACE"`
 	// Kubernetes pod labels to be added to exporter labels.
 	PodLabels []string `env:"AMD_EXPORTER_POD_LABELS"`
+	// Export the deprecated amd_gpu_power metric besides amd_gpu_power_watts.
+	DeprecatedGPUPower bool `env:"AMD_EXPORTER_DEPRECATED_GPU_POWER" envDefault:"true"`
+	// Read the utilization and frequency of each logical CPU of the host.
+	WithCPUStats bool `env:"AMD_EXPORTER_WITH_CPU_STATS" envDefault:"false"`
+	// Backend used to read AMD metrics, e.g. goamdsmi or fake.
+	Backend string `env:"AMD_EXPORTER_BACKEND" envDefault:"goamdsmi"`
+	// Backend used to read AMD CPU metrics, the GPU backend is used when it is empty.
+	CPUBackend string `env:"AMD_EXPORTER_CPU_BACKEND"`
+	// Directory where sysfs is mounted, it is used by backends reading amdgpu driver files.
+	SysfsRoot string `env:"AMD_EXPORTER_SYSFS_ROOT" envDefault:"/sys"`
+	// Directory where host procfs is mounted, it is used to attribute GPU processes to containers.
+	ProcfsRoot string `env:"AMD_EXPORTER_PROCFS_ROOT" envDefault:"/proc"`
+	// File where every metrics snapshot is recorded, recording is disabled when it is empty.
+	RecordFile string `env:"AMD_EXPORTER_RECORD_FILE"`
+	// Recording played by the replay backend.
+	ReplayFile string `env:"AMD_EXPORTER_REPLAY_FILE"`
+	// Pace used to play recordings, e.g. 2 plays twice as fast as recorded.
+	ReplaySpeed float64 `env:"AMD_EXPORTER_REPLAY_SPEED" envDefault:"1"`
+	// Fleet simulated by the simulator backend.
+	SimulatorGPUs             int    `env:"AMD_EXPORTER_SIMULATOR_GPUS" envDefault:"8"`
+	SimulatorGPUModel         string `env:"AMD_EXPORTER_SIMULATOR_GPU_MODEL" envDefault:"MI300X"`
+	SimulatorSockets          int    `env:"AMD_EXPORTER_SIMULATOR_SOCKETS" envDefault:"2"`
+	SimulatorThreadsPerSocket int    `env:"AMD_EXPORTER_SIMULATOR_THREADS_PER_SOCKET" envDefault:"192"`
+	// Seed used by the simulator backend, a random seed is used when it is zero.
+	SimulatorSeed uint64 `env:"AMD_EXPORTER_SIMULATOR_SEED"`
+	// Addresses of the DIMMs read through HSMP on every socket, e.g. 0x80,0x90. DIMMs are not read when it is empty.
+	DIMMAddresses []string `env:"AMD_EXPORTER_DIMM_ADDRESSES"`
+	// Level CPU thread readings are aggregated to, it could be thread, core, ccx or socket.
+	CPUAggregation string `env:"AMD_EXPORTER_CPU_AGGREGATION" envDefault:"thread"`
+	// Adds numa_node and socket labels to every GPU metric.
+	GPUNUMALabels bool `env:"AMD_EXPORTER_GPU_NUMA_LABELS" envDefault:"false"`
+	// Time the amd-smi commands of a reading may take before they are killed, it is used by the amdsmi backend.
+	AMDSMITimeout time.Duration `env:"AMD_EXPORTER_AMDSMI_TIMEOUT" envDefault:"10s"`
+	// Read the GPU processes of the host to export per-container GPU usage, it requires kubernetes.
+	WithProcesses bool `env:"AMD_EXPORTER_WITH_PROCESSES" envDefault:"false"`
 }
 
 func Load() (*Configuration, error) {
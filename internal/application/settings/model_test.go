@@ -3,6 +3,7 @@ package settings_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/application/settings"
 	"github.com/stretchr/testify/assert"
@@ -30,17 +31,74 @@ func TestLoad(t *testing.T) {
 	require.NoError(t, err)
 	err = os.Setenv("AMD_EXPORTER_POD_LABELS", "label_1,label_2,label_3")
 	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_DEPRECATED_GPU_POWER", "false")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_WITH_CPU_STATS", "true")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_BACKEND", "fake")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_CPU_BACKEND", "goamdsmi")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SYSFS_ROOT", "/host/sys")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_PROCFS_ROOT", "/host/proc")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_RECORD_FILE", "/tmp/record.jsonl")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_REPLAY_FILE", "/tmp/replay.jsonl")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_REPLAY_SPEED", "2.5")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SIMULATOR_GPUS", "4")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SIMULATOR_GPU_MODEL", "MI250X")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SIMULATOR_SOCKETS", "1")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SIMULATOR_THREADS_PER_SOCKET", "128")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_SIMULATOR_SEED", "42")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_DIMM_ADDRESSES", "0x80,0x90")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_CPU_AGGREGATION", "socket")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_GPU_NUMA_LABELS", "true")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_AMDSMI_TIMEOUT", "30s")
+	require.NoError(t, err)
+	err = os.Setenv("AMD_EXPORTER_WITH_PROCESSES", "true")
+	require.NoError(t, err)
 
 	want := &settings.Configuration{
-		LogLevel:          "development",
-		WebServerPort:     8080,
-		KubeletSocketPath: "/any/path",
-		AMDResourceNames:  []string{"custom1", "custom2", "custom3"},
-		NodeName:          "oi-wn-gpu-amd-01.test.oiai.corp",
-		PodName:           "amd-smi-exporter-v2-2",
-		PodNamespace:      "amdexporter-amdsmiexporter",
-		PodLabels:         []string{"label_1", "label_2", "label_3"},
-		WithKubernetes:    true,
+		LogLevel:                  "development",
+		WebServerPort:             8080,
+		KubeletSocketPath:         "/any/path",
+		AMDResourceNames:          []string{"custom1", "custom2", "custom3"},
+		NodeName:                  "oi-wn-gpu-amd-01.test.oiai.corp",
+		PodName:                   "amd-smi-exporter-v2-2",
+		PodNamespace:              "amdexporter-amdsmiexporter",
+		PodLabels:                 []string{"label_1", "label_2", "label_3"},
+		WithKubernetes:            true,
+		DeprecatedGPUPower:        false,
+		WithCPUStats:              true,
+		Backend:                   "fake",
+		CPUBackend:                "goamdsmi",
+		SysfsRoot:                 "/host/sys",
+		ProcfsRoot:                "/host/proc",
+		RecordFile:                "/tmp/record.jsonl",
+		ReplayFile:                "/tmp/replay.jsonl",
+		ReplaySpeed:               2.5,
+		SimulatorGPUs:             4,
+		SimulatorGPUModel:         "MI250X",
+		SimulatorSockets:          1,
+		SimulatorThreadsPerSocket: 128,
+		SimulatorSeed:             42,
+		DIMMAddresses:             []string{"0x80", "0x90"},
+		CPUAggregation:            "socket",
+		GPUNUMALabels:             true,
+		AMDSMITimeout:             30 * time.Second,
+		WithProcesses:             true,
 	}
 
 	// When
@@ -68,4 +126,40 @@ func TestLoad(t *testing.T) {
 	require.NoError(t, err)
 	err = os.Unsetenv("AMD_EXPORTER_POD_LABELS")
 	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_DEPRECATED_GPU_POWER")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_WITH_CPU_STATS")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_BACKEND")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_CPU_BACKEND")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SYSFS_ROOT")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_PROCFS_ROOT")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_RECORD_FILE")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_REPLAY_FILE")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_REPLAY_SPEED")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SIMULATOR_GPUS")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SIMULATOR_GPU_MODEL")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SIMULATOR_SOCKETS")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SIMULATOR_THREADS_PER_SOCKET")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_SIMULATOR_SEED")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_DIMM_ADDRESSES")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_CPU_AGGREGATION")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_AMDSMI_TIMEOUT")
+	require.NoError(t, err)
+	err = os.Unsetenv("AMD_EXPORTER_WITH_PROCESSES")
+	require.NoError(t, err)
 }
@@ -39,11 +39,49 @@ type Card struct {
 	CardSKU    string `json:"cardsku"`
 	PCIBus     string `json:"pcibus"`
 	CardGUID   string `json:"guid"`
+	UniqueID   string `json:"uniqueid"`
+	// VBIOSVersion is the VBIOS part number, e.g. 113-D67301-063.
+	VBIOSVersion string `json:"vbiosversion"`
+	// DriverVersion is the amdgpu driver version, it is empty for drivers built within the kernel.
+	DriverVersion string `json:"driverversion"`
+	// GFXVersion is the graphics target of the GPU, e.g. gfx90a.
+	GFXVersion string `json:"gfxversion"`
+	// Firmware versions of the SMU, compute micro engine, SDMA engine and PSP secure OS.
+	SMCFirmwareVersion  string `json:"smcfirmwareversion"`
+	MECFirmwareVersion  string `json:"mecfirmwareversion"`
+	SDMAFirmwareVersion string `json:"sdmafirmwareversion"`
+	PSPFirmwareVersion  string `json:"pspfirmwareversion"`
+	// ComputePartition and MemoryPartition are the partition modes of the physical GPU, e.g. CPX and NPS4,
+	// they are empty for GPUs that could not be partitioned.
+	ComputePartition string `json:"computepartition"`
+	MemoryPartition  string `json:"memorypartition"`
+	// PartitionID is the index of the compute partition within the physical GPU, 0 for whole GPUs.
+	PartitionID int `json:"partitionid"`
+	// PartitionDevice is the platform device of compute partitions other than the first one,
+	// e.g. amdgpu_xcp_3, the first partition is the pci device itself.
+	PartitionDevice string `json:"partitiondevice"`
+	// NUMANode is the NUMA node of the pci device and Socket the physical package of its local CPUs,
+	// they are empty if they are unknown.
+	NUMANode string `json:"numanode"`
+	Socket   string `json:"socket"`
+	// LocalCPUs is the list of CPUs local to the pci device, e.g. 0-23,48-71.
+	LocalCPUs string `json:"localcpus"`
+	// RootComplex is the pci domain and bus of the host bridge of the device, e.g. 0000:00.
+	RootComplex string `json:"rootcomplex"`
+}
+
+// DeviceID returns the id given to the card by the device plugin, the pci bus address of whole
+// GPUs and first partitions or the platform device of other partitions, empty if it is unknown.
+func (c *Card) DeviceID() string {
+	if c.PartitionID == 0 {
+		return c.PCIBus
+	}
+
+	return c.PartitionDevice
 }
 
 // amd constant values.
 const (
-	MaxNumGPUDevices               uint   = 24
 	GKEVirtualGPUDeviceIDSeparator string = "/vgpu"
 	AMDVirtualGPUDeviceIDSeparator string = "/mxgpu"
 	AMDResourceName                string = "amd.com/gpu"
@@ -0,0 +1,25 @@
+package gpus
+
+// ClockDomain is a GPU clock domain.
+type ClockDomain int
+
+// GPU clock domains.
+const (
+	ClockDomainGFX ClockDomain = iota
+	ClockDomainSoC
+	ClockDomainMemory
+	// NumClockDomains is the number of clock domains, it is not a clock domain.
+	NumClockDomains
+)
+
+// clockDomainNames contains clock domain names indexed by clock domain.
+var clockDomainNames = [NumClockDomains]string{"gfx", "soc", "memory"}
+
+// String returns the clock domain name used in metric labels, e.g. gfx.
+func (c ClockDomain) String() string {
+	if c < 0 || c >= NumClockDomains {
+		return "unknown"
+	}
+
+	return clockDomainNames[c]
+}
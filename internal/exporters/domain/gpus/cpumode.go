@@ -0,0 +1,29 @@
+package gpus
+
+// CPUMode is a mode in which a logical CPU spends its time, as accounted by the kernel.
+type CPUMode int
+
+// CPU modes.
+const (
+	CPUModeUser CPUMode = iota
+	CPUModeSystem
+	CPUModeIdle
+	// CPUModeIOWait is idle time while there were outstanding I/O requests.
+	CPUModeIOWait
+	// CPUModeSteal is time taken by the hypervisor to run other guests.
+	CPUModeSteal
+	// NumCPUModes is the number of modes, it is not a mode.
+	NumCPUModes
+)
+
+// cpuModeNames contains mode names indexed by mode.
+var cpuModeNames = [NumCPUModes]string{"user", "system", "idle", "iowait", "steal"}
+
+// String returns the mode name used in metric labels, e.g. user.
+func (m CPUMode) String() string {
+	if m < 0 || m >= NumCPUModes {
+		return "unknown"
+	}
+
+	return cpuModeNames[m]
+}
@@ -0,0 +1,30 @@
+package gpus
+
+// Engine is a GPU engine which busy time is accounted to DRM clients.
+type Engine int
+
+// GPU engines.
+const (
+	EngineGFX Engine = iota
+	EngineCompute
+	// EngineDMA is the SDMA copy engine.
+	EngineDMA
+	// EngineDecode and EngineEncode are the video engines, e.g. VCN.
+	EngineDecode
+	EngineEncode
+	EngineJPEG
+	// NumEngines is the number of engines, it is not an engine.
+	NumEngines
+)
+
+// engineNames contains engine names indexed by engine.
+var engineNames = [NumEngines]string{"gfx", "compute", "dma", "dec", "enc", "jpeg"}
+
+// String returns the engine name used in metric labels, e.g. gfx.
+func (e Engine) String() string {
+	if e < 0 || e >= NumEngines {
+		return "unknown"
+	}
+
+	return engineNames[e]
+}
@@ -1,59 +1,356 @@
 package gpus
 
+import "slices"
+
 // AMDParamsHandler defines function signature to return amd metrics data.
-type AMDParamsHandler func() AMDParams
+type AMDParamsHandler func() *AMDParams
 
-// AMDParams contains all metrics to be collected from environment.
+// AMDParams contains all metrics to be collected from environment. CPU readings are
+// indexed by thread or socket and GPU readings by card index, readings are sized by
+// ResizeCPUs and ResizeGPUs once backends know the number of devices, and they are
+// unsupported until backends set them.
 type AMDParams struct {
-	CoreEnergy     [768]float64
-	CoreBoost      [768]float64
-	SocketEnergy   [8]float64
-	SocketPower    [8]float64
-	PowerLimit     [8]float64
-	ProchotStatus  [8]float64
+	CoreEnergy     []Reading
+	CoreBoost      []Reading
+	SocketEnergy   []Reading
+	SocketPower    []Reading
+	PowerLimit     []Reading
+	ProchotStatus  []Reading
 	Sockets        uint
 	Threads        uint
 	ThreadsPerCore uint
 	NumGPUs        uint
-	GPUDevID       [MaxNumGPUDevices]float64
-	GPUDevPCIId    [MaxNumGPUDevices]float64
-	GPUPowerCap    [MaxNumGPUDevices]float64
-	GPUPower       [MaxNumGPUDevices]float64
-	GPUTemperature [MaxNumGPUDevices]float64
-	GPUSCLK        [MaxNumGPUDevices]float64
-	GPUMCLK        [MaxNumGPUDevices]float64
-	GPUUsage       [MaxNumGPUDevices]float64
-	GPUMemoryUsage [MaxNumGPUDevices]float64
-}
-
-// Init initializes amd metrics.
+	GPUDevID       []Reading
+	GPUDevPCIId    []Reading
+	GPUPowerCap    []Reading
+	GPUPower       []Reading
+	// GPUEnergy is the energy consumed by the GPU in microjoules, it only grows while
+	// the driver is loaded.
+	GPUEnergy      []Reading
+	GPUTemperature []Reading
+	GPUSCLK        []Reading
+	GPUMCLK        []Reading
+	GPUUsage       []Reading
+	GPUMemoryUsage []Reading
+	// GPUVRAMTotal, GPUVRAMUsed, GPUVisVRAMUsed and GPUGTTUsed are given in bytes.
+	GPUVRAMTotal   []Reading
+	GPUVRAMUsed    []Reading
+	GPUVisVRAMUsed []Reading
+	GPUGTTUsed     []Reading
+	// GPUECCCorrectable and GPUECCUncorrectable are indexed by RAS block and then by card index.
+	GPUECCCorrectable   [NumRASBlocks][]Reading
+	GPUECCUncorrectable [NumRASBlocks][]Reading
+	GPURetiredPages     []Reading
+	// GPUPCIeSpeed and GPUPCIeMaxSpeed are given in GT/s, widths in lanes and
+	// bandwidth in bytes per second.
+	GPUPCIeSpeed       []Reading
+	GPUPCIeMaxSpeed    []Reading
+	GPUPCIeWidth       []Reading
+	GPUPCIeMaxWidth    []Reading
+	GPUPCIeReplays     []Reading
+	GPUPCIeNAKSent     []Reading
+	GPUPCIeNAKReceived []Reading
+	GPUPCIeBandwidth   []Reading
+	// GPUTemperatures, GPUTemperatureCritical and GPUTemperatureEmergency are indexed by
+	// sensor and then by card index, they are given in millidegrees celsius.
+	GPUTemperatures         [NumTemperatureSensors][]Reading
+	GPUTemperatureCritical  [NumTemperatureSensors][]Reading
+	GPUTemperatureEmergency [NumTemperatureSensors][]Reading
+	// GPUFanSpeed is given in RPM and GPUFanSpeedPercent in percent of the maximum fan speed.
+	GPUFanSpeed        []Reading
+	GPUFanSpeedPercent []Reading
+	// GPUVoltage is indexed by rail and then by card index, it is given in millivolts.
+	GPUVoltage [NumVoltageRails][]Reading
+	// GPUThrottled is 1 while clocks are throttled and GPUThrottledSeconds is the time spent
+	// throttled, both are indexed by throttle reason and then by card index.
+	GPUThrottled        [NumThrottleReasons][]Reading
+	GPUThrottledSeconds [NumThrottleReasons][]Reading
+	// GPUXGMILinks contains the XGMI links of each GPU indexed by card index.
+	GPUXGMILinks [][]XGMILink
+	// GPUProcesses contains the processes using each GPU indexed by card index.
+	GPUProcesses [][]Process
+	// GPUContainers contains the usage of each GPU by container indexed by card index.
+	GPUContainers [][]ContainerUsage
+	// SocketTemperatures are indexed by sensor and then by socket, they are given in millidegrees celsius.
+	SocketTemperatures [NumSocketTemperatureSensors][]Reading
+	// CCDTemperatures contains the temperature of each CCD indexed by socket, they are given in millidegrees celsius.
+	CCDTemperatures [][]Reading
+	// CPUSeconds contains the time spent by each logical CPU in each mode, it is indexed by mode and
+	// then by logical CPU, which is the thread index of CoreEnergy. It is given in seconds.
+	CPUSeconds [NumCPUModes][]Reading
+	// CPUFrequency is the current frequency of each logical CPU in hertz.
+	CPUFrequency []Reading
+	// SocketDDRBandwidth and SocketDDRMaxBandwidth are given in bytes per second and
+	// SocketDDRUtilization in percent of the maximum bandwidth.
+	SocketDDRBandwidth    []Reading
+	SocketDDRMaxBandwidth []Reading
+	SocketDDRUtilization  []Reading
+	// SocketFCLK and SocketMCLK are the data fabric and memory clocks in hertz.
+	SocketFCLK []Reading
+	SocketMCLK []Reading
+	// SocketC0Residency is the percent of time the cores of the socket are active.
+	SocketC0Residency []Reading
+	// SocketFrequencyLimit is the current frequency limit of the cores in hertz and SocketFrequencyLimited
+	// is 1 while a source is limiting it, it is indexed by source and then by socket.
+	SocketFrequencyLimit   []Reading
+	SocketFrequencyLimited [NumFrequencyLimitSources][]Reading
+	// SocketSVIPower is the power of the SVI rails of the socket in milliwatts.
+	SocketSVIPower []Reading
+	// SocketDIMMs contains the DIMMs of each socket indexed by socket.
+	SocketDIMMs [][]DIMM
+	// CPUTopology contains the placement of each logical CPU, it is indexed as CPUSeconds and
+	// it is set by backends as a whole since it may cover more CPUs than threads.
+	CPUTopology []CPUPlacement
+	// GPUAverageClocks is indexed by clock domain and then by card index, it is the average
+	// frequency reported by the firmware in hertz.
+	GPUAverageClocks [NumClockDomains][]Reading
+	// GPUXGMIWidth is the width in lanes and GPUXGMISpeed the speed in Gb/s per lane of the XGMI links
+	// of the GPU, backends only report them for the GPU as a whole.
+	GPUXGMIWidth []Reading
+	GPUXGMISpeed []Reading
+}
+
+// Init initializes amd metrics without any device.
 func (amdParams *AMDParams) Init() {
-	amdParams.Sockets = 0
-	amdParams.Threads = 0
-	amdParams.ThreadsPerCore = 0
+	*amdParams = AMDParams{}
+}
+
+// ResizeCPUs sets the number of sockets and threads, readings of new devices are
+// unsupported and existing readings are kept.
+func (amdParams *AMDParams) ResizeCPUs(sockets, threads uint) {
+	amdParams.Sockets = sockets
+	amdParams.Threads = threads
+
+	amdParams.CoreEnergy = resize(amdParams.CoreEnergy, threads)
+	amdParams.CoreBoost = resize(amdParams.CoreBoost, threads)
+
+	amdParams.SocketEnergy = resize(amdParams.SocketEnergy, sockets)
+	amdParams.SocketPower = resize(amdParams.SocketPower, sockets)
+	amdParams.PowerLimit = resize(amdParams.PowerLimit, sockets)
+	amdParams.ProchotStatus = resize(amdParams.ProchotStatus, sockets)
+	amdParams.CCDTemperatures = resizeLists(amdParams.CCDTemperatures, sockets)
+	amdParams.SocketDDRBandwidth = resize(amdParams.SocketDDRBandwidth, sockets)
+	amdParams.SocketDDRMaxBandwidth = resize(amdParams.SocketDDRMaxBandwidth, sockets)
+	amdParams.SocketDDRUtilization = resize(amdParams.SocketDDRUtilization, sockets)
+	amdParams.SocketFCLK = resize(amdParams.SocketFCLK, sockets)
+	amdParams.SocketMCLK = resize(amdParams.SocketMCLK, sockets)
+	amdParams.SocketC0Residency = resize(amdParams.SocketC0Residency, sockets)
+	amdParams.SocketFrequencyLimit = resize(amdParams.SocketFrequencyLimit, sockets)
+	amdParams.SocketSVIPower = resize(amdParams.SocketSVIPower, sockets)
+	amdParams.SocketDIMMs = resizeLists(amdParams.SocketDIMMs, sockets)
+
+	for sensor := range NumSocketTemperatureSensors {
+		amdParams.SocketTemperatures[sensor] = resize(amdParams.SocketTemperatures[sensor], sockets)
+	}
+
+	for source := range NumFrequencyLimitSources {
+		amdParams.SocketFrequencyLimited[source] = resize(amdParams.SocketFrequencyLimited[source], sockets)
+	}
+}
+
+// ResizeCPUStats sets the number of logical CPUs of CPU time and frequency readings, which
+// may differ from the number of threads reported by the CPU backend, e.g. when CPUs are offline.
+func (amdParams *AMDParams) ResizeCPUStats(cpus uint) {
+	amdParams.CPUFrequency = resize(amdParams.CPUFrequency, cpus)
+
+	for mode := range NumCPUModes {
+		amdParams.CPUSeconds[mode] = resize(amdParams.CPUSeconds[mode], cpus)
+	}
+}
+
+// ResizeGPUs sets the number of GPUs, readings of new devices are unsupported and
+// existing readings are kept.
+func (amdParams *AMDParams) ResizeGPUs(numGPUs uint) {
+	amdParams.NumGPUs = numGPUs
+
+	amdParams.GPUDevID = resize(amdParams.GPUDevID, numGPUs)
+	amdParams.GPUDevPCIId = resize(amdParams.GPUDevPCIId, numGPUs)
+	amdParams.GPUPowerCap = resize(amdParams.GPUPowerCap, numGPUs)
+	amdParams.GPUPower = resize(amdParams.GPUPower, numGPUs)
+	amdParams.GPUEnergy = resize(amdParams.GPUEnergy, numGPUs)
+	amdParams.GPUTemperature = resize(amdParams.GPUTemperature, numGPUs)
+	amdParams.GPUSCLK = resize(amdParams.GPUSCLK, numGPUs)
+	amdParams.GPUMCLK = resize(amdParams.GPUMCLK, numGPUs)
+	amdParams.GPUUsage = resize(amdParams.GPUUsage, numGPUs)
+	amdParams.GPUMemoryUsage = resize(amdParams.GPUMemoryUsage, numGPUs)
+	amdParams.GPUVRAMTotal = resize(amdParams.GPUVRAMTotal, numGPUs)
+	amdParams.GPUVRAMUsed = resize(amdParams.GPUVRAMUsed, numGPUs)
+	amdParams.GPUVisVRAMUsed = resize(amdParams.GPUVisVRAMUsed, numGPUs)
+	amdParams.GPUGTTUsed = resize(amdParams.GPUGTTUsed, numGPUs)
+	amdParams.GPURetiredPages = resize(amdParams.GPURetiredPages, numGPUs)
+	amdParams.GPUPCIeSpeed = resize(amdParams.GPUPCIeSpeed, numGPUs)
+	amdParams.GPUPCIeMaxSpeed = resize(amdParams.GPUPCIeMaxSpeed, numGPUs)
+	amdParams.GPUPCIeWidth = resize(amdParams.GPUPCIeWidth, numGPUs)
+	amdParams.GPUPCIeMaxWidth = resize(amdParams.GPUPCIeMaxWidth, numGPUs)
+	amdParams.GPUPCIeReplays = resize(amdParams.GPUPCIeReplays, numGPUs)
+	amdParams.GPUPCIeNAKSent = resize(amdParams.GPUPCIeNAKSent, numGPUs)
+	amdParams.GPUPCIeNAKReceived = resize(amdParams.GPUPCIeNAKReceived, numGPUs)
+	amdParams.GPUPCIeBandwidth = resize(amdParams.GPUPCIeBandwidth, numGPUs)
+	amdParams.GPUFanSpeed = resize(amdParams.GPUFanSpeed, numGPUs)
+	amdParams.GPUFanSpeedPercent = resize(amdParams.GPUFanSpeedPercent, numGPUs)
+	amdParams.GPUXGMILinks = resizeLists(amdParams.GPUXGMILinks, numGPUs)
+	amdParams.GPUProcesses = resizeLists(amdParams.GPUProcesses, numGPUs)
+	amdParams.GPUContainers = resizeLists(amdParams.GPUContainers, numGPUs)
+	amdParams.GPUXGMIWidth = resize(amdParams.GPUXGMIWidth, numGPUs)
+	amdParams.GPUXGMISpeed = resize(amdParams.GPUXGMISpeed, numGPUs)
+
+	for block := range NumRASBlocks {
+		amdParams.GPUECCCorrectable[block] = resize(amdParams.GPUECCCorrectable[block], numGPUs)
+		amdParams.GPUECCUncorrectable[block] = resize(amdParams.GPUECCUncorrectable[block], numGPUs)
+	}
+
+	for sensor := range NumTemperatureSensors {
+		amdParams.GPUTemperatures[sensor] = resize(amdParams.GPUTemperatures[sensor], numGPUs)
+		amdParams.GPUTemperatureCritical[sensor] = resize(amdParams.GPUTemperatureCritical[sensor], numGPUs)
+		amdParams.GPUTemperatureEmergency[sensor] = resize(amdParams.GPUTemperatureEmergency[sensor], numGPUs)
+	}
 
-	amdParams.NumGPUs = 0
+	for rail := range NumVoltageRails {
+		amdParams.GPUVoltage[rail] = resize(amdParams.GPUVoltage[rail], numGPUs)
+	}
+
+	for reason := range NumThrottleReasons {
+		amdParams.GPUThrottled[reason] = resize(amdParams.GPUThrottled[reason], numGPUs)
+		amdParams.GPUThrottledSeconds[reason] = resize(amdParams.GPUThrottledSeconds[reason], numGPUs)
+	}
+
+	for clock := range NumClockDomains {
+		amdParams.GPUAverageClocks[clock] = resize(amdParams.GPUAverageClocks[clock], numGPUs)
+	}
+}
+
+// resize returns given readings with the given size, new readings are unsupported.
+func resize(readings []Reading, size uint) []Reading {
+	if uint(len(readings)) >= size {
+		return readings[:size]
+	}
+
+	result := make([]Reading, size)
+	copy(result, readings)
+
+	return result
+}
+
+// resizeLists returns given per device lists with the given number of devices, new devices have empty lists.
+func resizeLists[T any](lists [][]T, size uint) [][]T {
+	if uint(len(lists)) >= size {
+		return lists[:size]
+	}
+
+	result := make([][]T, size)
+	copy(result, lists)
+
+	return result
+}
+
+// cloneLists returns a deep copy of the given per device lists.
+func cloneLists[T any](lists [][]T) [][]T {
+	if lists == nil {
+		return nil
+	}
+
+	result := make([][]T, len(lists))
+
+	for i := range lists {
+		result[i] = slices.Clone(lists[i])
+	}
+
+	return result
+}
+
+// CopyCPUs copies CPU readings from given params.
+func (amdParams *AMDParams) CopyCPUs(source *AMDParams) {
+	amdParams.CoreEnergy = slices.Clone(source.CoreEnergy)
+	amdParams.CoreBoost = slices.Clone(source.CoreBoost)
+	amdParams.SocketEnergy = slices.Clone(source.SocketEnergy)
+	amdParams.SocketPower = slices.Clone(source.SocketPower)
+	amdParams.PowerLimit = slices.Clone(source.PowerLimit)
+	amdParams.ProchotStatus = slices.Clone(source.ProchotStatus)
+	amdParams.Sockets = source.Sockets
+	amdParams.Threads = source.Threads
+	amdParams.ThreadsPerCore = source.ThreadsPerCore
+	amdParams.CCDTemperatures = cloneLists(source.CCDTemperatures)
+
+	amdParams.CPUFrequency = slices.Clone(source.CPUFrequency)
+	amdParams.SocketDDRBandwidth = slices.Clone(source.SocketDDRBandwidth)
+	amdParams.SocketDDRMaxBandwidth = slices.Clone(source.SocketDDRMaxBandwidth)
+	amdParams.SocketDDRUtilization = slices.Clone(source.SocketDDRUtilization)
+	amdParams.SocketFCLK = slices.Clone(source.SocketFCLK)
+	amdParams.SocketMCLK = slices.Clone(source.SocketMCLK)
+	amdParams.SocketC0Residency = slices.Clone(source.SocketC0Residency)
+	amdParams.SocketFrequencyLimit = slices.Clone(source.SocketFrequencyLimit)
+	amdParams.SocketSVIPower = slices.Clone(source.SocketSVIPower)
+	amdParams.SocketDIMMs = cloneLists(source.SocketDIMMs)
+	amdParams.CPUTopology = slices.Clone(source.CPUTopology)
+
+	for sensor := range NumSocketTemperatureSensors {
+		amdParams.SocketTemperatures[sensor] = slices.Clone(source.SocketTemperatures[sensor])
+	}
+
+	for limit := range NumFrequencyLimitSources {
+		amdParams.SocketFrequencyLimited[limit] = slices.Clone(source.SocketFrequencyLimited[limit])
+	}
+
+	for mode := range NumCPUModes {
+		amdParams.CPUSeconds[mode] = slices.Clone(source.CPUSeconds[mode])
+	}
+}
+
+// CopyGPUs copies GPU readings from given params.
+func (amdParams *AMDParams) CopyGPUs(source *AMDParams) {
+	amdParams.NumGPUs = source.NumGPUs
+	amdParams.GPUDevID = slices.Clone(source.GPUDevID)
+	amdParams.GPUDevPCIId = slices.Clone(source.GPUDevPCIId)
+	amdParams.GPUPowerCap = slices.Clone(source.GPUPowerCap)
+	amdParams.GPUPower = slices.Clone(source.GPUPower)
+	amdParams.GPUEnergy = slices.Clone(source.GPUEnergy)
+	amdParams.GPUTemperature = slices.Clone(source.GPUTemperature)
+	amdParams.GPUSCLK = slices.Clone(source.GPUSCLK)
+	amdParams.GPUMCLK = slices.Clone(source.GPUMCLK)
+	amdParams.GPUUsage = slices.Clone(source.GPUUsage)
+	amdParams.GPUMemoryUsage = slices.Clone(source.GPUMemoryUsage)
+	amdParams.GPUVRAMTotal = slices.Clone(source.GPUVRAMTotal)
+	amdParams.GPUVRAMUsed = slices.Clone(source.GPUVRAMUsed)
+	amdParams.GPUVisVRAMUsed = slices.Clone(source.GPUVisVRAMUsed)
+	amdParams.GPUGTTUsed = slices.Clone(source.GPUGTTUsed)
+	amdParams.GPURetiredPages = slices.Clone(source.GPURetiredPages)
+	amdParams.GPUPCIeSpeed = slices.Clone(source.GPUPCIeSpeed)
+	amdParams.GPUPCIeMaxSpeed = slices.Clone(source.GPUPCIeMaxSpeed)
+	amdParams.GPUPCIeWidth = slices.Clone(source.GPUPCIeWidth)
+	amdParams.GPUPCIeMaxWidth = slices.Clone(source.GPUPCIeMaxWidth)
+	amdParams.GPUPCIeReplays = slices.Clone(source.GPUPCIeReplays)
+	amdParams.GPUPCIeNAKSent = slices.Clone(source.GPUPCIeNAKSent)
+	amdParams.GPUPCIeNAKReceived = slices.Clone(source.GPUPCIeNAKReceived)
+	amdParams.GPUPCIeBandwidth = slices.Clone(source.GPUPCIeBandwidth)
+	amdParams.GPUFanSpeed = slices.Clone(source.GPUFanSpeed)
+	amdParams.GPUFanSpeedPercent = slices.Clone(source.GPUFanSpeedPercent)
+	amdParams.GPUXGMILinks = cloneLists(source.GPUXGMILinks)
+	amdParams.GPUProcesses = cloneLists(source.GPUProcesses)
+	amdParams.GPUContainers = cloneLists(source.GPUContainers)
+	amdParams.GPUXGMIWidth = slices.Clone(source.GPUXGMIWidth)
+	amdParams.GPUXGMISpeed = slices.Clone(source.GPUXGMISpeed)
+
+	for block := range NumRASBlocks {
+		amdParams.GPUECCCorrectable[block] = slices.Clone(source.GPUECCCorrectable[block])
+		amdParams.GPUECCUncorrectable[block] = slices.Clone(source.GPUECCUncorrectable[block])
+	}
+
+	for sensor := range NumTemperatureSensors {
+		amdParams.GPUTemperatures[sensor] = slices.Clone(source.GPUTemperatures[sensor])
+		amdParams.GPUTemperatureCritical[sensor] = slices.Clone(source.GPUTemperatureCritical[sensor])
+		amdParams.GPUTemperatureEmergency[sensor] = slices.Clone(source.GPUTemperatureEmergency[sensor])
+	}
 
-	for socketLoopCounter := 0; socketLoopCounter < len(amdParams.SocketEnergy); socketLoopCounter++ {
-		amdParams.SocketEnergy[socketLoopCounter] = -1
-		amdParams.SocketPower[socketLoopCounter] = -1
-		amdParams.PowerLimit[socketLoopCounter] = -1
-		amdParams.ProchotStatus[socketLoopCounter] = -1
+	for rail := range NumVoltageRails {
+		amdParams.GPUVoltage[rail] = slices.Clone(source.GPUVoltage[rail])
 	}
 
-	for logicalCoreLoopCounter := 0; logicalCoreLoopCounter < len(amdParams.CoreEnergy); logicalCoreLoopCounter++ {
-		amdParams.CoreEnergy[logicalCoreLoopCounter] = -1
-		amdParams.CoreBoost[logicalCoreLoopCounter] = -1
+	for reason := range NumThrottleReasons {
+		amdParams.GPUThrottled[reason] = slices.Clone(source.GPUThrottled[reason])
+		amdParams.GPUThrottledSeconds[reason] = slices.Clone(source.GPUThrottledSeconds[reason])
 	}
 
-	for gpuLoopCounter := 0; gpuLoopCounter < len(amdParams.GPUDevID); gpuLoopCounter++ {
-		amdParams.GPUDevID[gpuLoopCounter] = -1
-		amdParams.GPUPowerCap[gpuLoopCounter] = -1
-		amdParams.GPUPower[gpuLoopCounter] = -1
-		amdParams.GPUTemperature[gpuLoopCounter] = -1
-		amdParams.GPUSCLK[gpuLoopCounter] = -1
-		amdParams.GPUMCLK[gpuLoopCounter] = -1
-		amdParams.GPUUsage[gpuLoopCounter] = -1
-		amdParams.GPUMemoryUsage[gpuLoopCounter] = -1
+	for clock := range NumClockDomains {
+		amdParams.GPUAverageClocks[clock] = slices.Clone(source.GPUAverageClocks[clock])
 	}
 }
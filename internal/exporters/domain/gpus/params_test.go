@@ -0,0 +1,65 @@
+package gpus_test
+
+import (
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResizeCPUs(t *testing.T) {
+	t.Parallel()
+	// Given
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	got.ResizeCPUs(16, 1024)
+
+	// Then
+	assert.Equal(t, uint(16), got.Sockets)
+	assert.Equal(t, uint(1024), got.Threads)
+	assert.Len(t, got.CoreEnergy, 1024)
+	assert.Len(t, got.CoreBoost, 1024)
+	assert.Len(t, got.SocketPower, 16)
+	assert.Equal(t, gpus.Reading{}, got.CoreEnergy[1023])
+	assert.Equal(t, gpus.Reading{}, got.ProchotStatus[15])
+}
+
+func TestResizeGPUsKeepsReadings(t *testing.T) {
+	t.Parallel()
+	// Given
+	var got gpus.AMDParams
+	got.Init()
+	got.ResizeGPUs(2)
+	got.GPUPower[1] = gpus.NewReading(612e6)
+
+	// When
+	got.ResizeGPUs(64)
+
+	// Then
+	assert.Equal(t, uint(64), got.NumGPUs)
+	assert.Len(t, got.GPUPower, 64)
+	assert.Equal(t, gpus.NewReading(612e6), got.GPUPower[1])
+	assert.Equal(t, gpus.Reading{}, got.GPUPower[63])
+	assert.Equal(t, gpus.Reading{}, got.GPUTemperature[0])
+}
+
+func TestCopyGPUsClonesXGMILinks(t *testing.T) {
+	t.Parallel()
+	// Given
+	var source gpus.AMDParams
+	source.Init()
+	source.ResizeGPUs(2)
+	source.GPUXGMILinks[0] = []gpus.XGMILink{gpus.NewXGMILink(1)}
+
+	var got gpus.AMDParams
+	got.Init()
+
+	// When
+	got.CopyGPUs(&source)
+	source.GPUXGMILinks[0][0].Status = gpus.NewReading(1)
+
+	// Then
+	assert.Equal(t, [][]gpus.XGMILink{{gpus.NewXGMILink(1)}, nil}, got.GPUXGMILinks)
+}
@@ -0,0 +1,21 @@
+package gpus
+
+// Process contains the readings of a process using a GPU.
+type Process struct {
+	PID int
+	// ContainerID is the container runtime id of the container running the process,
+	// it is empty for processes running outside containers.
+	ContainerID string
+	// VRAMUsed is given in bytes.
+	VRAMUsed Reading
+}
+
+// ContainerUsage contains the usage of a GPU by the processes of a container.
+type ContainerUsage struct {
+	// ContainerID is empty for processes running outside containers.
+	ContainerID string
+	// EngineSeconds contains the busy time of each engine accounted to the DRM clients of the container
+	// in seconds, it is a counter which keeps the time of processes that exited while the container uses
+	// the GPU. Compute queues mapped through KFD are not accounted to DRM clients.
+	EngineSeconds [NumEngines]Reading
+}
@@ -0,0 +1,27 @@
+package gpus
+
+// RASBlock is a GPU block reporting RAS (reliability, availability and serviceability) error counts.
+type RASBlock int
+
+// GPU blocks reporting ECC error counts.
+const (
+	RASBlockUMC RASBlock = iota
+	RASBlockGFX
+	RASBlockSDMA
+	RASBlockMMHUB
+	RASBlockXGMI
+	// NumRASBlocks is the number of blocks, it is not a block.
+	NumRASBlocks
+)
+
+// rasBlockNames contains block names indexed by block.
+var rasBlockNames = [NumRASBlocks]string{"umc", "gfx", "sdma", "mmhub", "xgmi"}
+
+// String returns the block name used in metric labels, e.g. umc.
+func (b RASBlock) String() string {
+	if b < 0 || b >= NumRASBlocks {
+		return "unknown"
+	}
+
+	return rasBlockNames[b]
+}
@@ -0,0 +1,90 @@
+package gpus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ReadingState indicates whether a reading contains a value.
+type ReadingState uint8
+
+// reading states.
+const (
+	// ReadingUnsupported is the state of readings not provided by the device or backend.
+	ReadingUnsupported ReadingState = iota
+	// ReadingValid is the state of readings containing a value.
+	ReadingValid
+	// ReadingFailed is the state of readings that could not be taken.
+	ReadingFailed
+)
+
+// failedReadingJSON is the json value of failed readings, unsupported readings are null.
+const failedReadingJSON string = `"failed"`
+
+// Reading is a value read from a device along with its state, the zero
+// value is an unsupported reading.
+type Reading struct {
+	Value float64
+	State ReadingState
+}
+
+// NewReading returns a valid reading with the given value.
+func NewReading(value float64) Reading {
+	return Reading{Value: value, State: ReadingValid}
+}
+
+// FailedReading returns a reading that could not be taken.
+func FailedReading() Reading {
+	return Reading{State: ReadingFailed}
+}
+
+// Valid returns true if the reading contains a value.
+func (r Reading) Valid() bool {
+	return r.State == ReadingValid
+}
+
+// Failed returns true if the reading could not be taken.
+func (r Reading) Failed() bool {
+	return r.State == ReadingFailed
+}
+
+// MarshalJSON encodes valid readings as numbers, failed readings as "failed"
+// and unsupported readings as null.
+func (r Reading) MarshalJSON() ([]byte, error) {
+	switch r.State {
+	case ReadingValid:
+		return json.Marshal(r.Value)
+	case ReadingFailed:
+		return []byte(failedReadingJSON), nil
+	default:
+		return []byte("null"), nil
+	}
+}
+
+// UnmarshalJSON decodes readings encoded by MarshalJSON.
+func (r *Reading) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+
+	switch string(data) {
+	case "null":
+		*r = Reading{}
+
+		return nil
+	case failedReadingJSON:
+		*r = FailedReading()
+
+		return nil
+	}
+
+	var value float64
+
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("decoding reading: %w", err)
+	}
+
+	*r = NewReading(value)
+
+	return nil
+}
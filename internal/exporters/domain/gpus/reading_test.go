@@ -0,0 +1,53 @@
+package gpus_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadingJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		reading gpus.Reading
+		want    string
+	}{
+		"valid reading":       {reading: gpus.NewReading(612e6), want: `612000000`},
+		"zero reading":        {reading: gpus.NewReading(0), want: `0`},
+		"failed reading":      {reading: gpus.FailedReading(), want: `"failed"`},
+		"unsupported reading": {reading: gpus.Reading{}, want: `null`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// When
+			data, err := json.Marshal(tt.reading)
+			require.NoError(t, err)
+
+			var got gpus.Reading
+			err = json.Unmarshal(data, &got)
+
+			// Then
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(data))
+			assert.Equal(t, tt.reading, got)
+		})
+	}
+}
+
+func TestReadingJSONInvalidValue(t *testing.T) {
+	t.Parallel()
+	// Given
+	var got gpus.Reading
+
+	// When
+	err := json.Unmarshal([]byte(`"N/A"`), &got)
+
+	// Then
+	require.Error(t, err)
+}
@@ -0,0 +1,77 @@
+package gpus
+
+// TemperatureSensor is a GPU temperature sensor.
+type TemperatureSensor int
+
+// GPU temperature sensors.
+const (
+	TemperatureSensorEdge TemperatureSensor = iota
+	// TemperatureSensorJunction is the hottest spot of the die, also known as hotspot.
+	TemperatureSensorJunction
+	// TemperatureSensorMemory is the HBM or VRAM temperature.
+	TemperatureSensorMemory
+	// NumTemperatureSensors is the number of sensors, it is not a sensor.
+	NumTemperatureSensors
+)
+
+// temperatureSensorNames contains sensor names indexed by sensor.
+var temperatureSensorNames = [NumTemperatureSensors]string{"edge", "junction", "memory"}
+
+// String returns the sensor name used in metric labels, e.g. edge.
+func (s TemperatureSensor) String() string {
+	if s < 0 || s >= NumTemperatureSensors {
+		return "unknown"
+	}
+
+	return temperatureSensorNames[s]
+}
+
+// SocketTemperatureSensor is a CPU socket temperature sensor.
+type SocketTemperatureSensor int
+
+// CPU socket temperature sensors.
+const (
+	// SocketTemperatureTctl is the control temperature used by cooling and throttling
+	// policies, some processors report it with an offset above the die temperature.
+	SocketTemperatureTctl SocketTemperatureSensor = iota
+	// SocketTemperatureTdie is the die temperature, it is only reported when Tctl has an offset.
+	SocketTemperatureTdie
+	// NumSocketTemperatureSensors is the number of sensors, it is not a sensor.
+	NumSocketTemperatureSensors
+)
+
+// socketTemperatureSensorNames contains sensor names indexed by sensor.
+var socketTemperatureSensorNames = [NumSocketTemperatureSensors]string{"tctl", "tdie"}
+
+// String returns the sensor name used in metric labels, e.g. tctl.
+func (s SocketTemperatureSensor) String() string {
+	if s < 0 || s >= NumSocketTemperatureSensors {
+		return "unknown"
+	}
+
+	return socketTemperatureSensorNames[s]
+}
+
+// VoltageRail is a GPU voltage rail.
+type VoltageRail int
+
+// GPU voltage rails.
+const (
+	VoltageRailGFX VoltageRail = iota
+	VoltageRailSoC
+	VoltageRailMemory
+	// NumVoltageRails is the number of rails, it is not a rail.
+	NumVoltageRails
+)
+
+// voltageRailNames contains rail names indexed by rail.
+var voltageRailNames = [NumVoltageRails]string{"gfx", "soc", "memory"}
+
+// String returns the rail name used in metric labels, e.g. gfx.
+func (r VoltageRail) String() string {
+	if r < 0 || r >= NumVoltageRails {
+		return "unknown"
+	}
+
+	return voltageRailNames[r]
+}
@@ -0,0 +1,53 @@
+package gpus
+
+// FrequencyLimitSource is a reason limiting the frequency of the cores of a CPU socket.
+type FrequencyLimitSource int
+
+// CPU socket frequency limit sources.
+const (
+	// FrequencyLimitCHTC is the thermal control limit of the socket.
+	FrequencyLimitCHTC FrequencyLimitSource = iota
+	// FrequencyLimitProchot is the PROCHOT signal asserted by the platform.
+	FrequencyLimitProchot
+	// FrequencyLimitTDC is the thermal design current limit.
+	FrequencyLimitTDC
+	// FrequencyLimitPPT is the package power tracking limit.
+	FrequencyLimitPPT
+	// FrequencyLimitOPNMax is the maximum frequency of the processor model.
+	FrequencyLimitOPNMax
+	// FrequencyLimitReliability is the reliability limit of the processor.
+	FrequencyLimitReliability
+	// FrequencyLimitAPML and FrequencyLimitHSMP are limits set through the BMC and the host respectively.
+	FrequencyLimitAPML
+	FrequencyLimitHSMP
+	// NumFrequencyLimitSources is the number of sources, it is not a source.
+	NumFrequencyLimitSources
+)
+
+// frequencyLimitSourceNames contains source names indexed by source.
+var frequencyLimitSourceNames = [NumFrequencyLimitSources]string{
+	"chtc", "prochot", "tdc", "ppt", "opn_max", "reliability", "apml", "hsmp",
+}
+
+// String returns the source name used in metric labels, e.g. ppt.
+func (s FrequencyLimitSource) String() string {
+	if s < 0 || s >= NumFrequencyLimitSources {
+		return "unknown"
+	}
+
+	return frequencyLimitSourceNames[s]
+}
+
+// DIMM contains readings of a DIMM reported by the SMU of its socket.
+type DIMM struct {
+	// Address identifies the DIMM within its socket, e.g. 0x80.
+	Address uint8
+	// Power is given in milliwatts and Temperature in millidegrees celsius.
+	Power       Reading
+	Temperature Reading
+}
+
+// NewDIMM returns the DIMM with the given address with unsupported readings.
+func NewDIMM(address uint8) DIMM {
+	return DIMM{Address: address}
+}
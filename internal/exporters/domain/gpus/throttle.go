@@ -0,0 +1,30 @@
+package gpus
+
+// ThrottleReason is a reason of GPU clocks being throttled by the power management firmware.
+type ThrottleReason int
+
+// GPU throttle reasons.
+const (
+	// ThrottleReasonThermal is a temperature limit of the die, voltage regulators or memory.
+	ThrottleReasonThermal ThrottleReason = iota
+	// ThrottleReasonPower is a package power limit, e.g. PPT.
+	ThrottleReasonPower
+	// ThrottleReasonProchot is the PROCHOT signal asserted by the platform.
+	ThrottleReasonProchot
+	// ThrottleReasonCurrent is a current limit of a voltage rail, e.g. TDC or EDC.
+	ThrottleReasonCurrent
+	// NumThrottleReasons is the number of reasons, it is not a reason.
+	NumThrottleReasons
+)
+
+// throttleReasonNames contains reason names indexed by reason.
+var throttleReasonNames = [NumThrottleReasons]string{"thermal", "power", "prochot", "current"}
+
+// String returns the reason name used in metric labels, e.g. thermal.
+func (r ThrottleReason) String() string {
+	if r < 0 || r >= NumThrottleReasons {
+		return "unknown"
+	}
+
+	return throttleReasonNames[r]
+}
@@ -0,0 +1,18 @@
+package gpus
+
+// CPUPlacement locates a logical CPU within its socket.
+type CPUPlacement struct {
+	// Known is false when the placement of the CPU could not be read, e.g. offline CPUs.
+	Known bool
+	// Socket is the physical package of the CPU.
+	Socket int
+	// Core identifies the core of the CPU within its socket, SMT siblings share it.
+	Core int
+	// CCX identifies the core complex of the CPU, whose cores share their L3 cache.
+	CCX int
+}
+
+// NewCPUPlacement returns the known placement of a logical CPU.
+func NewCPUPlacement(socket, core, ccx int) CPUPlacement {
+	return CPUPlacement{Known: true, Socket: socket, Core: core, CCX: ccx}
+}
@@ -0,0 +1,18 @@
+package gpus
+
+// XGMILink contains readings of an XGMI (Infinity Fabric) link from a GPU to one of its peers.
+type XGMILink struct {
+	// Peer is the card index of the GPU at the other end of the link.
+	Peer int
+	// Status is 1 when the link is up and 0 when it is down, it is only provided by backends
+	// reading the state of each link.
+	Status Reading
+	// ReadBytes and WriteBytes are the data transferred through the link since the driver was loaded.
+	ReadBytes  Reading
+	WriteBytes Reading
+}
+
+// NewXGMILink returns a link to the given peer with unsupported readings.
+func NewXGMILink(peer int) XGMILink {
+	return XGMILink{Peer: peer}
+}
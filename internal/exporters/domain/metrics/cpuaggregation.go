@@ -0,0 +1,241 @@
+package metrics
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CPUAggregation is the level thread readings of CPU metrics are aggregated to.
+type CPUAggregation string
+
+// CPU aggregation levels.
+const (
+	// CPUAggregationThread exports a series per logical CPU.
+	CPUAggregationThread CPUAggregation = "thread"
+	// CPUAggregationCore exports a series per core, SMT siblings are combined.
+	CPUAggregationCore CPUAggregation = "core"
+	// CPUAggregationCCX exports a series per core complex, cores sharing an L3 cache are combined.
+	CPUAggregationCCX CPUAggregation = "ccx"
+	// CPUAggregationSocket exports a series per socket.
+	CPUAggregationSocket CPUAggregation = "socket"
+)
+
+var errUnknownCPUAggregation = errors.New("unknown cpu aggregation level")
+
+// ParseCPUAggregation returns the aggregation level with the given name, thread readings are exported
+// as they are when it is empty, which is the default level.
+func ParseCPUAggregation(value string) (CPUAggregation, error) {
+	switch level := CPUAggregation(value); level {
+	case "", CPUAggregationThread:
+		return CPUAggregationThread, nil
+	case CPUAggregationCore, CPUAggregationCCX, CPUAggregationSocket:
+		return level, nil
+	default:
+		return "", fmt.Errorf("%w: %s", errUnknownCPUAggregation, value)
+	}
+}
+
+// aggregated reports whether thread readings are combined, the empty level exports thread readings.
+func (c CPUAggregation) aggregated() bool {
+	return c == CPUAggregationCore || c == CPUAggregationCCX || c == CPUAggregationSocket
+}
+
+// labels returns the labels identifying a series of the level.
+func (c CPUAggregation) labels() []string {
+	switch c {
+	case CPUAggregationCore:
+		return []string{"socket", coreLabel}
+	case CPUAggregationCCX:
+		return []string{"socket", ccxLabel}
+	case CPUAggregationSocket:
+		return []string{"socket"}
+	default:
+		return []string{"thread"}
+	}
+}
+
+// cpuGroup identifies the logical CPUs combined into a series, index is the core or the CCX of the socket.
+type cpuGroup struct {
+	socket int
+	index  int
+}
+
+// group returns the group of the CPU with the given placement.
+func (c CPUAggregation) group(placement *gpus.CPUPlacement) cpuGroup {
+	switch c {
+	case CPUAggregationCore:
+		return cpuGroup{socket: placement.Socket, index: placement.Core}
+	case CPUAggregationCCX:
+		return cpuGroup{socket: placement.Socket, index: placement.CCX}
+	default:
+		return cpuGroup{socket: placement.Socket}
+	}
+}
+
+// labelValues returns the label values of the given group.
+func (c CPUAggregation) labelValues(group cpuGroup) []string {
+	if c == CPUAggregationSocket {
+		return []string{strconv.Itoa(group.socket)}
+	}
+
+	return []string{strconv.Itoa(group.socket), strconv.Itoa(group.index)}
+}
+
+// device returns the device of the given group used to count failures, e.g. socket0_core3.
+func (c CPUAggregation) device(group cpuGroup) string {
+	device := socketIDPrefix + strconv.Itoa(group.socket)
+	if c == CPUAggregationSocket {
+		return device
+	}
+
+	return device + "_" + string(c) + strconv.Itoa(group.index)
+}
+
+// cpuCombiner combines the readings of the logical CPUs of a group, readings are indexed by core.
+type cpuCombiner func(cores map[int][]float64) float64
+
+// sumCores sums the readings of the cores of a group, SMT siblings report the reading of their core.
+func sumCores(cores map[int][]float64) float64 {
+	var result float64
+
+	for _, core := range slices.Sorted(maps.Keys(cores)) {
+		result += slices.Max(cores[core])
+	}
+
+	return result
+}
+
+// sumThreads sums the readings of the logical CPUs of a group.
+func sumThreads(cores map[int][]float64) float64 {
+	var result float64
+
+	for _, core := range slices.Sorted(maps.Keys(cores)) {
+		for _, value := range cores[core] {
+			result += value
+		}
+	}
+
+	return result
+}
+
+// minThreads returns the lowest reading of the logical CPUs of a group.
+func minThreads(cores map[int][]float64) float64 {
+	result := math.Inf(1)
+
+	for _, values := range cores {
+		result = min(result, slices.Min(values))
+	}
+
+	return result
+}
+
+// meanThreads returns the average reading of the logical CPUs of a group.
+func meanThreads(cores map[int][]float64) float64 {
+	var threads int
+
+	for _, values := range cores {
+		threads += len(values)
+	}
+
+	return sumThreads(cores) / float64(threads)
+}
+
+// buildCPUMetrics builds prometheus metric based on given readings of logical CPUs aggregated to the
+// configured level by the given combiner, label values are added after the labels of the level.
+// At levels other than thread, CPUs whose placement is unknown are omitted and groups with a failed
+// reading are omitted and counted as failed, since sums missing CPUs would look like counter resets.
+// Readings are exported per thread when the placement of every CPU is unknown, e.g. for recordings
+// without topology or backends that could not read it, so they are not dropped.
+func (a *AMDMetrics) buildCPUMetrics(
+	data []gpus.Reading,
+	topology []gpus.CPUPlacement,
+	metric *CustomMetric,
+	combine cpuCombiner,
+	labelValues ...string,
+) []prometheus.Metric {
+	if !a.cpuAggregation.aggregated() {
+		return a.buildMetrics(data, metric, threadIDPrefix, labelValues...)
+	}
+
+	if len(data) > 0 && !topologyKnown(topology) {
+		a.threadFallbackOnce.Do(func() {
+			a.logger.Warn(
+				"cpu topology is not available, cpu metrics are exported per thread",
+				slog.String("aggregation", string(a.cpuAggregation)),
+			)
+		})
+
+		return a.buildMetrics(data, a.threadMetric(metric), threadIDPrefix, labelValues...)
+	}
+
+	groups := make(map[cpuGroup]map[int][]float64)
+	failed := make(map[cpuGroup]bool)
+
+	for i := range min(len(data), len(topology)) {
+		placement := &topology[i]
+		if !placement.Known {
+			continue
+		}
+
+		group := a.cpuAggregation.group(placement)
+
+		switch {
+		case data[i].Failed():
+			failed[group] = true
+		case data[i].Valid():
+			if groups[group] == nil {
+				groups[group] = make(map[int][]float64)
+			}
+
+			groups[group][placement.Core] = append(groups[group][placement.Core], data[i].Value)
+		}
+	}
+
+	var metrics []prometheus.Metric
+
+	for _, group := range slices.SortedFunc(maps.Keys(groups), compareCPUGroups) {
+		if failed[group] {
+			continue
+		}
+
+		metrics = append(metrics, metric.buildPrometheusMetric(
+			combine(groups[group]), append(a.cpuAggregation.labelValues(group), labelValues...)...,
+		))
+	}
+
+	for _, group := range slices.SortedFunc(maps.Keys(failed), compareCPUGroups) {
+		a.countFailure(gpus.FailedReading(), a.cpuAggregation.device(group), metric)
+	}
+
+	return metrics
+}
+
+// topologyKnown reports whether the placement of any CPU is known.
+func topologyKnown(topology []gpus.CPUPlacement) bool {
+	return slices.ContainsFunc(topology, func(placement gpus.CPUPlacement) bool { return placement.Known })
+}
+
+// threadMetric returns a copy of the given metric labelled by logical CPU instead of the labels of the level.
+func (a *AMDMetrics) threadMetric(metric *CustomMetric) *CustomMetric {
+	result := *metric
+	result.Labels = append(CPUAggregationThread.labels(), metric.Labels[len(a.cpuAggregation.labels()):]...)
+
+	return &result
+}
+
+// compareCPUGroups orders groups by socket and then by index.
+func compareCPUGroups(a, b cpuGroup) int {
+	if a.socket != b.socket {
+		return a.socket - b.socket
+	}
+
+	return a.index - b.index
+}
@@ -3,9 +3,12 @@ package metrics
 import (
 	"fmt"
 	"log/slog"
+	"maps"
 	"regexp"
 	"slices"
 	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/pods"
@@ -41,17 +44,107 @@ type AMDMetrics struct {
 	NumGPUs        *CustomMetric
 	GPUDevID       *CustomMetric
 	GPUPowerCap    *CustomMetric
+	// GPUPower is deprecated by GPUPowerWatts, it is only exported when the deprecated power metric is enabled.
 	GPUPower       *CustomMetric
+	GPUPowerWatts  *CustomMetric
+	GPUEnergy      *CustomMetric
 	GPUTemperature *CustomMetric
 	GPUSCLK        *CustomMetric
 	GPUMCLK        *CustomMetric
 	GPUUsage       *CustomMetric
 	GPUMemoryUsage *CustomMetric
-	CardsInfo      [gpus.MaxNumGPUDevices]gpus.Card
-	K8SResources   map[string][]pods.PodInfo
+	GPUVRAMTotal   *CustomMetric
+	GPUVRAMUsed    *CustomMetric
+	GPUVisVRAMUsed *CustomMetric
+	GPUGTTUsed     *CustomMetric
+	// GPUECCCorrectable and GPUECCUncorrectable are labelled by RAS block.
+	GPUECCCorrectable   *CustomMetric
+	GPUECCUncorrectable *CustomMetric
+	GPURetiredPages     *CustomMetric
+	GPUPCIeSpeed        *CustomMetric
+	GPUPCIeMaxSpeed     *CustomMetric
+	GPUPCIeWidth        *CustomMetric
+	GPUPCIeMaxWidth     *CustomMetric
+	GPUPCIeReplays      *CustomMetric
+	GPUPCIeNAKSent      *CustomMetric
+	GPUPCIeNAKReceived  *CustomMetric
+	GPUPCIeBandwidth    *CustomMetric
+	// GPU temperatures and thresholds are labelled by sensor.
+	GPUTemperatures         *CustomMetric
+	GPUTemperatureCritical  *CustomMetric
+	GPUTemperatureEmergency *CustomMetric
+	GPUFanSpeed             *CustomMetric
+	GPUFanSpeedPercent      *CustomMetric
+	// GPUVoltage is labelled by voltage rail.
+	GPUVoltage *CustomMetric
+	// GPUAverageClock is labelled by clock domain.
+	GPUAverageClock *CustomMetric
+	// GPU throttle metrics are labelled by throttle reason.
+	GPUThrottled        *CustomMetric
+	GPUThrottledSeconds *CustomMetric
+	// XGMI width and speed of the GPU as a whole.
+	GPUXGMIWidth *CustomMetric
+	GPUXGMISpeed *CustomMetric
+	// XGMI link metrics are labelled by source and peer devices.
+	GPUXGMILinkStatus *CustomMetric
+	GPUXGMIReadBytes  *CustomMetric
+	GPUXGMIWriteBytes *CustomMetric
+	// GPUContainerVRAMUsed is labelled by the pod and container of GPU processes.
+	GPUContainerVRAMUsed *CustomMetric
+	// GPUContainerDRMEngineSeconds is labelled by engine, pod and container, it only accounts work
+	// submitted through DRM, not compute queues mapped through KFD.
+	GPUContainerDRMEngineSeconds *CustomMetric
+	// GPUInfo is always 1, it is labelled by the identity and versions of the GPU.
+	GPUInfo *CustomMetric
+	// GPUPartitionMode is always 1, it is labelled by the partition modes of a physical GPU.
+	GPUPartitionMode *CustomMetric
+	// GPUNUMAInfo is always 1, it is labelled by the NUMA node, local CPUs, socket and root complex of the GPU.
+	GPUNUMAInfo *CustomMetric
+	// SocketTemperature is labelled by sensor and CCDTemperature by the CCD of the socket.
+	SocketTemperature *CustomMetric
+	CCDTemperature    *CustomMetric
+	// CPUSeconds is labelled by thread and CPU mode.
+	CPUSeconds   *CustomMetric
+	CPUFrequency *CustomMetric
+	// Socket DDR bandwidth, clocks, C0 residency, frequency limit and SVI rail power.
+	SocketDDRBandwidth    *CustomMetric
+	SocketDDRMaxBandwidth *CustomMetric
+	SocketDDRUtilization  *CustomMetric
+	SocketFCLK            *CustomMetric
+	SocketMCLK            *CustomMetric
+	SocketC0Residency     *CustomMetric
+	SocketFrequencyLimit  *CustomMetric
+	// SocketFrequencyLimited is labelled by the source limiting the frequency of the socket.
+	SocketFrequencyLimited *CustomMetric
+	SocketSVIPower         *CustomMetric
+	// DIMMPower and DIMMTemperature are labelled by socket and DIMM address.
+	DIMMPower       *CustomMetric
+	DIMMTemperature *CustomMetric
+	// ReadingFailures counts readings that could not be taken by device and field.
+	ReadingFailures *CustomMetric
+	CardsInfo       []gpus.Card
+	K8SResources    map[string][]pods.PodInfo
+	// K8SContainers contains the pods running within the node indexed by container ID.
+	K8SContainers  map[string]pods.PodInfo
 	Data           gpus.AMDParamsHandler // This is the Scan() function handle
 	logger         *slog.Logger
 	withKubernetes bool
+	// deprecatedGPUPower enables the deprecated GPU power metric.
+	deprecatedGPUPower bool
+	failures           map[readingFailure]float64
+	failuresMutex      sync.Mutex
+	// cpuAggregation is the level thread readings of CPU metrics are aggregated to.
+	cpuAggregation CPUAggregation
+	// gpuNUMALabels adds NUMA node and socket of the GPU to common GPU labels.
+	gpuNUMALabels bool
+	// threadFallbackOnce logs the first time CPU metrics are exported per thread for lack of topology.
+	threadFallbackOnce sync.Once
+}
+
+// readingFailure identifies the readings counted by the reading failures metric.
+type readingFailure struct {
+	device string
+	field  string
 }
 
 // Setup contains objects required to process metrics.
@@ -59,6 +152,12 @@ type Setup struct {
 	AMDParamsHandler gpus.AMDParamsHandler
 	Logger           *slog.Logger
 	WithKubernetes   bool
+	// DeprecatedGPUPower enables the deprecated amd_gpu_power metric besides amd_gpu_power_watts.
+	DeprecatedGPUPower bool
+	// CPUAggregation is the level thread readings of CPU metrics are aggregated to, thread when it is empty.
+	CPUAggregation CPUAggregation
+	// GPUNUMALabels adds numa_node and socket labels to every GPU metric.
+	GPUNUMALabels bool
 }
 
 // metric labels.
@@ -69,9 +168,44 @@ const (
 	nodeNameLabel      string = "exported_node"
 	productNameLabel   string = "productname"
 	deviceNameLabel    string = "device"
+	fieldNameLabel     string = "field"
+	blockLabel         string = "block"
+	sensorLabel        string = "sensor"
+	railLabel          string = "rail"
+	clockLabel         string = "clock"
+	reasonLabel        string = "reason"
+	engineLabel        string = "engine"
+	pciBusLabel        string = "pci_bus"
+	skuLabel           string = "sku"
+	guidLabel          string = "guid"
+	uniqueIDLabel      string = "unique_id"
+	vbiosVersionLabel  string = "vbios_version"
+	driverVersionLabel string = "driver_version"
+	gfxVersionLabel    string = "gfx_version"
+	smcFirmwareLabel   string = "smc_firmware_version"
+	mecFirmwareLabel   string = "mec_firmware_version"
+	sdmaFirmwareLabel  string = "sdma_firmware_version"
+	pspFirmwareLabel   string = "psp_firmware_version"
+	partitionIDLabel   string = "partition_id"
+	ccdLabel           string = "ccd"
+	modeLabel          string = "mode"
+	sourceLabel        string = "source"
+	coreLabel          string = "core"
+	ccxLabel           string = "ccx"
+	dimmLabel          string = "dimm"
+	numaNodeLabel      string = "numa_node"
+	localCPUListLabel  string = "local_cpulist"
+	rootComplexLabel   string = "root_complex"
+	computePartLabel   string = "compute_partition"
+	memoryPartLabel    string = "memory_partition"
+	peerDeviceLabel    string = "peer_device"
+	peerPCIBusLabel    string = "peer_pci_bus"
 
 	deviceIDPrefix           string = "amd"
+	threadIDPrefix           string = "thread"
+	socketIDPrefix           string = "socket"
 	amdMetricHelpTextDefault string = "AMD Params" // The metric's help text.
+	gpuPowerHelpText         string = "Deprecated, use amd_gpu_power_watts instead."
 )
 
 // metric common values.
@@ -90,9 +224,12 @@ var replacer = regexp.MustCompile(`[^a-zA-Z0-9_]`)
 // if k8s resources are needed.
 func NewAMDMetrics(settings *Setup) *AMDMetrics {
 	newAMDMetrics := &AMDMetrics{
-		withKubernetes: settings.WithKubernetes,
-		Data:           settings.AMDParamsHandler,
-		logger:         settings.Logger,
+		withKubernetes:     settings.WithKubernetes,
+		deprecatedGPUPower: settings.DeprecatedGPUPower,
+		cpuAggregation:     settings.CPUAggregation,
+		gpuNUMALabels:      settings.GPUNUMALabels,
+		Data:               settings.AMDParamsHandler,
+		logger:             settings.Logger,
 	}
 
 	return newAMDMetrics.initializeMetrics()
@@ -105,9 +242,9 @@ func (a *AMDMetrics) initializeMetrics() *AMDMetrics {
 		HelpText: amdMetricHelpTextDefault,
 		Labels:   []string{"socket"},
 	}
-	a.CoreEnergy = newAMDCounterMetric("core_energy", "thread")
+	a.CoreEnergy = newAMDCounterMetric("core_energy", a.cpuAggregation.labels()...)
 	a.SocketEnergy = newAMDCounterMetric("socket_energy", "socket")
-	a.BoostLimit = newAMDGaugeMetric("boost_limit", "thread")
+	a.BoostLimit = newAMDGaugeMetric("boost_limit", a.cpuAggregation.labels()...)
 	a.SocketPower = newAMDGaugeMetric("socket_power", "socket")
 	a.PowerLimit = newAMDGaugeMetricWithName("power_limit")
 	a.ProchotStatus = newAMDGaugeMetricWithName("prochot_status")
@@ -115,19 +252,83 @@ func (a *AMDMetrics) initializeMetrics() *AMDMetrics {
 	a.Threads = newAMDGaugeMetricWithName("num_threads")
 	a.ThreadsPerCore = newAMDGaugeMetricWithName("num_threads_per_core")
 	a.NumGPUs = newAMDGaugeMetricWithName("num_gpus")
-	a.GPUDevID = newAMDGPUGaugeMetric("gpu_dev_id")
-	a.GPUPowerCap = newAMDGPUGaugeMetric("gpu_power_cap").
+	a.GPUDevID = a.newAMDGPUGaugeMetric("gpu_dev_id")
+	a.GPUPowerCap = a.newAMDGPUGaugeMetric("gpu_power_cap").
+		WithDivisor(1e6)
+	a.GPUPower = a.newAMDGPUGaugeMetric("gpu_power").
+		WithDivisor(1e6).
+		WithHelpText(gpuPowerHelpText)
+	a.GPUPowerWatts = a.newAMDGPUGaugeMetric("gpu_power_watts").
 		WithDivisor(1e6)
-	a.GPUPower = newAMDGPUCounterMetric("gpu_power").
+	a.GPUEnergy = a.newAMDGPUCounterMetric("gpu_energy_joules_total").
 		WithDivisor(1e6)
-	a.GPUTemperature = newAMDGPUGaugeMetric("gpu_current_temperature").
+	a.GPUTemperature = a.newAMDGPUGaugeMetric("gpu_current_temperature").
 		WithDivisor(1e3)
-	a.GPUSCLK = newAMDGPUGaugeMetric("gpu_SCLK").
+	a.GPUSCLK = a.newAMDGPUGaugeMetric("gpu_SCLK").
 		WithDivisor(1e6)
-	a.GPUMCLK = newAMDGPUGaugeMetric("gpu_MCLK").
+	a.GPUMCLK = a.newAMDGPUGaugeMetric("gpu_MCLK").
 		WithDivisor(1e6)
-	a.GPUUsage = newAMDGPUGaugeMetric("gpu_use_percent")
-	a.GPUMemoryUsage = newAMDGPUGaugeMetric("gpu_memory_use_percent")
+	a.GPUUsage = a.newAMDGPUGaugeMetric("gpu_use_percent")
+	a.GPUMemoryUsage = a.newAMDGPUGaugeMetric("gpu_memory_use_percent")
+	a.GPUVRAMTotal = a.newAMDGPUGaugeMetric("vram_total_bytes")
+	a.GPUVRAMUsed = a.newAMDGPUGaugeMetric("vram_used_bytes")
+	a.GPUVisVRAMUsed = a.newAMDGPUGaugeMetric("vis_vram_used_bytes")
+	a.GPUGTTUsed = a.newAMDGPUGaugeMetric("gtt_used_bytes")
+	a.GPUECCCorrectable = a.newAMDGPUCounterMetric("gpu_ecc_correctable_errors_total", blockLabel)
+	a.GPUECCUncorrectable = a.newAMDGPUCounterMetric("gpu_ecc_uncorrectable_errors_total", blockLabel)
+	a.GPURetiredPages = a.newAMDGPUCounterMetric("gpu_retired_pages_total")
+	a.GPUPCIeSpeed = a.newAMDGPUGaugeMetric("gpu_pcie_link_speed_gts")
+	a.GPUPCIeMaxSpeed = a.newAMDGPUGaugeMetric("gpu_pcie_link_max_speed_gts")
+	a.GPUPCIeWidth = a.newAMDGPUGaugeMetric("gpu_pcie_link_width")
+	a.GPUPCIeMaxWidth = a.newAMDGPUGaugeMetric("gpu_pcie_link_max_width")
+	a.GPUPCIeReplays = a.newAMDGPUCounterMetric("gpu_pcie_replays_total")
+	a.GPUPCIeNAKSent = a.newAMDGPUCounterMetric("gpu_pcie_nak_sent_total")
+	a.GPUPCIeNAKReceived = a.newAMDGPUCounterMetric("gpu_pcie_nak_received_total")
+	a.GPUPCIeBandwidth = a.newAMDGPUGaugeMetric("gpu_pcie_bandwidth_bytes_per_second")
+	a.GPUTemperatures = a.newAMDGPUGaugeMetric("gpu_temperature_celsius", sensorLabel).
+		WithDivisor(1e3)
+	a.GPUTemperatureCritical = a.newAMDGPUGaugeMetric("gpu_temperature_critical_celsius", sensorLabel).
+		WithDivisor(1e3)
+	a.GPUTemperatureEmergency = a.newAMDGPUGaugeMetric("gpu_temperature_emergency_celsius", sensorLabel).
+		WithDivisor(1e3)
+	a.GPUFanSpeed = a.newAMDGPUGaugeMetric("gpu_fan_speed_rpm")
+	a.GPUFanSpeedPercent = a.newAMDGPUGaugeMetric("gpu_fan_speed_percent")
+	a.GPUVoltage = a.newAMDGPUGaugeMetric("gpu_voltage_volts", railLabel).
+		WithDivisor(1e3)
+	a.GPUAverageClock = a.newAMDGPUGaugeMetric("gpu_average_clock_hertz", clockLabel)
+	a.GPUThrottled = a.newAMDGPUGaugeMetric("gpu_throttle_status", reasonLabel)
+	a.GPUThrottledSeconds = a.newAMDGPUCounterMetric("gpu_throttled_seconds_total", reasonLabel)
+	a.GPUXGMIWidth = a.newAMDGPUGaugeMetric("gpu_xgmi_width")
+	a.GPUXGMISpeed = a.newAMDGPUGaugeMetric("gpu_xgmi_speed_gbps")
+	a.GPUXGMILinkStatus = a.newAMDGPUGaugeMetric("gpu_xgmi_link_status", xgmiLinkLabels()...)
+	a.GPUXGMIReadBytes = a.newAMDGPUCounterMetric("gpu_xgmi_read_bytes_total", xgmiLinkLabels()...)
+	a.GPUXGMIWriteBytes = a.newAMDGPUCounterMetric("gpu_xgmi_write_bytes_total", xgmiLinkLabels()...)
+	a.GPUContainerVRAMUsed = a.newAMDGPUGaugeMetric("container_gpu_vram_used_bytes")
+	a.GPUContainerDRMEngineSeconds = a.newAMDGPUCounterMetric("container_gpu_drm_engine_seconds_total", engineLabel)
+	a.GPUInfo = a.newAMDGPUGaugeMetric("gpu_info", gpuInfoLabels()...)
+	a.GPUPartitionMode = a.newAMDGPUGaugeMetric("gpu_partition_mode", pciBusLabel, computePartLabel, memoryPartLabel)
+	a.GPUNUMAInfo = a.newAMDGPUGaugeMetric("gpu_numa_info", a.gpuNUMAInfoLabels()...)
+	a.SocketTemperature = newAMDGaugeMetric("socket_temperature_celsius", "socket", sensorLabel).
+		WithDivisor(1e3)
+	a.CCDTemperature = newAMDGaugeMetric("ccd_temperature_celsius", "socket", ccdLabel).
+		WithDivisor(1e3)
+	a.CPUSeconds = newAMDCounterMetric("cpu_seconds_total", append(a.cpuAggregation.labels(), modeLabel)...)
+	a.CPUFrequency = newAMDGaugeMetric("cpu_frequency_hertz", a.cpuAggregation.labels()...)
+	a.SocketDDRBandwidth = newAMDGaugeMetric("socket_ddr_bandwidth_bytes_per_second", "socket")
+	a.SocketDDRMaxBandwidth = newAMDGaugeMetric("socket_ddr_max_bandwidth_bytes_per_second", "socket")
+	a.SocketDDRUtilization = newAMDGaugeMetric("socket_ddr_bandwidth_utilization_percent", "socket")
+	a.SocketFCLK = newAMDGaugeMetric("socket_fclk_hertz", "socket")
+	a.SocketMCLK = newAMDGaugeMetric("socket_mclk_hertz", "socket")
+	a.SocketC0Residency = newAMDGaugeMetric("socket_c0_residency_percent", "socket")
+	a.SocketFrequencyLimit = newAMDGaugeMetric("socket_frequency_limit_hertz", "socket")
+	a.SocketFrequencyLimited = newAMDGaugeMetric("socket_frequency_limit_status", "socket", sourceLabel)
+	a.SocketSVIPower = newAMDGaugeMetric("socket_svi_power_watts", "socket").
+		WithDivisor(1e3)
+	a.DIMMPower = newAMDGaugeMetric("dimm_power_watts", "socket", dimmLabel).
+		WithDivisor(1e3)
+	a.DIMMTemperature = newAMDGaugeMetric("dimm_temperature_celsius", "socket", dimmLabel).
+		WithDivisor(1e3)
+	a.ReadingFailures = newAMDCounterMetric("reading_failures_total", deviceNameLabel, fieldNameLabel)
 
 	return a
 }
@@ -150,20 +351,57 @@ func newAMDMetric(name string, mType prometheus.ValueType, label ...string) *Cus
 	}
 }
 
-func newAMDGPUGaugeMetric(name string) *CustomMetric {
-	return newAMDGPUMetric(name, prometheus.GaugeValue)
+func (a *AMDMetrics) newAMDGPUGaugeMetric(name string, label ...string) *CustomMetric {
+	return a.newAMDGPUMetric(name, prometheus.GaugeValue, label...)
 }
 
-func newAMDGPUCounterMetric(name string) *CustomMetric {
-	return newAMDGPUMetric(name, prometheus.CounterValue)
+func (a *AMDMetrics) newAMDGPUCounterMetric(name string, label ...string) *CustomMetric {
+	return a.newAMDGPUMetric(name, prometheus.CounterValue, label...)
 }
 
 func newAMDGaugeMetricWithName(name string) *CustomMetric {
 	return newAMDMetric(name, prometheus.GaugeValue, name)
 }
 
-func newAMDGPUMetric(name string, mType prometheus.ValueType) *CustomMetric {
-	return newAMDMetric(name, mType, name, productNameLabel, deviceNameLabel)
+// newAMDGPUMetric creates a GPU metric, given labels are added after common GPU labels.
+func (a *AMDMetrics) newAMDGPUMetric(name string, mType prometheus.ValueType, label ...string) *CustomMetric {
+	return newAMDMetric(name, mType, append(a.commonGPULabels(name), label...)...)
+}
+
+// commonGPULabels returns labels identifying the GPU of a metric, the first one is named after the
+// metric. NUMA node and socket of the GPU are added when NUMA labels are enabled.
+func (a *AMDMetrics) commonGPULabels(name string) []string {
+	labels := []string{name, productNameLabel, deviceNameLabel}
+	if a.gpuNUMALabels {
+		labels = append(labels, numaNodeLabel, "socket")
+	}
+
+	return labels
+}
+
+// xgmiLinkLabels returns labels identifying both ends of an XGMI link, they are
+// added after common GPU labels which identify the source device.
+func xgmiLinkLabels() []string {
+	return []string{pciBusLabel, peerDeviceLabel, peerPCIBusLabel}
+}
+
+// gpuInfoLabels returns labels identifying the GPU and its versions, they are added after common GPU labels.
+func gpuInfoLabels() []string {
+	return []string{
+		skuLabel, guidLabel, uniqueIDLabel, pciBusLabel, partitionIDLabel,
+		vbiosVersionLabel, driverVersionLabel, gfxVersionLabel,
+		smcFirmwareLabel, mecFirmwareLabel, sdmaFirmwareLabel, pspFirmwareLabel,
+	}
+}
+
+// gpuNUMAInfoLabels returns labels locating the GPU within the host, they are added after common GPU
+// labels which already contain NUMA node and socket when NUMA labels are enabled.
+func (a *AMDMetrics) gpuNUMAInfoLabels() []string {
+	if a.gpuNUMALabels {
+		return []string{pciBusLabel, localCPUListLabel, rootComplexLabel}
+	}
+
+	return []string{pciBusLabel, numaNodeLabel, "socket", localCPUListLabel, rootComplexLabel}
 }
 
 // k8sVariableLabels return list of kubernetes labels required in metrics.
@@ -179,6 +417,13 @@ func (c *CustomMetric) WithDivisor(divisor float64) *CustomMetric {
 	return c
 }
 
+// WithHelpText sets the help text of the metric.
+func (c *CustomMetric) WithHelpText(helpText string) *CustomMetric {
+	c.HelpText = helpText
+
+	return c
+}
+
 // buildPrometheusMetric builds prometheus metric based on given value and metric configuration.
 func (c *CustomMetric) buildPrometheusMetric(value float64, labelValues ...string) prometheus.Metric {
 	value = c.transformValue(value)
@@ -223,66 +468,428 @@ func (a *AMDMetrics) CollectAndBuildMetrics() []prometheus.Metric {
 
 	metrics := make([]prometheus.Metric, 0)
 
-	metrics = append(metrics, buildMetrics(data.CoreEnergy[:data.Threads], data.Threads, a.CoreEnergy)...)
-	metrics = append(metrics, buildMetrics(data.CoreBoost[:data.Threads], data.Threads, a.BoostLimit)...)
-	metrics = append(metrics, buildMetrics(data.SocketEnergy[:data.Sockets], data.Sockets, a.SocketEnergy)...)
-	metrics = append(metrics, buildMetrics(data.SocketPower[:data.Sockets], data.Sockets, a.SocketPower)...)
-	metrics = append(metrics, buildMetrics(data.PowerLimit[:data.Sockets], data.Sockets, a.PowerLimit)...)
-	metrics = append(metrics, buildMetrics(data.ProchotStatus[:data.Sockets], data.Sockets, a.ProchotStatus)...)
+	metrics = append(metrics, a.buildCPUMetrics(data.CoreEnergy, data.CPUTopology, a.CoreEnergy, sumCores)...)
+	metrics = append(metrics, a.buildCPUMetrics(data.CoreBoost, data.CPUTopology, a.BoostLimit, minThreads)...)
+
+	for mode := range gpus.NumCPUModes {
+		metrics = append(metrics, a.buildCPUMetrics(data.CPUSeconds[mode], data.CPUTopology, a.CPUSeconds, sumThreads, mode.String())...)
+	}
+
+	metrics = append(metrics, a.buildCPUMetrics(data.CPUFrequency, data.CPUTopology, a.CPUFrequency, meanThreads)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketEnergy, a.SocketEnergy, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketPower, a.SocketPower, socketIDPrefix)...)
+
+	for sensor := range gpus.NumSocketTemperatureSensors {
+		metrics = append(metrics, a.buildMetrics(data.SocketTemperatures[sensor], a.SocketTemperature, socketIDPrefix, sensor.String())...)
+	}
+
+	metrics = append(metrics, a.buildCCDMetrics(data.CCDTemperatures, a.CCDTemperature)...)
+	metrics = append(metrics, a.buildMetrics(data.PowerLimit, a.PowerLimit, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.ProchotStatus, a.ProchotStatus, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketDDRBandwidth, a.SocketDDRBandwidth, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketDDRMaxBandwidth, a.SocketDDRMaxBandwidth, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketDDRUtilization, a.SocketDDRUtilization, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketFCLK, a.SocketFCLK, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketMCLK, a.SocketMCLK, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketC0Residency, a.SocketC0Residency, socketIDPrefix)...)
+	metrics = append(metrics, a.buildMetrics(data.SocketFrequencyLimit, a.SocketFrequencyLimit, socketIDPrefix)...)
+
+	for source := range gpus.NumFrequencyLimitSources {
+		limited := a.buildMetrics(data.SocketFrequencyLimited[source], a.SocketFrequencyLimited, socketIDPrefix, source.String())
+		metrics = append(metrics, limited...)
+	}
+
+	metrics = append(metrics, a.buildMetrics(data.SocketSVIPower, a.SocketSVIPower, socketIDPrefix)...)
+	metrics = append(metrics, a.buildDIMMMetrics(data.SocketDIMMs, a.DIMMPower, dimmPower)...)
+	metrics = append(metrics, a.buildDIMMMetrics(data.SocketDIMMs, a.DIMMTemperature, dimmTemperature)...)
 
 	// GPU metrics
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUDevID[:data.NumGPUs], data.NumGPUs, a.GPUDevID)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUPowerCap[:data.NumGPUs], data.NumGPUs, a.GPUPowerCap)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUPower[:data.NumGPUs], data.NumGPUs, a.GPUPower)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUTemperature[:data.NumGPUs], data.NumGPUs, a.GPUTemperature)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUSCLK[:data.NumGPUs], data.NumGPUs, a.GPUSCLK)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUMCLK[:data.NumGPUs], data.NumGPUs, a.GPUMCLK)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUUsage[:data.NumGPUs], data.NumGPUs, a.GPUUsage)...)
-	metrics = append(metrics, a.buildGPUMetrics(data.GPUMemoryUsage[:data.NumGPUs], data.NumGPUs, a.GPUMemoryUsage)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUDevID, a.GPUDevID)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPowerCap, a.GPUPowerCap)...)
+
+	if a.deprecatedGPUPower {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUPower, a.GPUPower)...)
+	}
+
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPower, a.GPUPowerWatts)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUEnergy, a.GPUEnergy)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUTemperature, a.GPUTemperature)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUSCLK, a.GPUSCLK)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUMCLK, a.GPUMCLK)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUUsage, a.GPUUsage)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUMemoryUsage, a.GPUMemoryUsage)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUVRAMTotal, a.GPUVRAMTotal)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUVRAMUsed, a.GPUVRAMUsed)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUVisVRAMUsed, a.GPUVisVRAMUsed)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUGTTUsed, a.GPUGTTUsed)...)
+
+	for block := range gpus.NumRASBlocks {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUECCCorrectable[block], a.GPUECCCorrectable, block.String())...)
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUECCUncorrectable[block], a.GPUECCUncorrectable, block.String())...)
+	}
+
+	metrics = append(metrics, a.buildGPUMetrics(data.GPURetiredPages, a.GPURetiredPages)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeSpeed, a.GPUPCIeSpeed)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeMaxSpeed, a.GPUPCIeMaxSpeed)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeWidth, a.GPUPCIeWidth)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeMaxWidth, a.GPUPCIeMaxWidth)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeReplays, a.GPUPCIeReplays)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeNAKSent, a.GPUPCIeNAKSent)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeNAKReceived, a.GPUPCIeNAKReceived)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUPCIeBandwidth, a.GPUPCIeBandwidth)...)
+
+	for sensor := range gpus.NumTemperatureSensors {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUTemperatures[sensor], a.GPUTemperatures, sensor.String())...)
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUTemperatureCritical[sensor], a.GPUTemperatureCritical, sensor.String())...)
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUTemperatureEmergency[sensor], a.GPUTemperatureEmergency, sensor.String())...)
+	}
+
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUFanSpeed, a.GPUFanSpeed)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUFanSpeedPercent, a.GPUFanSpeedPercent)...)
+
+	for rail := range gpus.NumVoltageRails {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUVoltage[rail], a.GPUVoltage, rail.String())...)
+	}
 
-	metrics = append(metrics, a.resourceGroupMetrics(&data)...)
+	for clock := range gpus.NumClockDomains {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUAverageClocks[clock], a.GPUAverageClock, clock.String())...)
+	}
+
+	for reason := range gpus.NumThrottleReasons {
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUThrottled[reason], a.GPUThrottled, reason.String())...)
+		metrics = append(metrics, a.buildGPUMetrics(data.GPUThrottledSeconds[reason], a.GPUThrottledSeconds, reason.String())...)
+	}
+
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUXGMIWidth, a.GPUXGMIWidth)...)
+	metrics = append(metrics, a.buildGPUMetrics(data.GPUXGMISpeed, a.GPUXGMISpeed)...)
+	metrics = append(metrics, a.buildXGMILinkMetrics(data.GPUXGMILinks, a.GPUXGMILinkStatus, xgmiLinkStatus)...)
+	metrics = append(metrics, a.buildXGMILinkMetrics(data.GPUXGMILinks, a.GPUXGMIReadBytes, xgmiReadBytes)...)
+	metrics = append(metrics, a.buildXGMILinkMetrics(data.GPUXGMILinks, a.GPUXGMIWriteBytes, xgmiWriteBytes)...)
+
+	metrics = append(metrics, a.buildContainerMetrics(data.GPUProcesses, a.GPUContainerVRAMUsed, processVRAMUsed)...)
+	metrics = append(metrics, a.buildContainerEngineMetrics(data.GPUContainers, a.GPUContainerDRMEngineSeconds)...)
+
+	metrics = append(metrics, a.resourceGroupMetrics(data)...)
+	metrics = append(metrics, a.readingFailureMetrics()...)
+	metrics = append(metrics, a.gpuInfoMetrics(data.NumGPUs)...)
 
 	return metrics
 }
 
-// buildMetrics builds prometheus metric based on given amd metric.
-func buildMetrics(
-	data []float64,
-	attrValue uint,
+// buildMetrics builds prometheus metric based on given amd metric and label values added after
+// the device index, readings without a value are omitted and failed readings are counted using
+// given device prefix.
+func (a *AMDMetrics) buildMetrics(
+	data []gpus.Reading,
 	metric *CustomMetric,
+	devicePrefix string,
+	labelValues ...string,
 ) []prometheus.Metric {
-	if attrValue == 0 {
-		return nil
+	var metrics []prometheus.Metric
+
+	for i := range data {
+		if !data[i].Valid() {
+			a.countFailure(data[i], devicePrefix+strconv.Itoa(i), metric)
+
+			continue
+		}
+
+		metrics = append(metrics, metric.buildPrometheusMetric(data[i].Value, append([]string{strconv.Itoa(i)}, labelValues...)...))
 	}
 
-	metrics := make([]prometheus.Metric, attrValue)
+	return metrics
+}
+
+// buildCCDMetrics builds prometheus metric based on the given readings of the CCDs of each socket,
+// readings without a value are omitted and failed readings are counted by socket.
+func (a *AMDMetrics) buildCCDMetrics(data [][]gpus.Reading, metric *CustomMetric) []prometheus.Metric {
+	var metrics []prometheus.Metric
 
 	for i := range data {
-		metrics[i] = metric.buildPrometheusMetric(data[i], strconv.Itoa(i))
+		for j := range data[i] {
+			if !data[i][j].Valid() {
+				a.countFailure(data[i][j], socketIDPrefix+strconv.Itoa(i), metric)
+
+				continue
+			}
+
+			metrics = append(metrics, metric.buildPrometheusMetric(data[i][j].Value, strconv.Itoa(i), strconv.Itoa(j)))
+		}
 	}
 
 	return metrics
 }
 
-// buildGPUMetrics builds prometheus metric based on given amd gpu metric.
+// buildDIMMMetrics builds prometheus metric based on the given reading of the DIMMs of each socket labelled
+// by DIMM address, readings without a value are omitted and failed readings are counted by socket.
+func (a *AMDMetrics) buildDIMMMetrics(
+	data [][]gpus.DIMM,
+	metric *CustomMetric,
+	reading func(*gpus.DIMM) gpus.Reading,
+) []prometheus.Metric {
+	var metrics []prometheus.Metric
+
+	for i := range data {
+		for j := range data[i] {
+			dimm := &data[i][j]
+
+			value := reading(dimm)
+			if !value.Valid() {
+				a.countFailure(value, socketIDPrefix+strconv.Itoa(i), metric)
+
+				continue
+			}
+
+			metrics = append(metrics, metric.buildPrometheusMetric(value.Value, strconv.Itoa(i), fmt.Sprintf("0x%02x", dimm.Address)))
+		}
+	}
+
+	return metrics
+}
+
+// DIMM readings exported as metrics.
+func dimmPower(dimm *gpus.DIMM) gpus.Reading       { return dimm.Power }
+func dimmTemperature(dimm *gpus.DIMM) gpus.Reading { return dimm.Temperature }
+
+// buildGPUMetrics builds prometheus metric based on given amd gpu metric and label values
+// added after common GPU labels, readings without a value are omitted and failed readings are counted.
 func (a *AMDMetrics) buildGPUMetrics(
-	data []float64,
-	attrValue uint,
+	data []gpus.Reading,
+	metric *CustomMetric,
+	labelValues ...string,
+) []prometheus.Metric {
+	var metrics []prometheus.Metric
+
+	for i := range data {
+		if !data[i].Valid() {
+			a.countFailure(data[i], buildDeviceLabelValue(i), metric)
+
+			continue
+		}
+
+		metrics = append(metrics, a.newMetricWithResources(metric, data[i].Value, i, labelValues...)...)
+	}
+
+	return metrics
+}
+
+// buildXGMILinkMetrics builds prometheus metric based on the given reading of XGMI links
+// labelled by source and peer devices, readings without a value are omitted and failed
+// readings are counted by source device.
+func (a *AMDMetrics) buildXGMILinkMetrics(
+	data [][]gpus.XGMILink,
+	metric *CustomMetric,
+	reading func(*gpus.XGMILink) gpus.Reading,
+) []prometheus.Metric {
+	var metrics []prometheus.Metric
+
+	for i := range data {
+		for j := range data[i] {
+			link := &data[i][j]
+
+			value := reading(link)
+			if !value.Valid() {
+				a.countFailure(value, buildDeviceLabelValue(i), metric)
+
+				continue
+			}
+
+			metrics = append(metrics, a.newMetricWithResources(
+				metric, value.Value, i,
+				a.card(i).PCIBus, buildDeviceLabelValue(link.Peer), a.card(link.Peer).PCIBus,
+			)...)
+		}
+	}
+
+	return metrics
+}
+
+// XGMI link readings exported as metrics.
+func xgmiLinkStatus(link *gpus.XGMILink) gpus.Reading { return link.Status }
+func xgmiReadBytes(link *gpus.XGMILink) gpus.Reading  { return link.ReadBytes }
+func xgmiWriteBytes(link *gpus.XGMILink) gpus.Reading { return link.WriteBytes }
+
+// buildContainerMetrics builds prometheus metric based on the given reading of GPU processes summed by
+// container, processes not running within a container of a known pod are omitted and failed readings are counted.
+func (a *AMDMetrics) buildContainerMetrics(
+	data [][]gpus.Process,
 	metric *CustomMetric,
+	reading func(*gpus.Process) gpus.Reading,
 ) []prometheus.Metric {
-	if attrValue == 0 {
+	if !a.withKubernetes {
 		return nil
 	}
 
 	var metrics []prometheus.Metric
 
 	for i := range data {
-		metrics = append(metrics, a.newMetricWithResources(metric, data[i], i)...)
+		values := make(map[string]float64)
+
+		for j := range data[i] {
+			process := &data[i][j]
+
+			value := reading(process)
+			if !value.Valid() {
+				a.countFailure(value, buildDeviceLabelValue(i), metric)
+
+				continue
+			}
+
+			if _, exist := a.K8SContainers[process.ContainerID]; !exist {
+				continue
+			}
+
+			values[process.ContainerID] += value.Value
+		}
+
+		for _, containerID := range slices.Sorted(maps.Keys(values)) {
+			metrics = append(metrics, a.newContainerMetric(metric, values[containerID], i, a.K8SContainers[containerID]))
+		}
+	}
+
+	return metrics
+}
+
+// buildContainerEngineMetrics builds prometheus metric based on the engine busy time of containers labelled
+// by engine, containers of unknown pods are omitted and failed readings are counted.
+func (a *AMDMetrics) buildContainerEngineMetrics(data [][]gpus.ContainerUsage, metric *CustomMetric) []prometheus.Metric {
+	if !a.withKubernetes {
+		return nil
+	}
+
+	var metrics []prometheus.Metric
+
+	for i := range data {
+		for j := range data[i] {
+			usage := &data[i][j]
+			pod, exist := a.K8SContainers[usage.ContainerID]
+
+			for engine, value := range usage.EngineSeconds {
+				if !value.Valid() {
+					a.countFailure(value, buildDeviceLabelValue(i), metric)
+
+					continue
+				}
+
+				if !exist {
+					continue
+				}
+
+				metrics = append(metrics, a.newContainerMetric(metric, value.Value, i, pod, gpus.Engine(engine).String()))
+			}
+		}
+	}
+
+	return metrics
+}
+
+// newContainerMetric creates a GPU card metric labelled by the given pod, additional label values
+// are added after common GPU label values.
+func (a *AMDMetrics) newContainerMetric(
+	metric *CustomMetric,
+	value float64, cardIndex int,
+	pod pods.PodInfo,
+	additionalLabelValues ...string,
+) prometheus.Metric {
+	labels, labelValues := buildK8SPodLabelValues(pod, append(a.commonGPULabelValues(cardIndex), additionalLabelValues...))
+
+	return prometheus.MustNewConstMetric(
+		metric.NewDesc(labels...),
+		metric.Type,
+		metric.transformValue(value),
+		labelValues...,
+	)
+}
+
+// GPU process readings exported as metrics.
+func processVRAMUsed(process *gpus.Process) gpus.Reading { return process.VRAMUsed }
+
+// countFailure increases reading failures of the given device and metric if the reading failed.
+func (a *AMDMetrics) countFailure(reading gpus.Reading, device string, metric *CustomMetric) {
+	if !reading.Failed() {
+		return
+	}
+
+	a.failuresMutex.Lock()
+	defer a.failuresMutex.Unlock()
+
+	if a.failures == nil {
+		a.failures = make(map[readingFailure]float64)
+	}
+
+	a.failures[readingFailure{device: device, field: metric.Name}]++
+}
+
+// readingFailureMetrics builds reading failure counters sorted by device and field,
+// devices without failures are not exposed.
+func (a *AMDMetrics) readingFailureMetrics() []prometheus.Metric {
+	a.failuresMutex.Lock()
+	defer a.failuresMutex.Unlock()
+
+	keys := make([]readingFailure, 0, len(a.failures))
+	for key := range a.failures {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(x, y readingFailure) int {
+		if x.device != y.device {
+			return strings.Compare(x.device, y.device)
+		}
+
+		return strings.Compare(x.field, y.field)
+	})
+
+	metrics := make([]prometheus.Metric, 0, len(keys))
+
+	for _, key := range keys {
+		metrics = append(metrics, a.ReadingFailures.buildPrometheusMetric(a.failures[key], key.device, key.field))
 	}
 
 	return metrics
 }
 
+// gpuInfoMetrics builds an info metric for each discovered GPU and a partition mode metric for each
+// physical GPU, reported by its first partition, they are not labelled by pods since they are meant
+// to be joined with other GPU metrics by device.
+func (a *AMDMetrics) gpuInfoMetrics(numGPUs uint) []prometheus.Metric {
+	metrics := make([]prometheus.Metric, 0, numGPUs)
+
+	for i := range min(int(numGPUs), len(a.CardsInfo)) {
+		card := a.CardsInfo[i]
+		labelValues := append(
+			a.commonGPULabelValues(i),
+			card.CardSKU, card.CardGUID, card.UniqueID, card.PCIBus, strconv.Itoa(card.PartitionID),
+			card.VBIOSVersion, card.DriverVersion, card.GFXVersion,
+			card.SMCFirmwareVersion, card.MECFirmwareVersion, card.SDMAFirmwareVersion, card.PSPFirmwareVersion,
+		)
+
+		metrics = append(metrics, a.GPUInfo.buildPrometheusMetric(1, labelValues...))
+
+		metrics = append(metrics, a.GPUNUMAInfo.buildPrometheusMetric(
+			1, append(a.commonGPULabelValues(i), a.gpuNUMAInfoLabelValues(&card)...)...,
+		))
+
+		if card.PartitionID != 0 || (card.ComputePartition == "" && card.MemoryPartition == "") {
+			continue
+		}
+
+		metrics = append(metrics, a.GPUPartitionMode.buildPrometheusMetric(
+			1, append(a.commonGPULabelValues(i), card.PCIBus, card.ComputePartition, card.MemoryPartition)...,
+		))
+	}
+
+	return metrics
+}
+
+// gpuNUMAInfoLabelValues returns the values of the labels given by gpuNUMAInfoLabels.
+func (a *AMDMetrics) gpuNUMAInfoLabelValues(card *gpus.Card) []string {
+	if a.gpuNUMALabels {
+		return []string{card.PCIBus, card.LocalCPUs, card.RootComplex}
+	}
+
+	return []string{card.PCIBus, card.NUMANode, card.Socket, card.LocalCPUs, card.RootComplex}
+}
+
 // resourceGroupMetrics build global metrics such as sockets, thread and number of GPUs.
 func (a *AMDMetrics) resourceGroupMetrics(params *gpus.AMDParams) []prometheus.Metric {
 	return []prometheus.Metric{
@@ -295,12 +902,14 @@ func (a *AMDMetrics) resourceGroupMetrics(params *gpus.AMDParams) []prometheus.M
 
 // newMetricWithResources map given GPU card metric with pod
 // using it. If there is no any pod using this card then
-// a prometheus metric is created with pod labels.
+// a prometheus metric is created with pod labels. Additional
+// label values are added after common GPU label values.
 func (a *AMDMetrics) newMetricWithResources(
 	metric *CustomMetric,
 	value float64, cardIndex int,
+	additionalLabelValues ...string,
 ) []prometheus.Metric {
-	labelValues := a.commonGPULabelValues(cardIndex)
+	labelValues := append(a.commonGPULabelValues(cardIndex), additionalLabelValues...)
 
 	if !a.withKubernetes {
 		return []prometheus.Metric{
@@ -308,7 +917,9 @@ func (a *AMDMetrics) newMetricWithResources(
 		}
 	}
 
-	podsInfo, exist := a.K8SResources[a.CardsInfo[cardIndex].PCIBus]
+	card := a.card(cardIndex)
+
+	podsInfo, exist := a.K8SResources[card.DeviceID()]
 	if !exist {
 		return []prometheus.Metric{
 			metric.buildPrometheusMetric(value, labelValues...),
@@ -334,11 +945,27 @@ func (a *AMDMetrics) newMetricWithResources(
 
 // commonGPULabelValues returns common GPU labels.
 func (a *AMDMetrics) commonGPULabelValues(cardIndex int) []string {
-	return []string{
+	card := a.card(cardIndex)
+	labelValues := []string{
 		strconv.Itoa(cardIndex),
-		a.CardsInfo[cardIndex].Cardseries,
+		card.Cardseries,
 		buildDeviceLabelValue(cardIndex),
 	}
+
+	if a.gpuNUMALabels {
+		labelValues = append(labelValues, card.NUMANode, card.Socket)
+	}
+
+	return labelValues
+}
+
+// card returns information of the given card, it is empty if the card was not discovered.
+func (a *AMDMetrics) card(cardIndex int) gpus.Card {
+	if cardIndex >= len(a.CardsInfo) {
+		return gpus.Card{}
+	}
+
+	return a.CardsInfo[cardIndex]
 }
 
 // buildDeviceIDLabelValue build device label name.
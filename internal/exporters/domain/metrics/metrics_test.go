@@ -1,6 +1,8 @@
 package metrics_test
 
 import (
+	"slices"
+	"strconv"
 	"testing"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
@@ -10,6 +12,7 @@ import (
 	"github.com/openinnovationai/k8s-amd-exporter/internal/sdk/unittests/testlogs"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewAMDMetrics(t *testing.T) {
@@ -112,11 +115,29 @@ func TestNewAMDMetrics(t *testing.T) {
 		GPUPower: &metrics.CustomMetric{
 			Name:      "gpu_power",
 			Namespace: "amd",
+			HelpText:  "Deprecated, use amd_gpu_power_watts instead.",
+			Type:      prometheus.GaugeValue,
+			Divide:    true,
+			Divisor:   1e6,
+			Labels:    []string{"gpu_power", "productname", "device"},
+		},
+		GPUPowerWatts: &metrics.CustomMetric{
+			Name:      "gpu_power_watts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Divide:    true,
+			Divisor:   1e6,
+			Labels:    []string{"gpu_power_watts", "productname", "device"},
+		},
+		GPUEnergy: &metrics.CustomMetric{
+			Name:      "gpu_energy_joules_total",
+			Namespace: "amd",
 			HelpText:  "AMD Params",
 			Type:      prometheus.CounterValue,
 			Divide:    true,
 			Divisor:   1e6,
-			Labels:    []string{"gpu_power", "productname", "device"},
+			Labels:    []string{"gpu_energy_joules_total", "productname", "device"},
 		},
 		GPUTemperature: &metrics.CustomMetric{
 			Name:      "gpu_current_temperature",
@@ -159,6 +180,378 @@ func TestNewAMDMetrics(t *testing.T) {
 			Type:      prometheus.GaugeValue,
 			Labels:    []string{"gpu_memory_use_percent", "productname", "device"},
 		},
+		GPUVRAMTotal: &metrics.CustomMetric{
+			Name:      "vram_total_bytes",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"vram_total_bytes", "productname", "device"},
+		},
+		GPUVRAMUsed: &metrics.CustomMetric{
+			Name:      "vram_used_bytes",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"vram_used_bytes", "productname", "device"},
+		},
+		GPUVisVRAMUsed: &metrics.CustomMetric{
+			Name:      "vis_vram_used_bytes",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"vis_vram_used_bytes", "productname", "device"},
+		},
+		GPUGTTUsed: &metrics.CustomMetric{
+			Name:      "gtt_used_bytes",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gtt_used_bytes", "productname", "device"},
+		},
+		GPUECCCorrectable: &metrics.CustomMetric{
+			Name:      "gpu_ecc_correctable_errors_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_ecc_correctable_errors_total", "productname", "device", "block"},
+		},
+		GPUECCUncorrectable: &metrics.CustomMetric{
+			Name:      "gpu_ecc_uncorrectable_errors_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_ecc_uncorrectable_errors_total", "productname", "device", "block"},
+		},
+		GPURetiredPages: &metrics.CustomMetric{
+			Name:      "gpu_retired_pages_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_retired_pages_total", "productname", "device"},
+		},
+		GPUPCIeSpeed: &metrics.CustomMetric{
+			Name:      "gpu_pcie_link_speed_gts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_pcie_link_speed_gts", "productname", "device"},
+		},
+		GPUPCIeMaxSpeed: &metrics.CustomMetric{
+			Name:      "gpu_pcie_link_max_speed_gts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_pcie_link_max_speed_gts", "productname", "device"},
+		},
+		GPUPCIeWidth: &metrics.CustomMetric{
+			Name:      "gpu_pcie_link_width",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_pcie_link_width", "productname", "device"},
+		},
+		GPUPCIeMaxWidth: &metrics.CustomMetric{
+			Name:      "gpu_pcie_link_max_width",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_pcie_link_max_width", "productname", "device"},
+		},
+		GPUPCIeReplays: &metrics.CustomMetric{
+			Name:      "gpu_pcie_replays_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_pcie_replays_total", "productname", "device"},
+		},
+		GPUPCIeNAKSent: &metrics.CustomMetric{
+			Name:      "gpu_pcie_nak_sent_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_pcie_nak_sent_total", "productname", "device"},
+		},
+		GPUPCIeNAKReceived: &metrics.CustomMetric{
+			Name:      "gpu_pcie_nak_received_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_pcie_nak_received_total", "productname", "device"},
+		},
+		GPUPCIeBandwidth: &metrics.CustomMetric{
+			Name:      "gpu_pcie_bandwidth_bytes_per_second",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_pcie_bandwidth_bytes_per_second", "productname", "device"},
+		},
+		GPUTemperatures: &metrics.CustomMetric{
+			Name:      "gpu_temperature_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_temperature_celsius", "productname", "device", "sensor"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		GPUTemperatureCritical: &metrics.CustomMetric{
+			Name:      "gpu_temperature_critical_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_temperature_critical_celsius", "productname", "device", "sensor"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		GPUTemperatureEmergency: &metrics.CustomMetric{
+			Name:      "gpu_temperature_emergency_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_temperature_emergency_celsius", "productname", "device", "sensor"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		GPUFanSpeed: &metrics.CustomMetric{
+			Name:      "gpu_fan_speed_rpm",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_fan_speed_rpm", "productname", "device"},
+		},
+		GPUFanSpeedPercent: &metrics.CustomMetric{
+			Name:      "gpu_fan_speed_percent",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_fan_speed_percent", "productname", "device"},
+		},
+		GPUVoltage: &metrics.CustomMetric{
+			Name:      "gpu_voltage_volts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_voltage_volts", "productname", "device", "rail"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		GPUAverageClock: &metrics.CustomMetric{
+			Name:      "gpu_average_clock_hertz",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_average_clock_hertz", "productname", "device", "clock"},
+		},
+		GPUThrottled: &metrics.CustomMetric{
+			Name:      "gpu_throttle_status",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_throttle_status", "productname", "device", "reason"},
+		},
+		GPUThrottledSeconds: &metrics.CustomMetric{
+			Name:      "gpu_throttled_seconds_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_throttled_seconds_total", "productname", "device", "reason"},
+		},
+		GPUXGMIWidth: &metrics.CustomMetric{
+			Name:      "gpu_xgmi_width",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_xgmi_width", "productname", "device"},
+		},
+		GPUXGMISpeed: &metrics.CustomMetric{
+			Name:      "gpu_xgmi_speed_gbps",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_xgmi_speed_gbps", "productname", "device"},
+		},
+		GPUXGMILinkStatus: &metrics.CustomMetric{
+			Name:      "gpu_xgmi_link_status",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_xgmi_link_status", "productname", "device", "pci_bus", "peer_device", "peer_pci_bus"},
+		},
+		GPUXGMIReadBytes: &metrics.CustomMetric{
+			Name:      "gpu_xgmi_read_bytes_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_xgmi_read_bytes_total", "productname", "device", "pci_bus", "peer_device", "peer_pci_bus"},
+		},
+		GPUXGMIWriteBytes: &metrics.CustomMetric{
+			Name:      "gpu_xgmi_write_bytes_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"gpu_xgmi_write_bytes_total", "productname", "device", "pci_bus", "peer_device", "peer_pci_bus"},
+		},
+		GPUContainerVRAMUsed: &metrics.CustomMetric{
+			Name:      "container_gpu_vram_used_bytes",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"container_gpu_vram_used_bytes", "productname", "device"},
+		},
+		GPUContainerDRMEngineSeconds: &metrics.CustomMetric{
+			Name:      "container_gpu_drm_engine_seconds_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"container_gpu_drm_engine_seconds_total", "productname", "device", "engine"},
+		},
+		GPUInfo: &metrics.CustomMetric{
+			Name:      "gpu_info",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels: []string{
+				"gpu_info", "productname", "device", "sku", "guid", "unique_id", "pci_bus", "partition_id",
+				"vbios_version", "driver_version", "gfx_version",
+				"smc_firmware_version", "mec_firmware_version", "sdma_firmware_version", "psp_firmware_version",
+			},
+		},
+		GPUPartitionMode: &metrics.CustomMetric{
+			Name:      "gpu_partition_mode",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_partition_mode", "productname", "device", "pci_bus", "compute_partition", "memory_partition"},
+		},
+		GPUNUMAInfo: &metrics.CustomMetric{
+			Name:      "gpu_numa_info",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"gpu_numa_info", "productname", "device", "pci_bus", "numa_node", "socket", "local_cpulist", "root_complex"},
+		},
+		SocketTemperature: &metrics.CustomMetric{
+			Name:      "socket_temperature_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket", "sensor"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		CCDTemperature: &metrics.CustomMetric{
+			Name:      "ccd_temperature_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket", "ccd"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		CPUSeconds: &metrics.CustomMetric{
+			Name:      "cpu_seconds_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"thread", "mode"},
+		},
+		CPUFrequency: &metrics.CustomMetric{
+			Name:      "cpu_frequency_hertz",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"thread"},
+		},
+		SocketDDRBandwidth: &metrics.CustomMetric{
+			Name:      "socket_ddr_bandwidth_bytes_per_second",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketDDRMaxBandwidth: &metrics.CustomMetric{
+			Name:      "socket_ddr_max_bandwidth_bytes_per_second",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketDDRUtilization: &metrics.CustomMetric{
+			Name:      "socket_ddr_bandwidth_utilization_percent",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketFCLK: &metrics.CustomMetric{
+			Name:      "socket_fclk_hertz",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketMCLK: &metrics.CustomMetric{
+			Name:      "socket_mclk_hertz",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketC0Residency: &metrics.CustomMetric{
+			Name:      "socket_c0_residency_percent",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketFrequencyLimit: &metrics.CustomMetric{
+			Name:      "socket_frequency_limit_hertz",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+		},
+		SocketFrequencyLimited: &metrics.CustomMetric{
+			Name:      "socket_frequency_limit_status",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket", "source"},
+		},
+		SocketSVIPower: &metrics.CustomMetric{
+			Name:      "socket_svi_power_watts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		DIMMPower: &metrics.CustomMetric{
+			Name:      "dimm_power_watts",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket", "dimm"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		DIMMTemperature: &metrics.CustomMetric{
+			Name:      "dimm_temperature_celsius",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.GaugeValue,
+			Labels:    []string{"socket", "dimm"},
+			Divide:    true,
+			Divisor:   1e3,
+		},
+		ReadingFailures: &metrics.CustomMetric{
+			Name:      "reading_failures_total",
+			Namespace: "amd",
+			HelpText:  "AMD Params",
+			Type:      prometheus.CounterValue,
+			Labels:    []string{"device", "field"},
+		},
 	}
 	// When
 	got := metrics.NewAMDMetrics(&settings)
@@ -187,7 +580,7 @@ func TestCollectAndBuildMetricsWithoutGPUs(t *testing.T) {
 		`Desc{fqName: "amd_prochot_status", help: "AMD Params", constLabels: {}, variableLabels: {prochot_status}}`,
 		`Desc{fqName: "amd_gpu_dev_id", help: "AMD Params", constLabels: {}, variableLabels: {gpu_dev_id,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
 		`Desc{fqName: "amd_gpu_power_cap", help: "AMD Params", constLabels: {}, variableLabels: {gpu_power_cap,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
-		`Desc{fqName: "amd_gpu_power", help: "AMD Params", constLabels: {}, variableLabels: {gpu_power,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
+		`Desc{fqName: "amd_gpu_power_watts", help: "AMD Params", constLabels: {}, variableLabels: {gpu_power_watts,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
 		`Desc{fqName: "amd_gpu_current_temperature", help: "AMD Params", constLabels: {}, variableLabels: {gpu_current_temperature,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
 		`Desc{fqName: "amd_gpu_SCLK", help: "AMD Params", constLabels: {}, variableLabels: {gpu_SCLK,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
 		`Desc{fqName: "amd_gpu_MCLK", help: "AMD Params", constLabels: {}, variableLabels: {gpu_MCLK,productname,device,exported_pod,exported_container,exported_namespace,exported_node}}`,
@@ -197,6 +590,8 @@ func TestCollectAndBuildMetricsWithoutGPUs(t *testing.T) {
 		`Desc{fqName: "amd_num_threads", help: "AMD Params", constLabels: {}, variableLabels: {num_threads}}`,
 		`Desc{fqName: "amd_num_threads_per_core", help: "AMD Params", constLabels: {}, variableLabels: {num_threads_per_core}}`,
 		`Desc{fqName: "amd_num_gpus", help: "AMD Params", constLabels: {}, variableLabels: {num_gpus}}`,
+		`Desc{fqName: "amd_gpu_info", help: "AMD Params", constLabels: {}, variableLabels: {gpu_info,productname,device,sku,guid,unique_id,pci_bus,partition_id,vbios_version,driver_version,gfx_version,smc_firmware_version,mec_firmware_version,sdma_firmware_version,psp_firmware_version}}`,
+		`Desc{fqName: "amd_gpu_numa_info", help: "AMD Params", constLabels: {}, variableLabels: {gpu_numa_info,productname,device,pci_bus,numa_node,socket,local_cpulist,root_complex}}`,
 	}
 
 	// When
@@ -223,13 +618,6 @@ func TestCollectAndBuildMetricsOnlyGPUs(t *testing.T) {
 	amdMetrics.K8SResources = makeK8SResourcesFixture(t)
 
 	want := []prometheus.Metric{
-		metricfixtures.ConstCounterMetric("core_energy", -1, []string{"thread"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("boost_limit", -1, []string{"thread"}, []string{"0"}),
-		metricfixtures.ConstCounterMetric("socket_energy", -1, []string{"socket"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("socket_power", -1, []string{"socket"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("power_limit", -1, []string{"power_limit"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("prochot_status", -1, []string{"prochot_status"}, []string{"0"}),
-
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1"}),
@@ -242,11 +630,11 @@ func TestCollectAndBuildMetricsOnlyGPUs(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
 
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
 
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
@@ -283,6 +671,7 @@ func TestCollectAndBuildMetricsOnlyGPUs(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("num_threads_per_core", 1, []string{"num_threads_per_core"}, []string{""}),
 		metricfixtures.ConstGaugeMetric("num_gpus", 4, []string{"num_gpus"}, []string{""}),
 	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 4)...)
 
 	// When
 	got := amdMetrics.CollectAndBuildMetrics()
@@ -304,13 +693,6 @@ func TestCollectAndBuildMetricsGPUsAndK8SWithLabels(t *testing.T) {
 	amdMetrics.K8SResources = makeK8SResourcesWithLabelsFixture(t)
 
 	want := []prometheus.Metric{
-		metricfixtures.ConstCounterMetric("core_energy", -1, []string{"thread"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("boost_limit", -1, []string{"thread"}, []string{"0"}),
-		metricfixtures.ConstCounterMetric("socket_energy", -1, []string{"socket"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("socket_power", -1, []string{"socket"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("power_limit", -1, []string{"power_limit"}, []string{"0"}),
-		metricfixtures.ConstGaugeMetric("prochot_status", -1, []string{"prochot_status"}, []string{"0"}),
-
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1", "value-1", "value-2"}),
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1", "value-1", "value-2"}),
 		metricfixtures.ConstGaugeMetric("gpu_dev_id", 0, metricfixtures.GPULabels("gpu_dev_id", "label_1", "label_oip_author_username"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1", "value-1", "gpu-user-1"}),
@@ -323,11 +705,11 @@ func TestCollectAndBuildMetricsGPUsAndK8SWithLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
 
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1", "value-1", "value-2"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1", "value-1", "value-2"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power", "label_1", "label_oip_author_username"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1", "value-1", "gpu-user-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1", "value-1", "value-2"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1", "value-1", "value-2"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts", "label_1", "label_oip_author_username"), []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2", "pod-1", "container-1", "team-a", "node-1", "value-1", "gpu-user-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-y", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"3", "amdinstinctmi250(mcm)oamacmba", "amd3", "pod-z", "container-1", "team-a", "node-1"}),
 
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1", "value-1", "value-2"}),
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1", "value-1", "value-2"}),
@@ -364,6 +746,7 @@ func TestCollectAndBuildMetricsGPUsAndK8SWithLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("num_threads_per_core", 1, []string{"num_threads_per_core"}, []string{""}),
 		metricfixtures.ConstGaugeMetric("num_gpus", 4, []string{"num_gpus"}, []string{""}),
 	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 4)...)
 
 	// When
 	got := amdMetrics.CollectAndBuildMetrics()
@@ -372,95 +755,1046 @@ func TestCollectAndBuildMetricsGPUsAndK8SWithLabels(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
-func makeAMDDataFuncFixture(t *testing.T) func() gpus.AMDParams {
-	return func() gpus.AMDParams {
-		t.Helper()
+func TestCollectAndBuildMetricsOmitsReadingsWithoutValue(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
 
-		amdParams := gpus.AMDParams{}
-		amdParams.Init()
+			amdParams.ResizeCPUs(1, 1)
+			amdParams.SocketPower[0] = gpus.FailedReading()
 
-		amdParams.Threads = 1
-		amdParams.Sockets = 1
-		amdParams.ThreadsPerCore = 1
-		amdParams.NumGPUs = 1
-
-		amdParams.NumGPUs = 4
-		amdParams.GPUDevID[0] = float64(0)
-		amdParams.GPUPowerCap[0] = float64(300)
-		amdParams.GPUPower[0] = float64(301)
-		amdParams.GPUTemperature[0] = float64(302)
-		amdParams.GPUSCLK[0] = float64(303)
-		amdParams.GPUMCLK[0] = float64(304)
-		amdParams.GPUUsage[0] = float64(305)
-		amdParams.GPUMemoryUsage[0] = float64(306)
-
-		amdParams.GPUDevID[1] = float64(0)
-		amdParams.GPUPowerCap[1] = float64(300)
-		amdParams.GPUPower[1] = float64(301)
-		amdParams.GPUTemperature[1] = float64(302)
-		amdParams.GPUSCLK[1] = float64(303)
-		amdParams.GPUMCLK[1] = float64(304)
-		amdParams.GPUUsage[1] = float64(305)
-		amdParams.GPUMemoryUsage[1] = float64(306)
-
-		amdParams.GPUDevID[2] = float64(0)
-		amdParams.GPUPowerCap[2] = float64(300)
-		amdParams.GPUPower[2] = float64(301)
-		amdParams.GPUTemperature[2] = float64(302)
-		amdParams.GPUSCLK[2] = float64(303)
-		amdParams.GPUMCLK[2] = float64(304)
-		amdParams.GPUUsage[2] = float64(305)
-		amdParams.GPUMemoryUsage[2] = float64(306)
-
-		amdParams.GPUDevID[3] = float64(0)
-		amdParams.GPUPowerCap[3] = float64(300)
-		amdParams.GPUPower[3] = float64(301)
-		amdParams.GPUTemperature[3] = float64(302)
-		amdParams.GPUSCLK[3] = float64(303)
-		amdParams.GPUMCLK[3] = float64(304)
-		amdParams.GPUUsage[3] = float64(305)
-		amdParams.GPUMemoryUsage[3] = float64(306)
-
-		return amdParams
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUPower[0] = gpus.NewReading(301e6)
+			amdParams.GPUTemperature[0] = gpus.FailedReading()
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
 	}
-}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
 
-func makeAMDDataAllDataFuncFixture(t *testing.T) func() gpus.AMDParams {
-	return func() gpus.AMDParams {
-		t.Helper()
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 301, []string{"gpu_power_watts", "productname", "device"}, []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 1, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 1, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 2, []string{"device", "field"}, []string{"amd0", "gpu_current_temperature"}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 2, []string{"device", "field"}, []string{"socket0", "socket_power"}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	amdMetrics.CollectAndBuildMetrics()
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsMemoryUsage(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUVRAMTotal[0] = gpus.NewReading(64 << 30)
+			amdParams.GPUVRAMUsed[0] = gpus.NewReading(16 << 30)
+			amdParams.GPUVisVRAMUsed[0] = gpus.NewReading(16 << 30)
+			amdParams.GPUGTTUsed[0] = gpus.NewReading(32 << 20)
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SResources = makeK8SResourcesFixture(t)
+
+	podLabelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("vram_total_bytes", 64<<30, metricfixtures.GPULabels("vram_total_bytes"), podLabelValues),
+		metricfixtures.ConstGaugeMetric("vram_used_bytes", 16<<30, metricfixtures.GPULabels("vram_used_bytes"), podLabelValues),
+		metricfixtures.ConstGaugeMetric("vis_vram_used_bytes", 16<<30, metricfixtures.GPULabels("vis_vram_used_bytes"), podLabelValues),
+		metricfixtures.ConstGaugeMetric("gtt_used_bytes", 32<<20, metricfixtures.GPULabels("gtt_used_bytes"), podLabelValues),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsPartitions(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(2)
+			amdParams.GPUUsage[0] = gpus.NewReading(50)
+			amdParams.GPUUsage[1] = gpus.NewReading(20)
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = []gpus.Card{
+		{Cardseries: "AMD Instinct MI300X", PCIBus: "0000:0c:00.0", ComputePartition: "DPX", MemoryPartition: "NPS1"},
+		{
+			Cardseries: "AMD Instinct MI300X", PCIBus: "0000:0c:00.0", ComputePartition: "DPX", MemoryPartition: "NPS1",
+			PartitionID: 1, PartitionDevice: "amdgpu_xcp_0",
+		},
+	}
+	amdMetrics.K8SResources = map[string][]pods.PodInfo{
+		"0000:0c:00.0": {{Name: "pod-a", Namespace: "team-a", Container: "container-1", NodeName: "node-1"}},
+		"amdgpu_xcp_0": {{Name: "pod-b", Namespace: "team-b", Container: "container-1", NodeName: "node-1"}},
+	}
+
+	infoLabels := []string{
+		"gpu_info", "productname", "device", "sku", "guid", "unique_id", "pci_bus", "partition_id",
+		"vbios_version", "driver_version", "gfx_version",
+		"smc_firmware_version", "mec_firmware_version", "sdma_firmware_version", "psp_firmware_version",
+	}
+	partitionLabels := []string{"gpu_partition_mode", "productname", "device", "pci_bus", "compute_partition", "memory_partition"}
+	numaLabels := []string{"gpu_numa_info", "productname", "device", "pci_bus", "numa_node", "socket", "local_cpulist", "root_complex"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_use_percent", 50, metricfixtures.GPULabels("gpu_use_percent"),
+			[]string{"0", "AMD Instinct MI300X", "amd0", "pod-a", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_use_percent", 20, metricfixtures.GPULabels("gpu_use_percent"),
+			[]string{"1", "AMD Instinct MI300X", "amd1", "pod-b", "container-1", "team-b", "node-1"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 2, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("gpu_info", 1, infoLabels,
+			[]string{"0", "AMD Instinct MI300X", "amd0", "", "", "", "0000:0c:00.0", "0", "", "", "", "", "", "", ""}),
+		metricfixtures.ConstGaugeMetric("gpu_numa_info", 1, numaLabels,
+			[]string{"0", "AMD Instinct MI300X", "amd0", "0000:0c:00.0", "", "", "", ""}),
+		metricfixtures.ConstGaugeMetric("gpu_partition_mode", 1, partitionLabels,
+			[]string{"0", "AMD Instinct MI300X", "amd0", "0000:0c:00.0", "DPX", "NPS1"}),
+		metricfixtures.ConstGaugeMetric("gpu_info", 1, infoLabels,
+			[]string{"1", "AMD Instinct MI300X", "amd1", "", "", "", "0000:0c:00.0", "1", "", "", "", "", "", "", ""}),
+		metricfixtures.ConstGaugeMetric("gpu_numa_info", 1, numaLabels,
+			[]string{"1", "AMD Instinct MI300X", "amd1", "0000:0c:00.0", "", "", "", ""}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsGPUNUMALabels(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUUsage[0] = gpus.NewReading(50)
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		GPUNUMALabels:  true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = []gpus.Card{
+		{
+			Cardseries: "AMD Instinct MI300X", PCIBus: "0000:9f:00.0",
+			NUMANode: "1", Socket: "1", LocalCPUs: "48-95,144-191", RootComplex: "0000:80",
+		},
+	}
+	amdMetrics.K8SResources = map[string][]pods.PodInfo{
+		"0000:9f:00.0": {{Name: "pod-a", Namespace: "team-a", Container: "container-1", NodeName: "node-1"}},
+	}
+
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_use_percent", 50,
+			[]string{
+				"gpu_use_percent", "productname", "device", "numa_node", "socket",
+				"exported_pod", "exported_container", "exported_namespace", "exported_node",
+			},
+			[]string{"0", "AMD Instinct MI300X", "amd0", "1", "1", "pod-a", "container-1", "team-a", "node-1"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("gpu_info", 1,
+			[]string{
+				"gpu_info", "productname", "device", "numa_node", "socket", "sku", "guid", "unique_id", "pci_bus", "partition_id",
+				"vbios_version", "driver_version", "gfx_version",
+				"smc_firmware_version", "mec_firmware_version", "sdma_firmware_version", "psp_firmware_version",
+			},
+			[]string{"0", "AMD Instinct MI300X", "amd0", "1", "1", "", "", "", "0000:9f:00.0", "0", "", "", "", "", "", "", ""}),
+		metricfixtures.ConstGaugeMetric("gpu_numa_info", 1,
+			[]string{"gpu_numa_info", "productname", "device", "numa_node", "socket", "pci_bus", "local_cpulist", "root_complex"},
+			[]string{"0", "AMD Instinct MI300X", "amd0", "1", "1", "0000:9f:00.0", "48-95,144-191", "0000:80"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsPowerAndEnergy(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUPower[0] = gpus.NewReading(612e6)
+			amdParams.GPUEnergy[0] = gpus.NewReading(15392.5e6)
+
+			return &amdParams
+		},
+		WithKubernetes:     true,
+		DeprecatedGPUPower: true,
+		Logger:             testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SResources = makeK8SResourcesFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}
+	deprecatedPower := prometheus.MustNewConstMetric(
+		prometheus.NewDesc("amd_gpu_power", "Deprecated, use amd_gpu_power_watts instead.", metricfixtures.GPULabels("gpu_power"), nil),
+		prometheus.GaugeValue,
+		612,
+		labelValues...,
+	)
+	want := []prometheus.Metric{
+		deprecatedPower,
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 612, metricfixtures.GPULabels("gpu_power_watts"), labelValues),
+		metricfixtures.ConstCounterMetric("gpu_energy_joules_total", 15392.5, metricfixtures.GPULabels("gpu_energy_joules_total"), labelValues),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsECCErrors(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUECCCorrectable[gpus.RASBlockUMC][0] = gpus.NewReading(3)
+			amdParams.GPUECCUncorrectable[gpus.RASBlockUMC][0] = gpus.NewReading(0)
+			amdParams.GPUECCCorrectable[gpus.RASBlockXGMI][0] = gpus.NewReading(1)
+			amdParams.GPURetiredPages[0] = gpus.NewReading(2)
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SResources = makeK8SResourcesFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	podLabelValues := []string{"pod-ii", "container-1", "team-b", "node-1"}
+	correctableLabels := []string{"gpu_ecc_correctable_errors_total", "productname", "device", "block", "exported_pod", "exported_container", "exported_namespace", "exported_node"}
+	uncorrectableLabels := []string{"gpu_ecc_uncorrectable_errors_total", "productname", "device", "block", "exported_pod", "exported_container", "exported_namespace", "exported_node"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstCounterMetric("gpu_ecc_correctable_errors_total", 3, correctableLabels, slices.Concat(labelValues, []string{"umc"}, podLabelValues)),
+		metricfixtures.ConstCounterMetric("gpu_ecc_uncorrectable_errors_total", 0, uncorrectableLabels, slices.Concat(labelValues, []string{"umc"}, podLabelValues)),
+		metricfixtures.ConstCounterMetric("gpu_ecc_correctable_errors_total", 1, correctableLabels, slices.Concat(labelValues, []string{"xgmi"}, podLabelValues)),
+		metricfixtures.ConstCounterMetric("gpu_retired_pages_total", 2, metricfixtures.GPULabels("gpu_retired_pages_total"), slices.Concat(labelValues, podLabelValues)),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsTemperatureSensors(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUTemperatures[gpus.TemperatureSensorEdge][0] = gpus.NewReading(41e3)
+			amdParams.GPUTemperatures[gpus.TemperatureSensorJunction][0] = gpus.NewReading(48e3)
+			amdParams.GPUTemperatureCritical[gpus.TemperatureSensorJunction][0] = gpus.NewReading(105e3)
+			amdParams.GPUTemperatureEmergency[gpus.TemperatureSensorJunction][0] = gpus.NewReading(110e3)
+			amdParams.GPUFanSpeed[0] = gpus.NewReading(1200)
+			amdParams.GPUVoltage[gpus.VoltageRailGFX][0] = gpus.NewReading(806)
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	temperatureLabels := []string{"gpu_temperature_celsius", "productname", "device", "sensor"}
+	criticalLabels := []string{"gpu_temperature_critical_celsius", "productname", "device", "sensor"}
+	emergencyLabels := []string{"gpu_temperature_emergency_celsius", "productname", "device", "sensor"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_temperature_celsius", 41, temperatureLabels, slices.Concat(labelValues, []string{"edge"})),
+		metricfixtures.ConstGaugeMetric("gpu_temperature_celsius", 48, temperatureLabels, slices.Concat(labelValues, []string{"junction"})),
+		metricfixtures.ConstGaugeMetric("gpu_temperature_critical_celsius", 105, criticalLabels, slices.Concat(labelValues, []string{"junction"})),
+		metricfixtures.ConstGaugeMetric("gpu_temperature_emergency_celsius", 110, emergencyLabels, slices.Concat(labelValues, []string{"junction"})),
+		metricfixtures.ConstGaugeMetric("gpu_fan_speed_rpm", 1200, []string{"gpu_fan_speed_rpm", "productname", "device"}, labelValues),
+		metricfixtures.ConstGaugeMetric("gpu_voltage_volts", 0.806, []string{"gpu_voltage_volts", "productname", "device", "rail"}, slices.Concat(labelValues, []string{"gfx"})),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsAverageClocks(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUAverageClocks[gpus.ClockDomainGFX][0] = gpus.NewReading(2100e6)
+			amdParams.GPUAverageClocks[gpus.ClockDomainMemory][0] = gpus.NewReading(1300e6)
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	clockLabels := []string{"gpu_average_clock_hertz", "productname", "device", "clock"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_average_clock_hertz", 2100e6, clockLabels, slices.Concat(labelValues, []string{"gfx"})),
+		metricfixtures.ConstGaugeMetric("gpu_average_clock_hertz", 1300e6, clockLabels, slices.Concat(labelValues, []string{"memory"})),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsCPUTemperatures(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeCPUs(2, 0)
+			amdParams.SocketTemperatures[gpus.SocketTemperatureTctl][0] = gpus.NewReading(61250)
+			amdParams.SocketTemperatures[gpus.SocketTemperatureTctl][1] = gpus.NewReading(58e3)
+			amdParams.SocketTemperatures[gpus.SocketTemperatureTdie][1] = gpus.NewReading(48e3)
+			amdParams.CCDTemperatures[0] = []gpus.Reading{gpus.NewReading(55e3), {}, gpus.NewReading(57500)}
+			amdParams.CCDTemperatures[1] = []gpus.Reading{gpus.FailedReading()}
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+
+	socketLabels := []string{"socket", "sensor"}
+	ccdLabels := []string{"socket", "ccd"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("socket_temperature_celsius", 61.25, socketLabels, []string{"0", "tctl"}),
+		metricfixtures.ConstGaugeMetric("socket_temperature_celsius", 58, socketLabels, []string{"1", "tctl"}),
+		metricfixtures.ConstGaugeMetric("socket_temperature_celsius", 48, socketLabels, []string{"1", "tdie"}),
+		metricfixtures.ConstGaugeMetric("ccd_temperature_celsius", 55, ccdLabels, []string{"0", "0"}),
+		metricfixtures.ConstGaugeMetric("ccd_temperature_celsius", 57.5, ccdLabels, []string{"0", "2"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 2, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"socket1", "ccd_temperature_celsius"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsSocketTelemetry(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeCPUs(1, 0)
+			amdParams.SocketDDRBandwidth[0] = gpus.NewReading(115e9)
+			amdParams.SocketDDRMaxBandwidth[0] = gpus.NewReading(460e9)
+			amdParams.SocketDDRUtilization[0] = gpus.NewReading(25)
+			amdParams.SocketFCLK[0] = gpus.NewReading(2e9)
+			amdParams.SocketMCLK[0] = gpus.NewReading(2.4e9)
+			amdParams.SocketC0Residency[0] = gpus.FailedReading()
+			amdParams.SocketFrequencyLimit[0] = gpus.NewReading(3.1e9)
+			amdParams.SocketFrequencyLimited[gpus.FrequencyLimitPPT][0] = gpus.NewReading(1)
+			amdParams.SocketFrequencyLimited[gpus.FrequencyLimitHSMP][0] = gpus.NewReading(0)
+			amdParams.SocketSVIPower[0] = gpus.NewReading(41250)
+			amdParams.SocketDIMMs[0] = []gpus.DIMM{
+				{Address: 0x80, Power: gpus.NewReading(4500), Temperature: gpus.NewReading(42500)},
+				{Address: 0x0a, Power: gpus.NewReading(3000)},
+			}
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+
+	socketLabels := []string{"socket"}
+	dimmLabels := []string{"socket", "dimm"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("socket_ddr_bandwidth_bytes_per_second", 115e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_ddr_max_bandwidth_bytes_per_second", 460e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_ddr_bandwidth_utilization_percent", 25, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_fclk_hertz", 2e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_mclk_hertz", 2.4e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_frequency_limit_hertz", 3.1e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("socket_frequency_limit_status", 1, []string{"socket", "source"}, []string{"0", "ppt"}),
+		metricfixtures.ConstGaugeMetric("socket_frequency_limit_status", 0, []string{"socket", "source"}, []string{"0", "hsmp"}),
+		metricfixtures.ConstGaugeMetric("socket_svi_power_watts", 41.25, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("dimm_power_watts", 4.5, dimmLabels, []string{"0", "0x80"}),
+		metricfixtures.ConstGaugeMetric("dimm_power_watts", 3, dimmLabels, []string{"0", "0x0a"}),
+		metricfixtures.ConstGaugeMetric("dimm_temperature_celsius", 42.5, dimmLabels, []string{"0", "0x80"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 1, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"socket0", "socket_c0_residency_percent"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsCPUStats(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeCPUs(1, 2)
+			amdParams.CoreEnergy[1] = gpus.NewReading(2e6)
+			amdParams.ResizeCPUStats(2)
+			amdParams.CPUSeconds[gpus.CPUModeUser][1] = gpus.NewReading(1520.5)
+			amdParams.CPUSeconds[gpus.CPUModeIdle][1] = gpus.NewReading(84210.25)
+			amdParams.CPUFrequency[0] = gpus.FailedReading()
+			amdParams.CPUFrequency[1] = gpus.NewReading(3.1e9)
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+
+	secondsLabels := []string{"thread", "mode"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstCounterMetric("core_energy", 2e6, []string{"thread"}, []string{"1"}),
+		metricfixtures.ConstCounterMetric("cpu_seconds_total", 1520.5, secondsLabels, []string{"1", "user"}),
+		metricfixtures.ConstCounterMetric("cpu_seconds_total", 84210.25, secondsLabels, []string{"1", "idle"}),
+		metricfixtures.ConstGaugeMetric("cpu_frequency_hertz", 3.1e9, []string{"thread"}, []string{"1"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 1, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 2, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"thread0", "cpu_frequency_hertz"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsCPUAggregation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[metrics.CPUAggregation][]prometheus.Metric{
+		metrics.CPUAggregationCore: {
+			metricfixtures.ConstCounterMetric("core_energy", 10, []string{"socket", "core"}, []string{"0", "0"}),
+			metricfixtures.ConstCounterMetric("core_energy", 20, []string{"socket", "core"}, []string{"0", "1"}),
+			metricfixtures.ConstCounterMetric("core_energy", 30, []string{"socket", "core"}, []string{"0", "8"}),
+			metricfixtures.ConstCounterMetric("core_energy", 40, []string{"socket", "core"}, []string{"1", "0"}),
+		},
+		metrics.CPUAggregationCCX: {
+			metricfixtures.ConstCounterMetric("core_energy", 30, []string{"socket", "ccx"}, []string{"0", "0"}),
+			metricfixtures.ConstCounterMetric("core_energy", 30, []string{"socket", "ccx"}, []string{"0", "1"}),
+			metricfixtures.ConstCounterMetric("core_energy", 40, []string{"socket", "ccx"}, []string{"1", "16"}),
+		},
+		metrics.CPUAggregationSocket: {
+			metricfixtures.ConstCounterMetric("core_energy", 60, []string{"socket"}, []string{"0"}),
+			metricfixtures.ConstCounterMetric("core_energy", 40, []string{"socket"}, []string{"1"}),
+		},
+	}
+
+	for level, want := range tests {
+		t.Run(string(level), func(t *testing.T) {
+			t.Parallel()
+			// Given
+			settings := metrics.Setup{
+				AMDParamsHandler: func() *gpus.AMDParams {
+					amdParams := makeCPUTopologyFixture(t)
+
+					// SMT siblings report the energy of their core and the placement of the seventh thread is unknown.
+					for i, energy := range []float64{10, 20, 30, 40, 10, 20, 99, 40} {
+						amdParams.CoreEnergy[i] = gpus.NewReading(energy)
+					}
+
+					return amdParams
+				},
+				Logger:         testlogs.NewLogger(),
+				CPUAggregation: level,
+			}
+			amdMetrics := metrics.NewAMDMetrics(&settings)
+
+			want = append(want,
+				metricfixtures.ConstGaugeMetric("num_sockets", 2, []string{"num_sockets"}, []string{""}),
+				metricfixtures.ConstGaugeMetric("num_threads", 8, []string{"num_threads"}, []string{""}),
+				metricfixtures.ConstGaugeMetric("num_threads_per_core", 2, []string{"num_threads_per_core"}, []string{""}),
+				metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+			)
+
+			// When
+			got := amdMetrics.CollectAndBuildMetrics()
+
+			// Then
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestCollectAndBuildMetricsCPUAggregationBySocket(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := makeCPUTopologyFixture(t)
+
+			for i, boost := range []float64{3700, 3700, 3000, 3700, 3700, 3700, 3700} {
+				amdParams.CoreBoost[i] = gpus.NewReading(boost)
+			}
+
+			amdParams.CoreBoost[7] = gpus.FailedReading()
+
+			amdParams.ResizeCPUStats(8)
+
+			for i := range 8 {
+				amdParams.CPUSeconds[gpus.CPUModeUser][i] = gpus.NewReading(float64(i))
+				amdParams.CPUFrequency[i] = gpus.NewReading(float64(i%2+1) * 1e9)
+			}
+
+			return amdParams
+		},
+		Logger:         testlogs.NewLogger(),
+		CPUAggregation: metrics.CPUAggregationSocket,
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+
+	socketLabels := []string{"socket"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("boost_limit", 3000, socketLabels, []string{"0"}),
+		metricfixtures.ConstCounterMetric("cpu_seconds_total", 0+1+2+4+5, []string{"socket", "mode"}, []string{"0", "user"}),
+		metricfixtures.ConstCounterMetric("cpu_seconds_total", 3+7, []string{"socket", "mode"}, []string{"1", "user"}),
+		metricfixtures.ConstGaugeMetric("cpu_frequency_hertz", 1.4e9, socketLabels, []string{"0"}),
+		metricfixtures.ConstGaugeMetric("cpu_frequency_hertz", 2e9, socketLabels, []string{"1"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 2, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 8, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 2, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"socket1", "boost_limit"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsCPUAggregationWithoutTopology(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeCPUs(1, 2)
+			amdParams.CoreEnergy[0] = gpus.NewReading(10)
+			amdParams.CoreEnergy[1] = gpus.FailedReading()
+			amdParams.ResizeCPUStats(2)
+			amdParams.CPUSeconds[gpus.CPUModeIdle][1] = gpus.NewReading(42)
+
+			return &amdParams
+		},
+		Logger:         testlogs.NewLogger(),
+		CPUAggregation: metrics.CPUAggregationCore,
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+
+	want := []prometheus.Metric{
+		metricfixtures.ConstCounterMetric("core_energy", 10, []string{"thread"}, []string{"0"}),
+		metricfixtures.ConstCounterMetric("cpu_seconds_total", 42, []string{"thread", "mode"}, []string{"1", "idle"}),
+		metricfixtures.ConstGaugeMetric("num_sockets", 1, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 2, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 0, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"thread1", "core_energy"}),
+	}
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestParseCPUAggregation(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]metrics.CPUAggregation{
+		"":       metrics.CPUAggregationThread,
+		"thread": metrics.CPUAggregationThread,
+		"core":   metrics.CPUAggregationCore,
+		"ccx":    metrics.CPUAggregationCCX,
+		"socket": metrics.CPUAggregationSocket,
+	}
+
+	for value, want := range tests {
+		t.Run(value, func(t *testing.T) {
+			t.Parallel()
+			// When
+			got, err := metrics.ParseCPUAggregation(value)
+
+			// Then
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestParseCPUAggregationUnknown(t *testing.T) {
+	t.Parallel()
+	// When
+	got, err := metrics.ParseCPUAggregation("numa")
+
+	// Then
+	require.Error(t, err)
+	assert.Empty(t, got)
+}
+
+// makeCPUTopologyFixture returns params of two sockets and eight threads, the first socket has two
+// cores in its first CCX and one in its second CCX, threads 4, 5 and 7 are SMT siblings of threads 0, 1 and 3.
+func makeCPUTopologyFixture(t *testing.T) *gpus.AMDParams {
+	t.Helper()
+
+	amdParams := gpus.AMDParams{}
+	amdParams.Init()
+
+	amdParams.ResizeCPUs(2, 8)
+	amdParams.ThreadsPerCore = 2
+	amdParams.CPUTopology = []gpus.CPUPlacement{
+		gpus.NewCPUPlacement(0, 0, 0),
+		gpus.NewCPUPlacement(0, 1, 0),
+		gpus.NewCPUPlacement(0, 8, 1),
+		gpus.NewCPUPlacement(1, 0, 16),
+		gpus.NewCPUPlacement(0, 0, 0),
+		gpus.NewCPUPlacement(0, 1, 0),
+		{},
+		gpus.NewCPUPlacement(1, 0, 16),
+	}
+
+	return &amdParams
+}
+
+func TestCollectAndBuildMetricsThrottleReasons(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(1)
+			amdParams.GPUThrottled[gpus.ThrottleReasonThermal][0] = gpus.NewReading(0)
+			amdParams.GPUThrottled[gpus.ThrottleReasonPower][0] = gpus.NewReading(1)
+			amdParams.GPUThrottledSeconds[gpus.ThrottleReasonThermal][0] = gpus.NewReading(3.5)
+			amdParams.GPUThrottledSeconds[gpus.ThrottleReasonPower][0] = gpus.NewReading(120)
+
+			return &amdParams
+		},
+		Logger: testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	statusLabels := []string{"gpu_throttle_status", "productname", "device", "reason"}
+	secondsLabels := []string{"gpu_throttled_seconds_total", "productname", "device", "reason"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric("gpu_throttle_status", 0, statusLabels, slices.Concat(labelValues, []string{"thermal"})),
+		metricfixtures.ConstCounterMetric("gpu_throttled_seconds_total", 3.5, secondsLabels, slices.Concat(labelValues, []string{"thermal"})),
+		metricfixtures.ConstGaugeMetric("gpu_throttle_status", 1, statusLabels, slices.Concat(labelValues, []string{"power"})),
+		metricfixtures.ConstCounterMetric("gpu_throttled_seconds_total", 120, secondsLabels, slices.Concat(labelValues, []string{"power"})),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 1, []string{"num_gpus"}, []string{""}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 1)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsXGMILinks(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(2)
+			amdParams.GPUXGMILinks[0] = []gpus.XGMILink{
+				{
+					Peer:       1,
+					Status:     gpus.NewReading(1),
+					ReadBytes:  gpus.NewReading(4096),
+					WriteBytes: gpus.FailedReading(),
+				},
+			}
+			amdParams.GPUXGMIWidth[0] = gpus.NewReading(16)
+			amdParams.GPUXGMISpeed[0] = gpus.NewReading(32)
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SResources = makeK8SResourcesFixture(t)
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "0000:b3:00.0", "amd1", "0000:8e:00.0"}
+	podLabelValues := []string{"pod-ii", "container-1", "team-b", "node-1"}
+	linkLabels := func(name string) []string {
+		return []string{
+			name, "productname", "device", "pci_bus", "peer_device", "peer_pci_bus",
+			"exported_pod", "exported_container", "exported_namespace", "exported_node",
+		}
+	}
+	gpuLabelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric(
+			"gpu_xgmi_width", 16, metricfixtures.GPULabels("gpu_xgmi_width"), slices.Concat(gpuLabelValues, podLabelValues),
+		),
+		metricfixtures.ConstGaugeMetric(
+			"gpu_xgmi_speed_gbps", 32, metricfixtures.GPULabels("gpu_xgmi_speed_gbps"), slices.Concat(gpuLabelValues, podLabelValues),
+		),
+		metricfixtures.ConstGaugeMetric("gpu_xgmi_link_status", 1, linkLabels("gpu_xgmi_link_status"), slices.Concat(labelValues, podLabelValues)),
+		metricfixtures.ConstCounterMetric("gpu_xgmi_read_bytes_total", 4096, linkLabels("gpu_xgmi_read_bytes_total"), slices.Concat(labelValues, podLabelValues)),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 2, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"amd0", "gpu_xgmi_write_bytes_total"}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 2)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsContainerVRAM(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(2)
+			amdParams.GPUProcesses[0] = []gpus.Process{
+				{PID: 100, ContainerID: "container-b", VRAMUsed: gpus.NewReading(1 << 30)},
+				{PID: 101, ContainerID: "container-a", VRAMUsed: gpus.NewReading(2 << 30)},
+				{PID: 102, ContainerID: "container-b", VRAMUsed: gpus.NewReading(3 << 30)},
+				{PID: 103, VRAMUsed: gpus.NewReading(4 << 30)},
+				{PID: 104, ContainerID: "unknown", VRAMUsed: gpus.NewReading(5 << 30)},
+			}
+			amdParams.GPUProcesses[1] = []gpus.Process{
+				{PID: 100, ContainerID: "container-b", VRAMUsed: gpus.FailedReading()},
+			}
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SContainers = map[string]pods.PodInfo{
+		"container-a": {Name: "pod-a", Namespace: "team-a", Container: "trainer", NodeName: "node-1"},
+		"container-b": {Name: "pod-b", Namespace: "team-b", Container: "inference", NodeName: "node-1"},
+	}
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	want := []prometheus.Metric{
+		metricfixtures.ConstGaugeMetric(
+			"container_gpu_vram_used_bytes", 2<<30, metricfixtures.GPULabels("container_gpu_vram_used_bytes"),
+			slices.Concat(labelValues, []string{"pod-a", "trainer", "team-a", "node-1"}),
+		),
+		metricfixtures.ConstGaugeMetric(
+			"container_gpu_vram_used_bytes", 4<<30, metricfixtures.GPULabels("container_gpu_vram_used_bytes"),
+			slices.Concat(labelValues, []string{"pod-b", "inference", "team-b", "node-1"}),
+		),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 2, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"amd1", "container_gpu_vram_used_bytes"}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 2)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func TestCollectAndBuildMetricsContainerEngines(t *testing.T) {
+	t.Parallel()
+	// Given
+	settings := metrics.Setup{
+		AMDParamsHandler: func() *gpus.AMDParams {
+			amdParams := gpus.AMDParams{}
+			amdParams.Init()
+
+			amdParams.ResizeGPUs(2)
+			amdParams.GPUContainers[0] = []gpus.ContainerUsage{
+				{EngineSeconds: [gpus.NumEngines]gpus.Reading{gpus.EngineGFX: gpus.NewReading(9)}},
+				{
+					ContainerID: "container-a",
+					EngineSeconds: [gpus.NumEngines]gpus.Reading{
+						gpus.EngineGFX:     gpus.NewReading(2.5),
+						gpus.EngineCompute: gpus.NewReading(4.5),
+					},
+				},
+			}
+			amdParams.GPUContainers[1] = []gpus.ContainerUsage{
+				{
+					ContainerID:   "container-a",
+					EngineSeconds: [gpus.NumEngines]gpus.Reading{gpus.EngineDMA: gpus.FailedReading()},
+				},
+			}
+
+			return &amdParams
+		},
+		WithKubernetes: true,
+		Logger:         testlogs.NewLogger(),
+	}
+	amdMetrics := metrics.NewAMDMetrics(&settings)
+	amdMetrics.CardsInfo = makeCardInfoFixture(t)
+	amdMetrics.K8SContainers = map[string]pods.PodInfo{
+		"container-a": {Name: "pod-a", Namespace: "team-a", Container: "trainer", NodeName: "node-1"},
+	}
+
+	labelValues := []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0"}
+	podLabelValues := []string{"pod-a", "trainer", "team-a", "node-1"}
+	engineLabels := []string{
+		"container_gpu_drm_engine_seconds_total", "productname", "device", "engine",
+		"exported_pod", "exported_container", "exported_namespace", "exported_node",
+	}
+	want := []prometheus.Metric{
+		metricfixtures.ConstCounterMetric("container_gpu_drm_engine_seconds_total", 2.5, engineLabels, slices.Concat(labelValues, []string{"gfx"}, podLabelValues)),
+		metricfixtures.ConstCounterMetric("container_gpu_drm_engine_seconds_total", 4.5, engineLabels, slices.Concat(labelValues, []string{"compute"}, podLabelValues)),
+		metricfixtures.ConstGaugeMetric("num_sockets", 0, []string{"num_sockets"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads", 0, []string{"num_threads"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
+		metricfixtures.ConstGaugeMetric("num_gpus", 2, []string{"num_gpus"}, []string{""}),
+		metricfixtures.ConstCounterMetric("reading_failures_total", 1, []string{"device", "field"}, []string{"amd1", "container_gpu_drm_engine_seconds_total"}),
+	}
+	want = append(want, makeGPUInfoMetricsFixture(t, 2)...)
+
+	// When
+	got := amdMetrics.CollectAndBuildMetrics()
+
+	// Then
+	assert.Equal(t, want, got)
+}
+
+func makeAMDDataFuncFixture(t *testing.T) func() *gpus.AMDParams {
+	return func() *gpus.AMDParams {
+		t.Helper()
 
 		amdParams := gpus.AMDParams{}
 		amdParams.Init()
 
-		amdParams.Threads = 1
-		amdParams.Sockets = 1
+		amdParams.ResizeCPUs(1, 1)
 		amdParams.ThreadsPerCore = 1
-		amdParams.NumGPUs = 1
-
-		amdParams.CoreBoost[0] = float64(1)
-		amdParams.CoreEnergy[0] = float64(2)
-		amdParams.PowerLimit[0] = float64(3)
-		amdParams.ProchotStatus[0] = float64(4)
-		amdParams.SocketEnergy[0] = float64(5)
-		amdParams.SocketPower[0] = float64(6)
-
-		amdParams.GPUDevID[0] = float64(0)
-		amdParams.GPUPowerCap[0] = float64(300)
-		amdParams.GPUPower[0] = float64(301)
-		amdParams.GPUTemperature[0] = float64(302)
-		amdParams.GPUSCLK[0] = float64(303)
-		amdParams.GPUMCLK[0] = float64(304)
-		amdParams.GPUUsage[0] = float64(305)
-		amdParams.GPUMemoryUsage[0] = float64(306)
-
-		return amdParams
+
+		amdParams.ResizeGPUs(4)
+		amdParams.GPUDevID[0] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[0] = gpus.NewReading(300)
+		amdParams.GPUPower[0] = gpus.NewReading(301)
+		amdParams.GPUTemperature[0] = gpus.NewReading(302)
+		amdParams.GPUSCLK[0] = gpus.NewReading(303)
+		amdParams.GPUMCLK[0] = gpus.NewReading(304)
+		amdParams.GPUUsage[0] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[0] = gpus.NewReading(306)
+
+		amdParams.GPUDevID[1] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[1] = gpus.NewReading(300)
+		amdParams.GPUPower[1] = gpus.NewReading(301)
+		amdParams.GPUTemperature[1] = gpus.NewReading(302)
+		amdParams.GPUSCLK[1] = gpus.NewReading(303)
+		amdParams.GPUMCLK[1] = gpus.NewReading(304)
+		amdParams.GPUUsage[1] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[1] = gpus.NewReading(306)
+
+		amdParams.GPUDevID[2] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[2] = gpus.NewReading(300)
+		amdParams.GPUPower[2] = gpus.NewReading(301)
+		amdParams.GPUTemperature[2] = gpus.NewReading(302)
+		amdParams.GPUSCLK[2] = gpus.NewReading(303)
+		amdParams.GPUMCLK[2] = gpus.NewReading(304)
+		amdParams.GPUUsage[2] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[2] = gpus.NewReading(306)
+
+		amdParams.GPUDevID[3] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[3] = gpus.NewReading(300)
+		amdParams.GPUPower[3] = gpus.NewReading(301)
+		amdParams.GPUTemperature[3] = gpus.NewReading(302)
+		amdParams.GPUSCLK[3] = gpus.NewReading(303)
+		amdParams.GPUMCLK[3] = gpus.NewReading(304)
+		amdParams.GPUUsage[3] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[3] = gpus.NewReading(306)
+
+		return &amdParams
 	}
 }
 
-func makeCardInfoFixture(t *testing.T) [24]gpus.Card {
+func makeAMDDataAllDataFuncFixture(t *testing.T) func() *gpus.AMDParams {
+	return func() *gpus.AMDParams {
+		t.Helper()
+
+		amdParams := gpus.AMDParams{}
+		amdParams.Init()
+
+		amdParams.ResizeCPUs(1, 1)
+		amdParams.ThreadsPerCore = 1
+		amdParams.ResizeGPUs(1)
+
+		amdParams.CoreBoost[0] = gpus.NewReading(1)
+		amdParams.CoreEnergy[0] = gpus.NewReading(2)
+		amdParams.PowerLimit[0] = gpus.NewReading(3)
+		amdParams.ProchotStatus[0] = gpus.NewReading(4)
+		amdParams.SocketEnergy[0] = gpus.NewReading(5)
+		amdParams.SocketPower[0] = gpus.NewReading(6)
+
+		amdParams.GPUDevID[0] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[0] = gpus.NewReading(300)
+		amdParams.GPUPower[0] = gpus.NewReading(301)
+		amdParams.GPUTemperature[0] = gpus.NewReading(302)
+		amdParams.GPUSCLK[0] = gpus.NewReading(303)
+		amdParams.GPUMCLK[0] = gpus.NewReading(304)
+		amdParams.GPUUsage[0] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[0] = gpus.NewReading(306)
+
+		return &amdParams
+	}
+}
+
+func makeCardInfoFixture(t *testing.T) []gpus.Card {
 	t.Helper()
 
-	return [24]gpus.Card{
+	return []gpus.Card{
 		0: {
 			Cardseries: "amdinstinctmi250(mcm)oamacmba",
 			Cardmodel:  "0x740c",
@@ -496,6 +1830,31 @@ func makeCardInfoFixture(t *testing.T) [24]gpus.Card {
 	}
 }
 
+func makeGPUInfoMetricsFixture(t *testing.T, numGPUs int) []prometheus.Metric {
+	t.Helper()
+
+	labels := []string{
+		"gpu_info", "productname", "device", "sku", "guid", "unique_id", "pci_bus", "partition_id",
+		"vbios_version", "driver_version", "gfx_version",
+		"smc_firmware_version", "mec_firmware_version", "sdma_firmware_version", "psp_firmware_version",
+	}
+	numaLabels := []string{"gpu_numa_info", "productname", "device", "pci_bus", "numa_node", "socket", "local_cpulist", "root_complex"}
+
+	var result []prometheus.Metric
+
+	for i, card := range makeCardInfoFixture(t)[:numGPUs] {
+		labelValues := []string{
+			strconv.Itoa(i), card.Cardseries, "amd" + strconv.Itoa(i), card.CardSKU, card.CardGUID, "", card.PCIBus, "0",
+			"", "", "", "", "", "", "",
+		}
+		result = append(result, metricfixtures.ConstGaugeMetric("gpu_info", 1, labels, labelValues))
+		result = append(result, metricfixtures.ConstGaugeMetric("gpu_numa_info", 1, numaLabels,
+			[]string{strconv.Itoa(i), card.Cardseries, "amd" + strconv.Itoa(i), card.PCIBus, "", "", "", ""}))
+	}
+
+	return result
+}
+
 func makeK8SResourcesFixture(t *testing.T) map[string][]pods.PodInfo {
 	t.Helper()
 
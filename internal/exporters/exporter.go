@@ -5,47 +5,60 @@ import (
 	"fmt"
 	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
-	"github.com/prometheus/client_golang/prometheus"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/metrics"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/pods"
 	"github.com/openinnovationai/k8s-amd-exporter/internal/kubernetes"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type Setup struct {
 	K8SClient      *kubernetes.Client
-	CardsInfo      [gpus.MaxNumGPUDevices]gpus.Card
+	CardsInfo      []gpus.Card
 	Logger         *slog.Logger
 	GetMetricsFunc gpus.AMDParamsHandler
 	// list of custom labels required for pods.
 	OIPLabels      []string
 	WithKubernetes bool
+	// DeprecatedGPUPower enables the deprecated amd_gpu_power metric.
+	DeprecatedGPUPower bool
+	// CPUAggregation is the level thread readings of CPU metrics are aggregated to.
+	CPUAggregation metrics.CPUAggregation
+	// GPUNUMALabels adds the NUMA node and socket of the GPU to every GPU metric.
+	GPUNUMALabels bool
 }
 
 // Exporter implements logic about scanning metrics from environment
 // and from applications running within the gpu environment.
 type Exporter struct {
-	k8sClient      *kubernetes.Client
-	cardsInfo      [gpus.MaxNumGPUDevices]gpus.Card
-	getMetricsFunc gpus.AMDParamsHandler
-	amdMetrics     *metrics.AMDMetrics
-	oipLabels      []string
-	withKubernetes bool
-	logger         *slog.Logger
+	k8sClient          *kubernetes.Client
+	cardsInfo          []gpus.Card
+	getMetricsFunc     gpus.AMDParamsHandler
+	amdMetrics         *metrics.AMDMetrics
+	oipLabels          []string
+	withKubernetes     bool
+	deprecatedGPUPower bool
+	logger             *slog.Logger
+	cpuAggregation     metrics.CPUAggregation
+	gpuNUMALabels      bool
 }
 
 var gkeMigDeviceIDRegex = regexp.MustCompile(`^amd([0-9]+)/gi([0-9]+)$`)
 
 func NewExporter(settings *Setup) *Exporter {
 	newScanner := Exporter{
-		k8sClient:      settings.K8SClient,
-		logger:         settings.Logger,
-		cardsInfo:      settings.CardsInfo,
-		getMetricsFunc: settings.GetMetricsFunc,
-		withKubernetes: settings.WithKubernetes,
-		oipLabels:      settings.OIPLabels,
+		k8sClient:          settings.K8SClient,
+		logger:             settings.Logger,
+		cardsInfo:          settings.CardsInfo,
+		getMetricsFunc:     settings.GetMetricsFunc,
+		withKubernetes:     settings.WithKubernetes,
+		deprecatedGPUPower: settings.DeprecatedGPUPower,
+		oipLabels:          settings.OIPLabels,
+		cpuAggregation:     settings.CPUAggregation,
+		gpuNUMALabels:      settings.GPUNUMALabels,
 	}
 
 	newScanner.makeCollector()
@@ -55,9 +68,12 @@ func NewExporter(settings *Setup) *Exporter {
 
 func (e *Exporter) makeCollector() {
 	settings := metrics.Setup{
-		AMDParamsHandler: e.getMetricsFunc,
-		WithKubernetes:   e.withKubernetes,
-		Logger:           e.logger,
+		AMDParamsHandler:   e.scanMetrics,
+		WithKubernetes:     e.withKubernetes,
+		DeprecatedGPUPower: e.deprecatedGPUPower,
+		CPUAggregation:     e.cpuAggregation,
+		GPUNUMALabels:      e.gpuNUMALabels,
+		Logger:             e.logger,
 	}
 	e.amdMetrics = metrics.NewAMDMetrics(&settings)
 	e.amdMetrics.CardsInfo = e.cardsInfo
@@ -72,6 +88,15 @@ func (e *Exporter) Describe(descStream chan<- *prometheus.Desc) {
 	descStream <- e.amdMetrics.DataDesc.NewDesc()
 }
 
+// scanMetrics scans AMD metrics and then the pods of the containers running GPU processes,
+// so that pods are only looked up for the containers found by the scan.
+func (e *Exporter) scanMetrics() *gpus.AMDParams {
+	data := e.getMetricsFunc()
+	e.amdMetrics.K8SContainers = e.scanK8SContainers(context.TODO(), data)
+
+	return data
+}
+
 // Collect is called by the Prometheus registry when collecting
 // metrics.
 func (e *Exporter) Collect(metricStream chan<- prometheus.Metric) {
@@ -137,6 +162,51 @@ func (e *Exporter) scanK8SResources(ctx context.Context) (map[string][]pods.PodI
 	return deviceToPodMap, nil
 }
 
+// scanK8SContainers scans the containers running the GPU processes of the given readings in order to map
+// them with pods, processes are not mapped when containers could not be scanned.
+func (e *Exporter) scanK8SContainers(ctx context.Context, data *gpus.AMDParams) map[string]pods.PodInfo {
+	containerIDs := gpuContainerIDs(data)
+	if !e.withKubernetes || len(containerIDs) == 0 {
+		return make(map[string]pods.PodInfo)
+	}
+
+	containers, err := e.k8sClient.GetContainers(ctx, containerIDs, e.oipLabels)
+	if err != nil {
+		e.logger.Error("getting containers within node", slog.String("error", err.Error()))
+
+		return make(map[string]pods.PodInfo)
+	}
+
+	return containers
+}
+
+// gpuContainerIDs returns the sorted IDs of the containers running the GPU processes of the given readings.
+func gpuContainerIDs(data *gpus.AMDParams) []string {
+	var containerIDs []string
+
+	for i := range data.GPUProcesses {
+		for j := range data.GPUProcesses[i] {
+			containerIDs = append(containerIDs, data.GPUProcesses[i][j].ContainerID)
+		}
+	}
+
+	for i := range data.GPUContainers {
+		for j := range data.GPUContainers[i] {
+			containerIDs = append(containerIDs, data.GPUContainers[i][j].ContainerID)
+		}
+	}
+
+	slices.Sort(containerIDs)
+	containerIDs = slices.Compact(containerIDs)
+
+	// processes running outside containers have no container ID.
+	if len(containerIDs) > 0 && containerIDs[0] == "" {
+		containerIDs = containerIDs[1:]
+	}
+
+	return containerIDs
+}
+
 // calculateAdditionalDeviceIDs calculate other possible device ids for pods based
 // on the device id returned by the kubelete pod resources api.
 func calculateAdditionalDeviceIDs(deviceID string) []string {
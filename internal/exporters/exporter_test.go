@@ -1,6 +1,7 @@
 package exporters_test
 
 import (
+	"strconv"
 	"testing"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters"
@@ -28,7 +29,7 @@ func TestCollectWithoutAdditionalLabels(t *testing.T) {
 		),
 	)
 
-	cardsInfo := [24]gpus.Card{
+	cardsInfo := []gpus.Card{
 		0: {
 			Cardseries: "amdinstinctmi250(mcm)oamacmba",
 			Cardmodel:  "0x740c",
@@ -78,9 +79,9 @@ func TestCollectWithoutAdditionalLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, []string{"gpu_power_cap", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
 
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, []string{"gpu_power", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, []string{"gpu_power_watts", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
 
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "node-1"}),
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "node-1"}),
@@ -107,6 +108,7 @@ func TestCollectWithoutAdditionalLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
 		metricfixtures.ConstGaugeMetric("num_gpus", 3, []string{"num_gpus"}, []string{""}),
 	}
+	want = append(want, makeGPUInfoMetricsFixture(t, cardsInfo)...)
 
 	// When
 	go func() {
@@ -135,7 +137,7 @@ func TestCollectWithAdditionalLabels(t *testing.T) {
 		),
 	)
 
-	cardsInfo := [24]gpus.Card{
+	cardsInfo := []gpus.Card{
 		0: {
 			Cardseries: "amdinstinctmi250(mcm)oamacmba",
 			Cardmodel:  "0x740c",
@@ -186,9 +188,9 @@ func TestCollectWithAdditionalLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, metricfixtures.GPULabels("gpu_power_cap", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "", "value-i", "value-ii"}),
 		metricfixtures.ConstGaugeMetric("gpu_power_cap", 0.0003, []string{"gpu_power_cap", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
 
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "", "value-1", "value-2"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, metricfixtures.GPULabels("gpu_power", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "", "value-i", "value-ii"}),
-		metricfixtures.ConstCounterMetric("gpu_power", 0.000301, []string{"gpu_power", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "", "value-1", "value-2"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, metricfixtures.GPULabels("gpu_power_watts", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "", "value-i", "value-ii"}),
+		metricfixtures.ConstGaugeMetric("gpu_power_watts", 0.000301, []string{"gpu_power_watts", "productname", "device"}, []string{"2", "amdinstinctmi250(mcm)oamacmba", "amd2"}),
 
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature", "label_1", "label_2"), []string{"0", "amdinstinctmi250(mcm)oamacmba", "amd0", "pod-ii", "container-1", "team-b", "", "value-1", "value-2"}),
 		metricfixtures.ConstGaugeMetric("gpu_current_temperature", 0.302, metricfixtures.GPULabels("gpu_current_temperature", "label_1", "label_2"), []string{"1", "amdinstinctmi250(mcm)oamacmba", "amd1", "pod-c", "container-1", "team-2", "", "value-i", "value-ii"}),
@@ -215,6 +217,7 @@ func TestCollectWithAdditionalLabels(t *testing.T) {
 		metricfixtures.ConstGaugeMetric("num_threads_per_core", 0, []string{"num_threads_per_core"}, []string{""}),
 		metricfixtures.ConstGaugeMetric("num_gpus", 3, []string{"num_gpus"}, []string{""}),
 	}
+	want = append(want, makeGPUInfoMetricsFixture(t, cardsInfo)...)
 
 	// When
 	go func() {
@@ -239,7 +242,7 @@ func TestDescribe(t *testing.T) {
 		fakekubelet.WithAMDCustomResourceNames([]string{"amd-custom-resource-name"}),
 	)
 
-	cardsInfo := [24]gpus.Card{
+	cardsInfo := []gpus.Card{
 		0: {
 			Cardseries: "amdinstinctmi250(mcm)oamacmba",
 			Cardmodel:  "0x740c",
@@ -300,41 +303,66 @@ func TestDescribe(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
-func makeAMDDataFuncFixture(t *testing.T) func() gpus.AMDParams {
-	return func() gpus.AMDParams {
+func makeGPUInfoMetricsFixture(t *testing.T, cards []gpus.Card) []prometheus.Metric {
+	t.Helper()
+
+	labels := []string{
+		"gpu_info", "productname", "device", "sku", "guid", "unique_id", "pci_bus", "partition_id",
+		"vbios_version", "driver_version", "gfx_version",
+		"smc_firmware_version", "mec_firmware_version", "sdma_firmware_version", "psp_firmware_version",
+	}
+	numaLabels := []string{"gpu_numa_info", "productname", "device", "pci_bus", "numa_node", "socket", "local_cpulist", "root_complex"}
+
+	var result []prometheus.Metric
+
+	for i, card := range cards {
+		labelValues := []string{
+			strconv.Itoa(i), card.Cardseries, "amd" + strconv.Itoa(i), card.CardSKU, card.CardGUID, "", card.PCIBus, "0",
+			"", "", "", "", "", "", "",
+		}
+		result = append(result, metricfixtures.ConstGaugeMetric("gpu_info", 1, labels, labelValues))
+		result = append(result, metricfixtures.ConstGaugeMetric("gpu_numa_info", 1, numaLabels,
+			[]string{strconv.Itoa(i), card.Cardseries, "amd" + strconv.Itoa(i), card.PCIBus, "", "", "", ""}))
+	}
+
+	return result
+}
+
+func makeAMDDataFuncFixture(t *testing.T) func() *gpus.AMDParams {
+	return func() *gpus.AMDParams {
 		t.Helper()
 
 		amdParams := gpus.AMDParams{}
 		amdParams.Init()
 
-		amdParams.NumGPUs = 3
-		amdParams.GPUDevID[0] = float64(0)
-		amdParams.GPUPowerCap[0] = float64(300)
-		amdParams.GPUPower[0] = float64(301)
-		amdParams.GPUTemperature[0] = float64(302)
-		amdParams.GPUSCLK[0] = float64(303)
-		amdParams.GPUMCLK[0] = float64(304)
-		amdParams.GPUUsage[0] = float64(305)
-		amdParams.GPUMemoryUsage[0] = float64(306)
-
-		amdParams.GPUDevID[1] = float64(0)
-		amdParams.GPUPowerCap[1] = float64(300)
-		amdParams.GPUPower[1] = float64(301)
-		amdParams.GPUTemperature[1] = float64(302)
-		amdParams.GPUSCLK[1] = float64(303)
-		amdParams.GPUMCLK[1] = float64(304)
-		amdParams.GPUUsage[1] = float64(305)
-		amdParams.GPUMemoryUsage[1] = float64(306)
-
-		amdParams.GPUDevID[2] = float64(0)
-		amdParams.GPUPowerCap[2] = float64(300)
-		amdParams.GPUPower[2] = float64(301)
-		amdParams.GPUTemperature[2] = float64(302)
-		amdParams.GPUSCLK[2] = float64(303)
-		amdParams.GPUMCLK[2] = float64(304)
-		amdParams.GPUUsage[2] = float64(305)
-		amdParams.GPUMemoryUsage[2] = float64(306)
-
-		return amdParams
+		amdParams.ResizeGPUs(3)
+		amdParams.GPUDevID[0] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[0] = gpus.NewReading(300)
+		amdParams.GPUPower[0] = gpus.NewReading(301)
+		amdParams.GPUTemperature[0] = gpus.NewReading(302)
+		amdParams.GPUSCLK[0] = gpus.NewReading(303)
+		amdParams.GPUMCLK[0] = gpus.NewReading(304)
+		amdParams.GPUUsage[0] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[0] = gpus.NewReading(306)
+
+		amdParams.GPUDevID[1] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[1] = gpus.NewReading(300)
+		amdParams.GPUPower[1] = gpus.NewReading(301)
+		amdParams.GPUTemperature[1] = gpus.NewReading(302)
+		amdParams.GPUSCLK[1] = gpus.NewReading(303)
+		amdParams.GPUMCLK[1] = gpus.NewReading(304)
+		amdParams.GPUUsage[1] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[1] = gpus.NewReading(306)
+
+		amdParams.GPUDevID[2] = gpus.NewReading(0)
+		amdParams.GPUPowerCap[2] = gpus.NewReading(300)
+		amdParams.GPUPower[2] = gpus.NewReading(301)
+		amdParams.GPUTemperature[2] = gpus.NewReading(302)
+		amdParams.GPUSCLK[2] = gpus.NewReading(303)
+		amdParams.GPUMCLK[2] = gpus.NewReading(304)
+		amdParams.GPUUsage[2] = gpus.NewReading(305)
+		amdParams.GPUMemoryUsage[2] = gpus.NewReading(306)
+
+		return &amdParams
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"net"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/gpus"
 	podbus "github.com/openinnovationai/k8s-amd-exporter/internal/exporters/domain/pods"
@@ -46,11 +48,21 @@ type Client struct {
 	nodeName            string
 	podName             string
 	podNamespace        string
+	// containers caches the containers of the pods of the node indexed by container ID, missingContainers
+	// contains the IDs not found by the last listing and containersListed is the time of the last listing.
+	containersMutex   sync.Mutex
+	containers        map[string]podbus.PodInfo
+	missingContainers map[string]struct{}
+	containersListed  time.Time
 }
 
 const (
 	unixProtocol             = "unix"
 	socketPathDefault string = "/var/lib/kubelet/pod-resources/kubelet.sock"
+	// container ids reported in pod status are prefixed by the runtime, e.g. containerd://<id>.
+	containerIDSchemeSeparator string = "://"
+	// containersTTL is the time containers are cached, so that pods deleted or relabelled are eventually refreshed.
+	containersTTL = 5 * time.Minute
 )
 
 func NewClient(settings *Setup) *Client {
@@ -245,6 +257,93 @@ func (c *Client) getPodLabelsOnNode(ctx context.Context, pods []podbus.PodInfo,
 	return toPodsMap(items, customLabels), nil
 }
 
+// GetContainers gets the containers with the given IDs running within the preconfigured node, the ID is
+// the one used by the container runtime without its scheme. Containers of the node are cached, pods are
+// only listed again when the cache expires or when a given ID was neither found nor missing in the last
+// listing, so containers outside pods do not make every call list pods.
+func (c *Client) GetContainers(ctx context.Context, containerIDs, customLabels []string) (map[string]podbus.PodInfo, error) {
+	result := make(map[string]podbus.PodInfo)
+
+	if c.nodeName == "" {
+		return result, nil
+	}
+
+	c.containersMutex.Lock()
+	defer c.containersMutex.Unlock()
+
+	if c.containersExpired(containerIDs) {
+		containers, err := c.listContainers(ctx, customLabels)
+		if err != nil {
+			return nil, err
+		}
+
+		c.containers = containers
+		c.missingContainers = make(map[string]struct{})
+		c.containersListed = time.Now()
+	}
+
+	for _, containerID := range containerIDs {
+		container, exist := c.containers[containerID]
+		if !exist {
+			c.missingContainers[containerID] = struct{}{}
+
+			continue
+		}
+
+		result[containerID] = container
+	}
+
+	return result, nil
+}
+
+// containersExpired reports whether cached containers should be listed again to find the given container IDs.
+func (c *Client) containersExpired(containerIDs []string) bool {
+	if c.containers == nil || time.Since(c.containersListed) > containersTTL {
+		return true
+	}
+
+	return slices.ContainsFunc(containerIDs, func(containerID string) bool {
+		_, known := c.containers[containerID]
+		_, missing := c.missingContainers[containerID]
+
+		return !known && !missing
+	})
+}
+
+// listContainers lists the pods of the preconfigured node and indexes their containers by container ID.
+func (c *Client) listContainers(ctx context.Context, customLabels []string) (map[string]podbus.PodInfo, error) {
+	podList, err := c.k8sClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", c.nodeName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get pods from node: %w", err)
+	}
+
+	result := make(map[string]podbus.PodInfo)
+
+	for index := range podList.Items {
+		pod := &podList.Items[index]
+		labels := selectLabels(pod.Labels, customLabels)
+
+		for _, status := range slices.Concat(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses) {
+			_, containerID, found := strings.Cut(status.ContainerID, containerIDSchemeSeparator)
+			if !found || containerID == "" {
+				continue
+			}
+
+			result[containerID] = podbus.PodInfo{
+				Name:      pod.Name,
+				Namespace: pod.Namespace,
+				Container: status.Name,
+				NodeName:  c.nodeName,
+				Labels:    labels,
+			}
+		}
+	}
+
+	return result, nil
+}
+
 func toPodsMap(podList []corev1.Pod, requiredLabels []string) map[string]podbus.Labels {
 	result := make(map[string]podbus.Labels)
 
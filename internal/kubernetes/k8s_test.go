@@ -343,3 +343,152 @@ func TestGetPodsLabelsWithinNode(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, want, got)
 }
+
+func TestGetContainers(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	existingPods := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "pod-1",
+				Namespace: "team-a",
+				Labels: map[string]string{
+					"key":     "value",
+					"label-1": "value-1",
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "init", ContainerID: "containerd://c0ffee"},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "trainer", ContainerID: "containerd://0b5e4c71"},
+					{Name: "waiting"},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "pod-2",
+				Namespace: "team-b",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "inference", ContainerID: "cri-o://f3c2a1b0"},
+				},
+			},
+		},
+	}
+
+	logger := testlogs.NewLogger()
+	k8sClient := fake.NewClientset(existingPods...)
+	setup := kubernetes.Setup{
+		Logger:    logger,
+		K8SClient: k8sClient,
+		NodeName:  "node-1",
+	}
+
+	kubeClient := kubernetes.NewClient(&setup)
+
+	want := map[string]pods.PodInfo{
+		"c0ffee": {
+			Name:      "pod-1",
+			Namespace: "team-a",
+			Container: "init",
+			NodeName:  "node-1",
+			Labels:    pods.Labels{"label-1": "value-1"},
+		},
+		"0b5e4c71": {
+			Name:      "pod-1",
+			Namespace: "team-a",
+			Container: "trainer",
+			NodeName:  "node-1",
+			Labels:    pods.Labels{"label-1": "value-1"},
+		},
+		"f3c2a1b0": {
+			Name:      "pod-2",
+			Namespace: "team-b",
+			Container: "inference",
+			NodeName:  "node-1",
+			Labels:    pods.Labels{},
+		},
+	}
+
+	// When
+	got, err := kubeClient.GetContainers(ctx, []string{"0b5e4c71", "c0ffee", "deadbeef", "f3c2a1b0"}, []string{"label-1"})
+
+	// Then
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestGetContainersListsPodsForUnknownContainers(t *testing.T) {
+	// Given
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	existingPods := []runtime.Object{
+		&corev1.Pod{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "pod-1",
+				Namespace: "team-a",
+			},
+			Spec: corev1.PodSpec{
+				NodeName: "node-1",
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "trainer", ContainerID: "containerd://0b5e4c71"},
+				},
+			},
+		},
+	}
+
+	k8sClient := fake.NewClientset(existingPods...)
+	setup := kubernetes.Setup{
+		Logger:    testlogs.NewLogger(),
+		K8SClient: k8sClient,
+		NodeName:  "node-1",
+	}
+
+	kubeClient := kubernetes.NewClient(&setup)
+
+	listings := func() int {
+		var count int
+
+		for _, action := range k8sClient.Actions() {
+			if action.GetVerb() == "list" {
+				count++
+			}
+		}
+
+		return count
+	}
+
+	// When
+	_, err := kubeClient.GetContainers(ctx, []string{"0b5e4c71"}, nil)
+	require.NoError(t, err)
+	_, err = kubeClient.GetContainers(ctx, []string{"0b5e4c71"}, nil)
+	require.NoError(t, err)
+	knownListings := listings()
+
+	_, err = kubeClient.GetContainers(ctx, []string{"0b5e4c71", "deadbeef"}, nil)
+	require.NoError(t, err)
+	got, err := kubeClient.GetContainers(ctx, []string{"0b5e4c71", "deadbeef"}, nil)
+	require.NoError(t, err)
+
+	// Then
+	assert.Equal(t, 1, knownListings)
+	assert.Equal(t, 2, listings())
+	assert.Equal(t, map[string]pods.PodInfo{
+		"0b5e4c71": {Name: "pod-1", Namespace: "team-a", Container: "trainer", NodeName: "node-1", Labels: pods.Labels{}},
+	}, got)
+}
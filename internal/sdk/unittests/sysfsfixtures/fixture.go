@@ -0,0 +1,65 @@
+package sysfsfixtures
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// WriteFiles creates given files below root directory, keys are relative paths
+// and values are files content.
+func WriteFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		path := filepath.Join(root, name)
+
+		err := os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = os.WriteFile(path, []byte(content), 0o600)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// Symlink creates a symbolic link below root directory pointing to target, both paths
+// are relative to root.
+func Symlink(t *testing.T, root, target, link string) {
+	t.Helper()
+
+	linkPath := filepath.Join(root, link)
+
+	err := os.MkdirAll(filepath.Dir(linkPath), 0o755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = os.Symlink(filepath.Join(root, target), linkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AMDGPUDevice creates an amdgpu card in drm class directory which device is
+// located at the given pci bus address, returning the device directory relative to root.
+func AMDGPUDevice(t *testing.T, root, cardName, pciBus string, files map[string]string) string {
+	t.Helper()
+
+	devicePath := filepath.Join("devices", "pci0000:00", pciBus)
+
+	deviceFiles := map[string]string{
+		"vendor": "0x1002\n",
+	}
+	for name, content := range files {
+		deviceFiles[name] = content
+	}
+
+	WriteFiles(t, filepath.Join(root, devicePath), deviceFiles)
+	Symlink(t, root, devicePath, filepath.Join("class", "drm", cardName, "device"))
+
+	return devicePath
+}